@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// AddressPool cycles through the recipient addresses of the bank sends
+type AddressPool struct {
+	addresses []string
+	next      atomic.Uint64
+}
+
+// NewAddressPool creates a pool cycling through the given addresses
+func NewAddressPool(addresses []string) *AddressPool {
+	return &AddressPool{addresses: addresses}
+}
+
+// Next returns the next address of the pool, starting over after the last one. It is safe for concurrent use.
+func (p *AddressPool) Next() string {
+	i := p.next.Add(1) - 1
+	return p.addresses[i%uint64(len(p.addresses))]
+}
+
+// Len returns the number of addresses of the pool
+func (p *AddressPool) Len() int {
+	return len(p.addresses)
+}
+
+// loadAddressPool reads the addresses of a file, one bech32 address per line, skipping blank lines.
+// Every address must be a valid account address with the chain bech32 prefix.
+func loadAddressPool(path, bech32Prefix string) (*AddressPool, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read address pool file: %w", err)
+	}
+
+	var addresses []string
+	for i, line := range strings.Split(string(bz), "\n") {
+		address := strings.TrimSpace(line)
+		if address == "" {
+			continue
+		}
+
+		accAddr, err := sdk.GetFromBech32(address, bech32Prefix)
+		if err == nil {
+			err = sdk.VerifyAddressFormat(accAddr)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d of address pool file %s: invalid address %s: %w", i+1, path, address, err)
+		}
+
+		addresses = append(addresses, address)
+	}
+
+	if len(addresses) == 0 {
+		return nil, fmt.Errorf("address pool file %s is empty", path)
+	}
+
+	return NewAddressPool(addresses), nil
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"gotest.tools/v3/assert"
+)
+
+func TestAddressPoolNext(t *testing.T) {
+	pool := NewAddressPool([]string{"a", "b", "c"})
+
+	var got []string
+	for range 7 {
+		got = append(got, pool.Next())
+	}
+	assert.DeepEqual(t, got, []string{"a", "b", "c", "a", "b", "c", "a"})
+}
+
+func TestAddressPoolNextConcurrent(t *testing.T) {
+	pool := NewAddressPool([]string{"a", "b"})
+
+	var (
+		mu     sync.Mutex
+		counts = make(map[string]int)
+		wg     sync.WaitGroup
+	)
+	for range 100 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			address := pool.Next()
+
+			mu.Lock()
+			counts[address]++
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	// Each address is picked evenly
+	assert.DeepEqual(t, counts, map[string]int{"a": 50, "b": 50})
+}
+
+func TestLoadAddressPool(t *testing.T) {
+	alice := sdk.MustBech32ifyAddressBytes("cosmos", make([]byte, 20))
+	bob := sdk.MustBech32ifyAddressBytes("cosmos", append(make([]byte, 19), 1))
+	osmo := sdk.MustBech32ifyAddressBytes("osmo", make([]byte, 20))
+
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+		wantErr string
+	}{
+		{
+			name:    "addresses",
+			content: alice + "\n" + bob + "\n",
+			want:    []string{alice, bob},
+		},
+		{
+			name:    "blank lines and spaces",
+			content: "\n  " + alice + "  \r\n\n" + bob,
+			want:    []string{alice, bob},
+		},
+		{
+			name:    "invalid address",
+			content: alice + "\nnot-an-address\n",
+			wantErr: "line 2 of address pool file",
+		},
+		{
+			name:    "wrong prefix",
+			content: alice + "\n\n" + osmo,
+			wantErr: "line 3 of address pool file",
+		},
+		{
+			name:    "empty",
+			content: "\n\n",
+			wantErr: "is empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "addresses.txt")
+			assert.NilError(t, os.WriteFile(path, []byte(tt.content), 0o644))
+
+			pool, err := loadAddressPool(path, "cosmos")
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, pool.addresses, tt.want)
+		})
+	}
+}
+
+func TestLoadAddressPoolMissingFile(t *testing.T) {
+	_, err := loadAddressPool(filepath.Join(t.TempDir(), "missing.txt"), "cosmos")
+	assert.ErrorContains(t, err, "failed to read address pool file")
+}
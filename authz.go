@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	authztypes "github.com/cosmos/cosmos-sdk/x/authz"
+)
+
+// validateAuthzGranter checks that the authz granter is a valid bech32 address
+func validateAuthzGranter(address string) error {
+	if _, _, err := bech32.DecodeAndConvert(address); err != nil {
+		return fmt.Errorf("invalid authz granter address %s: %w", address, err)
+	}
+
+	return nil
+}
+
+// parseAuthzGranter decodes the authz granter address, which must use the chain bech32 prefix
+func parseAuthzGranter(address, bech32Prefix string) (sdk.AccAddress, error) {
+	granter, err := sdk.GetFromBech32(address, bech32Prefix)
+	if err != nil {
+		return nil, fmt.Errorf("invalid authz granter address %s: %w", address, err)
+	}
+
+	return granter, nil
+}
+
+// msgSender returns the address the messages are sent from: the authz granter when set, the account otherwise
+func msgSender(config Config, accountAddr string) string {
+	if config.AuthzGranter != "" {
+		return config.AuthzGranter
+	}
+
+	return accountAddr
+}
+
+// buildMsg returns the message to broadcast. When an authz granter is set, the message
+// is wrapped in a MsgExec executed by the grantee on behalf of the granter.
+func buildMsg(config Config, grantee string, msg sdk.Msg) (sdk.Msg, error) {
+	if config.AuthzGranter == "" {
+		return msg, nil
+	}
+
+	msgs, err := txtypes.SetMsgs([]sdk.Msg{msg})
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap message in authz exec: %w", err)
+	}
+
+	return &authztypes.MsgExec{
+		Grantee: grantee,
+		Msgs:    msgs,
+	}, nil
+}
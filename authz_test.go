@@ -0,0 +1,57 @@
+package main
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authztypes "github.com/cosmos/cosmos-sdk/x/authz"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"gotest.tools/v3/assert"
+)
+
+func TestValidateAuthzGranter(t *testing.T) {
+	granter, err := sdk.Bech32ifyAddressBytes("cosmos", sdk.AccAddress("granter_____________"))
+	assert.NilError(t, err)
+
+	assert.NilError(t, validateAuthzGranter(granter))
+	assert.ErrorContains(t, validateAuthzGranter("cosmos1invalid"), "invalid authz granter address")
+
+	_, err = parseAuthzGranter(granter, "cosmos")
+	assert.NilError(t, err)
+	_, err = parseAuthzGranter(granter, "osmo")
+	assert.ErrorContains(t, err, "invalid authz granter address")
+}
+
+func TestBuildMsg(t *testing.T) {
+	const (
+		grantee = "cosmos1grantee"
+		granter = "cosmos1granter"
+	)
+
+	sendMsg := &banktypes.MsgSend{
+		FromAddress: granter,
+		ToAddress:   granter,
+		Amount:      sdk.NewCoins(sdk.NewInt64Coin("uatom", 1)),
+	}
+
+	// Without an authz granter, the message is sent as is
+	msg, err := buildMsg(Config{}, grantee, sendMsg)
+	assert.NilError(t, err)
+	assert.Equal(t, msg, sdk.Msg(sendMsg))
+
+	// With an authz granter, the message is wrapped in a MsgExec of the grantee
+	msg, err = buildMsg(Config{AuthzGranter: granter}, grantee, sendMsg)
+	assert.NilError(t, err)
+
+	execMsg, ok := msg.(*authztypes.MsgExec)
+	assert.Assert(t, ok)
+	assert.Equal(t, execMsg.Grantee, grantee)
+	assert.Equal(t, len(execMsg.Msgs), 1)
+	assert.Equal(t, execMsg.Msgs[0].TypeUrl, sdk.MsgTypeURL(sendMsg))
+	assert.Equal(t, execMsg.Msgs[0].GetCachedValue(), any(sendMsg))
+}
+
+func TestMsgSender(t *testing.T) {
+	assert.Equal(t, msgSender(Config{}, "cosmos1grantee"), "cosmos1grantee")
+	assert.Equal(t, msgSender(Config{AuthzGranter: "cosmos1granter"}, "cosmos1grantee"), "cosmos1granter")
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ignite/cli/v29/ignite/pkg/cosmosaccount"
+)
+
+const (
+	// backupKeyExtension is the extension of the account entries of a keyring backup
+	backupKeyExtension = ".key"
+	// backupMaxKeySize caps the size of an account entry read from a keyring backup
+	backupMaxKeySize = 64 << 10
+)
+
+// backupRegistry lists, exports and imports keyring accounts
+type backupRegistry interface {
+	List() ([]cosmosaccount.Account, error)
+	Export(name, passphrase string) (string, error)
+	Import(name, secret, passphrase string) (cosmosaccount.Account, error)
+}
+
+// errEmptyBackupPassphrase is returned when backing up or restoring the keyring without a passphrase
+var errEmptyBackupPassphrase = fmt.Errorf("backup passphrase is required, with --passphrase or --%s", flagFromEnv)
+
+// resolveBackupPassphrase returns the backup passphrase, given with --passphrase or read from the environment variable named by --from-env
+func resolveBackupPassphrase(passphrase, fromEnv string) (string, error) {
+	if fromEnv == "" {
+		return passphrase, nil
+	}
+
+	if passphrase != "" {
+		return "", fmt.Errorf("--passphrase and --%s are mutually exclusive", flagFromEnv)
+	}
+
+	passphrase, ok := os.LookupEnv(fromEnv)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", fromEnv)
+	}
+
+	return passphrase, nil
+}
+
+// BackupKeyring exports all accounts of the keyring as private keys armored with passphrase into a tar.gz archive at path,
+// one <account-name>.key entry per account
+func BackupKeyring(registry backupRegistry, path, passphrase string) (err error) {
+	if passphrase == "" {
+		return errEmptyBackupPassphrase
+	}
+
+	accounts, err := registry.List()
+	if err != nil {
+		return fmt.Errorf("failed to list accounts: %w", err)
+	}
+	if len(accounts) == 0 {
+		return errors.New("no accounts to back up")
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create backup file: %w", err)
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil && closeErr != nil {
+			err = fmt.Errorf("failed to close backup file: %w", closeErr)
+		}
+		// Never leave a partial backup behind
+		if err != nil {
+			_ = os.Remove(path)
+		}
+	}()
+
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	for _, account := range accounts {
+		armored, err := registry.Export(account.Name, passphrase)
+		if err != nil {
+			return fmt.Errorf("failed to export account '%s': %w", account.Name, err)
+		}
+
+		header := &tar.Header{
+			Name:    account.Name + backupKeyExtension,
+			Mode:    0o600,
+			Size:    int64(len(armored)),
+			ModTime: time.Now(),
+		}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return fmt.Errorf("failed to write account '%s': %w", account.Name, err)
+		}
+		if _, err := tarWriter.Write([]byte(armored)); err != nil {
+			return fmt.Errorf("failed to write account '%s': %w", account.Name, err)
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return fmt.Errorf("failed to write backup archive: %w", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return fmt.Errorf("failed to write backup archive: %w", err)
+	}
+
+	fmt.Printf("✅ Backed up %d accounts to %s\n", len(accounts), path)
+	return nil
+}
+
+// RestoreKeyring imports all accounts of a tar.gz archive created by BackupKeyring with the same passphrase.
+// Accounts that already exist in the keyring are skipped.
+func RestoreKeyring(registry backupRegistry, path, passphrase string) error {
+	if passphrase == "" {
+		return errEmptyBackupPassphrase
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open backup file: %w", err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return fmt.Errorf("failed to read backup archive: %w", err)
+	}
+	defer func() {
+		_ = gzipReader.Close()
+	}()
+
+	var restored, skipped int
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read backup archive: %w", err)
+		}
+
+		if header.Typeflag != tar.TypeReg || strings.ContainsAny(header.Name, `/\`) || !strings.HasSuffix(header.Name, backupKeyExtension) {
+			return fmt.Errorf("unexpected entry %q in backup archive, must be <account-name>%s", header.Name, backupKeyExtension)
+		}
+		name := strings.TrimSuffix(header.Name, backupKeyExtension)
+		if err := validateAccountName(name); err != nil {
+			return fmt.Errorf("invalid account name %q in backup archive: %w", name, err)
+		}
+
+		armored, err := io.ReadAll(io.LimitReader(tarReader, backupMaxKeySize))
+		if err != nil {
+			return fmt.Errorf("failed to read account '%s': %w", name, err)
+		}
+
+		if _, err := registry.Import(name, string(armored), passphrase); err != nil {
+			if errors.Is(err, cosmosaccount.ErrAccountExists) {
+				fmt.Printf("⚠️ Skipping account '%s', it already exists\n", name)
+				skipped++
+				continue
+			}
+			return fmt.Errorf("failed to import account '%s': %w", name, err)
+		}
+		restored++
+	}
+
+	fmt.Printf("✅ Restored %d accounts from %s (%d skipped)\n", restored, path, skipped)
+	return nil
+}
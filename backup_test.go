@@ -0,0 +1,158 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ignite/cli/v29/ignite/pkg/cosmosaccount"
+	"gotest.tools/v3/assert"
+)
+
+// testBackupPassphrase encrypts the keyring backups of the tests
+const testBackupPassphrase = "correct horse battery staple"
+
+func TestBackupAndRestoreKeyring(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "backup.tar.gz")
+
+	registry, err := cosmosaccount.NewInMemory(cosmosaccount.WithBech32Prefix("cosmos"))
+	assert.NilError(t, err)
+
+	alice, _, err := registry.Create("alice")
+	assert.NilError(t, err)
+	bob, _, err := registry.Create("bob")
+	assert.NilError(t, err)
+
+	assert.NilError(t, BackupKeyring(registry, path, testBackupPassphrase))
+
+	// An existing backup is never overwritten
+	assert.ErrorContains(t, BackupKeyring(registry, path, testBackupPassphrase), "failed to create backup file")
+
+	restored, err := cosmosaccount.NewInMemory(cosmosaccount.WithBech32Prefix("cosmos"))
+	assert.NilError(t, err)
+	// Existing accounts are skipped
+	_, _, err = restored.Create("bob")
+	assert.NilError(t, err)
+
+	// The backup can only be restored with its passphrase
+	assert.ErrorContains(t, RestoreKeyring(restored, path, "wrong passphrase"), "failed to import account 'alice'")
+	assert.ErrorContains(t, RestoreKeyring(restored, path, ""), "backup passphrase is required")
+
+	assert.NilError(t, RestoreKeyring(restored, path, testBackupPassphrase))
+
+	restoredAlice, err := restored.GetByName("alice")
+	assert.NilError(t, err)
+	assert.Equal(t, mustAddress(t, restoredAlice), mustAddress(t, alice))
+
+	restoredBob, err := restored.GetByName("bob")
+	assert.NilError(t, err)
+	assert.Assert(t, mustAddress(t, restoredBob) != mustAddress(t, bob))
+}
+
+func TestBackupEmptyKeyring(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "backup.tar.gz")
+
+	registry, err := cosmosaccount.NewInMemory(cosmosaccount.WithBech32Prefix("cosmos"))
+	assert.NilError(t, err)
+
+	assert.ErrorContains(t, BackupKeyring(registry, path, testBackupPassphrase), "no accounts to back up")
+	_, err = os.Stat(path)
+	assert.Assert(t, os.IsNotExist(err))
+}
+
+func TestBackupKeyringEmptyPassphrase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "backup.tar.gz")
+
+	registry, err := cosmosaccount.NewInMemory(cosmosaccount.WithBech32Prefix("cosmos"))
+	assert.NilError(t, err)
+	_, _, err = registry.Create("alice")
+	assert.NilError(t, err)
+
+	assert.ErrorContains(t, BackupKeyring(registry, path, ""), "backup passphrase is required")
+	_, err = os.Stat(path)
+	assert.Assert(t, os.IsNotExist(err))
+}
+
+func TestResolveBackupPassphrase(t *testing.T) {
+	t.Setenv("SPAMTX_TEST_BACKUP_PASSPHRASE", "from env")
+
+	tests := []struct {
+		name       string
+		passphrase string
+		fromEnv    string
+		want       string
+		wantErr    string
+	}{
+		{name: "flag", passphrase: "from flag", want: "from flag"},
+		{name: "environment variable", fromEnv: "SPAMTX_TEST_BACKUP_PASSPHRASE", want: "from env"},
+		{name: "both", passphrase: "from flag", fromEnv: "SPAMTX_TEST_BACKUP_PASSPHRASE", wantErr: "mutually exclusive"},
+		{name: "unset environment variable", fromEnv: "SPAMTX_TEST_UNSET", wantErr: "is not set"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveBackupPassphrase(tt.passphrase, tt.fromEnv)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, got, tt.want)
+		})
+	}
+}
+
+func TestRestoreKeyringInvalidEntry(t *testing.T) {
+	tests := []struct {
+		name    string
+		entry   string
+		wantErr string
+	}{
+		{name: "nested entry", entry: "keys/alice.key", wantErr: "unexpected entry"},
+		{name: "wrong extension", entry: "alice.txt", wantErr: "unexpected entry"},
+		{name: "invalid account name", entry: "a.key", wantErr: "invalid account name"},
+		{name: "invalid key", entry: "alice.key", wantErr: "failed to import account 'alice'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "backup.tar.gz")
+			writeTestArchive(t, path, tt.entry, "not an armored key")
+
+			registry, err := cosmosaccount.NewInMemory(cosmosaccount.WithBech32Prefix("cosmos"))
+			assert.NilError(t, err)
+
+			assert.ErrorContains(t, RestoreKeyring(registry, path, testBackupPassphrase), tt.wantErr)
+		})
+	}
+}
+
+// writeTestArchive writes a tar.gz archive with a single entry
+func writeTestArchive(t *testing.T, path, name, content string) {
+	t.Helper()
+
+	file, err := os.Create(path)
+	assert.NilError(t, err)
+	defer func() {
+		_ = file.Close()
+	}()
+
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+	assert.NilError(t, tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0o600, Size: int64(len(content))}))
+	_, err = tarWriter.Write([]byte(content))
+	assert.NilError(t, err)
+	assert.NilError(t, tarWriter.Close())
+	assert.NilError(t, gzipWriter.Close())
+}
+
+// mustAddress returns the cosmos address of an account
+func mustAddress(t *testing.T, account cosmosaccount.Account) string {
+	t.Helper()
+
+	address, err := account.Address("cosmos")
+	assert.NilError(t, err)
+	return address
+}
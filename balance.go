@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ignite/cli/v29/ignite/pkg/cosmosclient"
+)
+
+// errStopBalance is the cause of a run stopped because the account balance fell below the stop balance
+var errStopBalance = errors.New("account balance below the stop balance")
+
+// balanceFetcher returns the current balance of the account
+type balanceFetcher func(ctx context.Context) (sdk.Coins, error)
+
+// balanceResponse is the answer of the balance monitor to a balance request
+type balanceResponse struct {
+	balance sdk.Coins
+	err     error
+}
+
+// balanceMonitor queries the account balance on behalf of the sending goroutines.
+// Requests are served one at a time by Run, so that concurrent senders never query the node at once.
+type balanceMonitor struct {
+	fetch    balanceFetcher
+	requests chan chan balanceResponse
+}
+
+// newBalanceMonitor creates a balance monitor querying the balance with fetch
+func newBalanceMonitor(fetch balanceFetcher) *balanceMonitor {
+	return &balanceMonitor{
+		fetch:    fetch,
+		requests: make(chan chan balanceResponse),
+	}
+}
+
+// Run answers the balance requests until the context is cancelled
+func (m *balanceMonitor) Run(ctx context.Context) {
+	for {
+		select {
+		case reply := <-m.requests:
+			balance, err := m.fetch(ctx)
+			reply <- balanceResponse{balance: balance, err: err}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Balance requests the current balance from Run
+func (m *balanceMonitor) Balance(ctx context.Context) (sdk.Coins, error) {
+	reply := make(chan balanceResponse, 1)
+	select {
+	case m.requests <- reply:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	select {
+	case resp := <-reply:
+		return resp.balance, resp.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// bankBalanceFetcher queries the bank balance of address
+func bankBalanceFetcher(client cosmosclient.Client, address string) balanceFetcher {
+	return func(ctx context.Context) (sdk.Coins, error) {
+		return client.BankBalances(ctx, address, nil)
+	}
+}
+
+// checkBalanceEvery wraps send to check the account balance every interval successful transactions.
+// It warns when the balance is below minBalance and calls stop when it is below stopBalance, either may be empty.
+func checkBalanceEvery(monitor *balanceMonitor, interval uint64, minBalance, stopBalance sdk.Coins, send sendFunc, stop context.CancelCauseFunc) sendFunc {
+	var sent atomic.Uint64
+
+	return func(ctx context.Context, txNum, sequence uint64) error {
+		if err := send(ctx, txNum, sequence); err != nil {
+			return err
+		}
+
+		if sent.Add(1)%interval != 0 {
+			return nil
+		}
+
+		balance, err := monitor.Balance(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.Warn("⚠️ Failed to query account balance", "error", err)
+			}
+			return nil
+		}
+
+		if shortfall := balanceShortfall(balance, stopBalance); !shortfall.IsZero() {
+			stop(fmt.Errorf("%w of %s: has %s, missing %s", errStopBalance, stopBalance, formatBalance(balance), shortfall))
+			return nil
+		}
+		if shortfall := balanceShortfall(balance, minBalance); !shortfall.IsZero() {
+			logger.Warn("⚠️ Account balance below the minimum balance", "balance", formatBalance(balance), "min_balance", minBalance, "missing", shortfall)
+		}
+
+		return nil
+	}
+}
+
+// computeSendAllAmount returns what is left of the balance once the fees are paid, to drain the account.
+// It fails when the balance does not cover the fees or nothing is left after them.
+func computeSendAllAmount(balance, fees sdk.Coins) (sdk.Coins, error) {
+	amount, negative := balance.SafeSub(fees...)
+	if negative {
+		return nil, fmt.Errorf("balance of %s does not cover the fees of %s", formatBalance(balance), fees)
+	}
+	if amount.IsZero() {
+		return nil, fmt.Errorf("balance of %s leaves nothing to send after the fees of %s", formatBalance(balance), fees)
+	}
+
+	return amount, nil
+}
+
+// formatBalance formats the balance, printing 0 for an empty balance
+func formatBalance(balance sdk.Coins) string {
+	if balance.IsZero() {
+		return "0"
+	}
+	return balance.String()
+}
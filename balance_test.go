@@ -0,0 +1,186 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"gotest.tools/v3/assert"
+)
+
+func TestBalanceMonitor(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var calls int
+	monitor := newBalanceMonitor(func(context.Context) (sdk.Coins, error) {
+		// Requests are served one at a time, so the count needs no lock
+		calls++
+		return sdk.NewCoins(sdk.NewInt64Coin("uatom", 1000)), nil
+	})
+	go monitor.Run(ctx)
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			balance, err := monitor.Balance(ctx)
+			assert.NilError(t, err)
+			assert.Equal(t, balance.AmountOf("uatom").Int64(), int64(1000))
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, calls, 10)
+
+	// Requests fail once the monitor is stopped
+	cancel()
+	_, err := monitor.Balance(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestCheckBalanceEvery(t *testing.T) {
+	minBalance := sdk.NewCoins(sdk.NewInt64Coin("uatom", 500))
+	stopBalance := sdk.NewCoins(sdk.NewInt64Coin("uatom", 100))
+
+	tests := []struct {
+		name         string
+		balance      int64
+		sends        int
+		interval     uint64
+		expectChecks int
+		expectStop   bool
+	}{
+		{
+			name:         "below check interval",
+			balance:      50,
+			sends:        2,
+			interval:     3,
+			expectChecks: 0,
+		},
+		{
+			name:         "balance above min balance",
+			balance:      1000,
+			sends:        6,
+			interval:     3,
+			expectChecks: 2,
+		},
+		{
+			name:         "balance below min balance",
+			balance:      200,
+			sends:        3,
+			interval:     3,
+			expectChecks: 1,
+		},
+		{
+			name:         "balance below stop balance",
+			balance:      50,
+			sends:        3,
+			interval:     3,
+			expectChecks: 1,
+			expectStop:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, stop := context.WithCancelCause(context.Background())
+			defer stop(nil)
+
+			var checks int
+			monitor := newBalanceMonitor(func(context.Context) (sdk.Coins, error) {
+				checks++
+				return sdk.NewCoins(sdk.NewInt64Coin("uatom", tt.balance)), nil
+			})
+			go monitor.Run(ctx)
+
+			send := checkBalanceEvery(monitor, tt.interval, minBalance, stopBalance, func(context.Context, uint64, uint64) error {
+				return nil
+			}, stop)
+			for i := range tt.sends {
+				assert.NilError(t, send(ctx, uint64(i), uint64(i)))
+			}
+
+			assert.Equal(t, checks, tt.expectChecks)
+			assert.Equal(t, errors.Is(context.Cause(ctx), errStopBalance), tt.expectStop)
+			if tt.expectStop {
+				assert.ErrorContains(t, context.Cause(ctx), "has 50uatom, missing 50uatom")
+			}
+		})
+	}
+}
+
+func TestCheckBalanceEverySkipsFailures(t *testing.T) {
+	ctx, stop := context.WithCancelCause(context.Background())
+	defer stop(nil)
+
+	var checks int
+	monitor := newBalanceMonitor(func(context.Context) (sdk.Coins, error) {
+		checks++
+		return nil, nil
+	})
+	go monitor.Run(ctx)
+
+	// Failed transactions do not count towards the check interval
+	send := checkBalanceEvery(monitor, 1, nil, sdk.NewCoins(sdk.NewInt64Coin("uatom", 100)), func(context.Context, uint64, uint64) error {
+		return errors.New("insufficient fees")
+	}, stop)
+	assert.ErrorContains(t, send(ctx, 0, 0), "insufficient fees")
+	assert.Equal(t, checks, 0)
+	assert.NilError(t, ctx.Err())
+}
+
+func TestComputeSendAllAmount(t *testing.T) {
+	tests := []struct {
+		name      string
+		balance   sdk.Coins
+		fees      sdk.Coins
+		expected  sdk.Coins
+		expectErr string
+	}{
+		{
+			name:     "balance above the fees",
+			balance:  sdk.NewCoins(sdk.NewInt64Coin("uatom", 1000)),
+			fees:     sdk.NewCoins(sdk.NewInt64Coin("uatom", 10)),
+			expected: sdk.NewCoins(sdk.NewInt64Coin("uatom", 990)),
+		},
+		{
+			name:     "other denoms are sent in full",
+			balance:  sdk.NewCoins(sdk.NewInt64Coin("uatom", 1000), sdk.NewInt64Coin("stake", 5)),
+			fees:     sdk.NewCoins(sdk.NewInt64Coin("uatom", 10)),
+			expected: sdk.NewCoins(sdk.NewInt64Coin("uatom", 990), sdk.NewInt64Coin("stake", 5)),
+		},
+		{
+			name:      "balance equal to the fees",
+			balance:   sdk.NewCoins(sdk.NewInt64Coin("uatom", 10)),
+			fees:      sdk.NewCoins(sdk.NewInt64Coin("uatom", 10)),
+			expectErr: "balance of 10uatom leaves nothing to send after the fees of 10uatom",
+		},
+		{
+			name:      "balance below the fees",
+			balance:   sdk.NewCoins(sdk.NewInt64Coin("uatom", 5)),
+			fees:      sdk.NewCoins(sdk.NewInt64Coin("uatom", 10)),
+			expectErr: "balance of 5uatom does not cover the fees of 10uatom",
+		},
+		{
+			name:      "empty balance",
+			fees:      sdk.NewCoins(sdk.NewInt64Coin("uatom", 10)),
+			expectErr: "balance of 0 does not cover the fees of 10uatom",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amount, err := computeSendAllAmount(tt.balance, tt.fees)
+			if tt.expectErr != "" {
+				assert.ErrorContains(t, err, tt.expectErr)
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.DeepEqual(t, amount, tt.expected)
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// benchmarkMaxErrorRate is the error rate above which a stage is unstable and the benchmark stops
+const benchmarkMaxErrorRate = 0.1
+
+// StageResult is the outcome of a benchmark stage sending at a constant target rate
+type StageResult struct {
+	TPS       int     `json:"tps"`
+	Sent      uint64  `json:"sent"`
+	Failed    uint64  `json:"failed"`
+	ErrorRate float64 `json:"error_rate"`
+	ActualTPS float64 `json:"actual_tps"`
+}
+
+// BenchmarkResult is the outcome of a benchmark, MaxStableTPS is 0 when no stage was stable
+type BenchmarkResult struct {
+	MaxStableTPS int           `json:"max_stable_tps"`
+	Stages       []StageResult `json:"stages"`
+}
+
+// benchmarkStages returns the target rates of the stages, starting at 1 TPS and doubling up to maxTPS
+func benchmarkStages(maxTPS int) []int {
+	var stages []int
+	for tps := 1; tps <= maxTPS; tps *= 2 {
+		stages = append(stages, tps)
+	}
+
+	return stages
+}
+
+// runBenchmark measures the maximum stable rate the node accepts and prints the result
+func runBenchmark(ctx context.Context, config Config, maxTPS int, output string) error {
+	session, err := prepareSpam(ctx, config)
+	if err != nil {
+		return err
+	}
+	defer session.Close()
+
+	stages := benchmarkStages(maxTPS)
+	logger.Info("🏎️ Benchmarking", "stages", len(stages), "from_tps", stages[0], "to_tps", stages[len(stages)-1], "stage_duration", config.StageDuration)
+
+	result := benchmarkLoop(ctx, config, stages, session.pool, session.send)
+	if ctx.Err() != nil {
+		logger.Info("🛑 Benchmark interrupted, reporting the completed stages")
+	}
+
+	return printBenchmarkResult(result, output)
+}
+
+// benchmarkLoop sends transactions at each stage rate for config.StageDuration,
+// stopping after the first stage whose error rate exceeds benchmarkMaxErrorRate.
+// Transactions cancelled at the end of a stage are not counted as failures.
+func benchmarkLoop(ctx context.Context, config Config, stages []int, pool *SequencePool, send sendFunc) BenchmarkResult {
+	var result BenchmarkResult
+
+	for _, tps := range stages {
+		stageCtx, cancel := context.WithTimeout(ctx, config.StageDuration)
+
+		var sent, failed atomic.Uint64
+		stageSend := func(ctx context.Context, txNum, sequence uint64) error {
+			err := send(ctx, txNum, sequence)
+			switch {
+			case err == nil:
+				sent.Add(1)
+			case stageCtx.Err() == nil:
+				failed.Add(1)
+			}
+			return err
+		}
+
+		stageConfig := config
+		stageConfig.TPS = uint64(tps)
+		stageConfig.Concurrent = 1
+		stageConfig.Count = 0
+		stageConfig.MaxErrors = 0
+		stageConfig.LogInterval = 0
+
+		logger.Info("🚀 Stage started", "tps", tps)
+		start := time.Now()
+		_, _ = spamLoop(stageCtx, stageConfig, pool, stageSend, nil)
+		elapsed := time.Since(start)
+		cancel()
+
+		// Discard the stage cut short by an interrupt
+		if ctx.Err() != nil {
+			break
+		}
+
+		stage := newStageResult(tps, sent.Load(), failed.Load(), elapsed)
+		result.Stages = append(result.Stages, stage)
+		logger.Info("📊 Stage done", "tps", tps, "sent", stage.Sent, "failed", stage.Failed, "error_rate", fmt.Sprintf("%.1f%%", stage.ErrorRate*100), "actual_tps", fmt.Sprintf("%.1f", stage.ActualTPS))
+
+		if stage.ErrorRate > benchmarkMaxErrorRate {
+			break
+		}
+		result.MaxStableTPS = tps
+	}
+
+	return result
+}
+
+// newStageResult computes the error rate and actual rate of a stage
+func newStageResult(tps int, sent, failed uint64, elapsed time.Duration) StageResult {
+	stage := StageResult{
+		TPS:    tps,
+		Sent:   sent,
+		Failed: failed,
+	}
+	if total := sent + failed; total > 0 {
+		stage.ErrorRate = float64(failed) / float64(total)
+	}
+	if elapsed > 0 {
+		stage.ActualTPS = float64(sent) / elapsed.Seconds()
+	}
+
+	return stage
+}
+
+// printBenchmarkResult prints the stages as a table highlighting the last stable one, or as JSON
+func printBenchmarkResult(result BenchmarkResult, output string) error {
+	switch output {
+	case "json":
+		bz, err := json.Marshal(result)
+		if err != nil {
+			return fmt.Errorf("failed to encode benchmark result: %w", err)
+		}
+		fmt.Println(string(bz))
+	case "text":
+		fmt.Printf("%10s %8s %8s %10s %12s\n", "TARGET TPS", "SENT", "FAILED", "ERROR RATE", "ACTUAL TPS")
+		for _, stage := range result.Stages {
+			marker := ""
+			if stage.TPS == result.MaxStableTPS {
+				marker = "  ⭐ max stable"
+			}
+			fmt.Printf("%10d %8d %8d %9.1f%% %12.1f%s\n", stage.TPS, stage.Sent, stage.Failed, stage.ErrorRate*100, stage.ActualTPS, marker)
+		}
+
+		if result.MaxStableTPS == 0 {
+			fmt.Println("No stable stage, the node rejected more than 10% of the transactions from the first stage.")
+		} else {
+			fmt.Printf("🏁 Maximum stable rate: %d TPS\n", result.MaxStableTPS)
+		}
+	default:
+		return fmt.Errorf("unknown output format %q, must be one of: text, json", output)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestBenchmarkStages(t *testing.T) {
+	tests := []struct {
+		name     string
+		maxTPS   int
+		expected []int
+	}{
+		{
+			name:     "power of two",
+			maxTPS:   16,
+			expected: []int{1, 2, 4, 8, 16},
+		},
+		{
+			name:     "not a power of two",
+			maxTPS:   100,
+			expected: []int{1, 2, 4, 8, 16, 32, 64},
+		},
+		{
+			name:     "single stage",
+			maxTPS:   1,
+			expected: []int{1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.DeepEqual(t, benchmarkStages(tt.maxTPS), tt.expected)
+		})
+	}
+}
+
+func TestNewStageResult(t *testing.T) {
+	stage := newStageResult(8, 45, 5, 5*time.Second)
+	assert.Equal(t, stage.TPS, 8)
+	assert.Equal(t, stage.ErrorRate, 0.1)
+	assert.Equal(t, stage.ActualTPS, 9.0)
+
+	empty := newStageResult(1, 0, 0, 0)
+	assert.Equal(t, empty.ErrorRate, 0.0)
+	assert.Equal(t, empty.ActualTPS, 0.0)
+}
+
+func TestBenchmarkLoop(t *testing.T) {
+	config := Config{StageDuration: 250 * time.Millisecond}
+
+	// Each stage sends with its own context, fail every transaction from the third stage
+	var mu sync.Mutex
+	var stageDone []<-chan struct{}
+	send := func(ctx context.Context, _, _ uint64) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		stage := slices.Index(stageDone, ctx.Done())
+		if stage < 0 {
+			stageDone = append(stageDone, ctx.Done())
+			stage = len(stageDone) - 1
+		}
+		if stage >= 2 {
+			return errors.New("mempool is full")
+		}
+		return nil
+	}
+
+	result := benchmarkLoop(context.Background(), config, []int{10, 20, 40, 80}, NewSequencePool(0, sequencePoolSize, sequencePoolThreshold, nil), send)
+
+	assert.Equal(t, result.MaxStableTPS, 20)
+	assert.Equal(t, len(result.Stages), 3)
+	assert.Equal(t, result.Stages[0].ErrorRate, 0.0)
+	assert.Equal(t, result.Stages[1].ErrorRate, 0.0)
+	assert.Equal(t, result.Stages[2].ErrorRate, 1.0)
+	assert.Assert(t, result.Stages[2].Failed > 0)
+}
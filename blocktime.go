@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ignite/cli/v29/ignite/pkg/cosmosclient"
+)
+
+const (
+	// DefaultBlockTimeEstimate is the default expected time between two blocks
+	DefaultBlockTimeEstimate = 6 * time.Second
+	// blockStallFactor is the number of block times without a new block after which block production is considered stalled
+	blockStallFactor = 2
+)
+
+// blockTimeFetcher returns the time of the latest block
+type blockTimeFetcher func(ctx context.Context) (time.Time, error)
+
+// latestBlockTimeFetcher queries the time of the latest block from the node status
+func latestBlockTimeFetcher(client cosmosclient.Client) blockTimeFetcher {
+	return func(ctx context.Context) (time.Time, error) {
+		status, err := client.RPC.Status(ctx)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to fetch node status: %w", err)
+		}
+
+		return status.SyncInfo.LatestBlockTime, nil
+	}
+}
+
+// blockTimeMonitor watches the block production and pushes an event to the rate controller
+// when it stalls, i.e. no block was produced for blockStallFactor block times, and when it resumes
+type blockTimeMonitor struct {
+	fetch    blockTimeFetcher
+	estimate time.Duration
+	// events receives true when block production stalls, false when it resumes
+	events chan bool
+}
+
+// newBlockTimeMonitor creates a block time monitor expecting a block every estimate
+func newBlockTimeMonitor(fetch blockTimeFetcher, estimate time.Duration) *blockTimeMonitor {
+	return &blockTimeMonitor{
+		fetch:    fetch,
+		estimate: estimate,
+		events:   make(chan bool),
+	}
+}
+
+// Events returns the channel receiving true when block production stalls, false when it resumes
+func (m *blockTimeMonitor) Events() <-chan bool {
+	return m.events
+}
+
+// Run checks the latest block time every block time estimate until the context is cancelled
+func (m *blockTimeMonitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.estimate)
+	defer ticker.Stop()
+
+	var stalled bool
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+
+		latest, err := m.fetch(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.Warn("⚠️ Failed to fetch the latest block time", "error", err)
+			}
+			continue
+		}
+
+		if isStalled := blockProductionStalled(latest, time.Now(), m.estimate); isStalled != stalled {
+			stalled = isStalled
+			select {
+			case m.events <- stalled:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// blockProductionStalled reports whether no block was produced since latest for blockStallFactor block times
+func blockProductionStalled(latest, now time.Time, estimate time.Duration) bool {
+	return now.Sub(latest) > blockStallFactor*estimate
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestBlockProductionStalled(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		latest time.Time
+		want   bool
+	}{
+		{name: "recent block", latest: now.Add(-3 * time.Second), want: false},
+		{name: "late block", latest: now.Add(-12 * time.Second), want: false},
+		{name: "stalled", latest: now.Add(-13 * time.Second), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, blockProductionStalled(tt.latest, now, 6*time.Second), tt.want)
+		})
+	}
+}
+
+func TestBlockTimeMonitor(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Blocks are produced, then stall for a few lookups, then resume
+	var (
+		mu      sync.Mutex
+		lookups int
+	)
+	monitor := newBlockTimeMonitor(func(context.Context) (time.Time, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		lookups++
+		switch {
+		case lookups == 2:
+			return time.Time{}, errors.New("connection refused")
+		case lookups >= 3 && lookups <= 5:
+			return time.Now().Add(-time.Hour), nil
+		default:
+			return time.Now(), nil
+		}
+	}, 10*time.Millisecond)
+	go monitor.Run(ctx)
+
+	// Each change of the block production is pushed once
+	for _, want := range []bool{true, false} {
+		select {
+		case stalled := <-monitor.Events():
+			assert.Equal(t, stalled, want)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no block production event, expected stalled=%t", want)
+		}
+	}
+}
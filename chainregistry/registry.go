@@ -0,0 +1,285 @@
+// Package chainregistry fetches chain information from a cosmos.directory compatible chain registry API.
+package chainregistry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ignite/cli/v29/ignite/pkg/chainregistry"
+)
+
+const (
+	// DefaultURL is the base URL of the cosmos.directory chain registry API
+	DefaultURL = "https://chains.cosmos.directory"
+	// DefaultRawURL is the base URL of the raw files of the chain registry GitHub repository, under which each branch is served
+	DefaultRawURL = "https://raw.githubusercontent.com/cosmos/chain-registry"
+)
+
+// ErrChainNotFound is returned when a chain is not in the registry
+var ErrChainNotFound = errors.New("not found in registry")
+
+// Chain is a chain of the registry
+type Chain = chainregistry.Chain
+
+// cache is the on-disk representation of the cached chain list
+type cache struct {
+	Timestamp time.Time `json:"timestamp"`
+	URL       string    `json:"url"`
+	Chains    []Chain   `json:"chains"`
+}
+
+// Registry is the list of chains of a chain registry API
+type Registry struct {
+	// URL is the base URL of the chain registry API
+	URL string
+	// Branch is the chain registry git branch whose chain.json files are fetched from RawURL instead of the API, empty for the API
+	Branch string
+	// RawURL is the base URL of the raw files of the chain registry repository, used when Branch is set
+	RawURL string
+	Chains map[string]Chain
+
+	httpClient *http.Client
+}
+
+// New creates an empty registry of the chain registry API at url
+func New(url string) *Registry {
+	return &Registry{
+		URL:    url,
+		RawURL: DefaultRawURL,
+		Chains: make(map[string]Chain),
+		httpClient: &http.Client{
+			Timeout: 5 * time.Second,
+		},
+	}
+}
+
+// ValidateURL checks that the chain registry URL is an absolute http(s) URL
+func ValidateURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid chain registry url: %w", err)
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid chain registry url %q: must be an http(s) URL", rawURL)
+	}
+
+	return nil
+}
+
+// Fetch fetches the list of chains from the registry API
+// Note, the fetched chains don't contain the full list of fields, use Enrich to complete them.
+// A branch has no chain list, its chains are fetched one by one by Get.
+func (r *Registry) Fetch() error {
+	if r.Branch != "" {
+		return nil
+	}
+
+	body, err := r.get(r.URL)
+	if err != nil {
+		return err
+	}
+
+	var cdOutput map[string]json.RawMessage
+	if err := json.Unmarshal(body, &cdOutput); err != nil {
+		return fmt.Errorf("failed to unmarshal cosmos.directory API response: %w", err)
+	}
+
+	rawChains, ok := cdOutput["chains"]
+	if !ok {
+		return fmt.Errorf("failed to get chains from response: cosmos.directory API may have changed")
+	}
+
+	var chains []Chain
+	if err := json.Unmarshal(rawChains, &chains); err != nil {
+		return fmt.Errorf("failed to unmarshal chains: %w", err)
+	}
+
+	for _, c := range chains {
+		r.Chains[c.ChainName] = c
+	}
+
+	return nil
+}
+
+// Get returns the chain with the given name, fetching its chain.json from the branch when Branch is set
+func (r *Registry) Get(name string) (Chain, error) {
+	chain, exists := r.Chains[name]
+	if exists {
+		return chain, nil
+	}
+	if r.Branch == "" {
+		return Chain{}, fmt.Errorf("chain '%s' %w", name, ErrChainNotFound)
+	}
+
+	body, err := r.get(r.chainFileURL(name))
+	if err != nil {
+		if errors.Is(err, ErrChainNotFound) {
+			return Chain{}, fmt.Errorf("chain '%s' %w branch %s", name, ErrChainNotFound, r.Branch)
+		}
+		return Chain{}, err
+	}
+
+	if err := json.Unmarshal(body, &chain); err != nil {
+		return Chain{}, fmt.Errorf("failed to unmarshal chain.json of %s: %w", name, err)
+	}
+	chain.APIs.Grpc = cleanGRPCEntries(chain.APIs.Grpc)
+	r.Chains[name] = chain
+
+	return chain, nil
+}
+
+// Enrich fetches the full chain information from the registry API.
+// The chains of a branch are complete already.
+func (r *Registry) Enrich(chain *Chain) error {
+	if r.Branch != "" {
+		return nil
+	}
+
+	body, err := r.get(fmt.Sprintf("%s/%s", strings.TrimSuffix(r.URL, "/"), chain.ChainName))
+	if err != nil {
+		return err
+	}
+
+	apiResponseType := struct {
+		Chain *Chain `json:"chain"`
+	}{
+		Chain: chain,
+	}
+
+	if err := json.Unmarshal(body, &apiResponseType); err != nil {
+		return fmt.Errorf("failed to unmarshal cosmos.directory API response: %w", err)
+	}
+
+	chain.APIs.Grpc = cleanGRPCEntries(chain.APIs.Grpc)
+
+	return nil
+}
+
+// LoadCached loads the chain list from the cache file at path.
+// It returns false when the cache does not exist, is older than ttl or was fetched from another registry.
+// The chains of a branch are never cached.
+func (r *Registry) LoadCached(path string, ttl time.Duration) (bool, error) {
+	if r.Branch != "" {
+		return false, nil
+	}
+
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to read chain registry cache: %w", err)
+	}
+
+	var c cache
+	if err := json.Unmarshal(bz, &c); err != nil {
+		return false, fmt.Errorf("failed to unmarshal chain registry cache: %w", err)
+	}
+
+	if time.Since(c.Timestamp) > ttl || c.URL != r.URL {
+		return false, nil
+	}
+
+	for _, chain := range c.Chains {
+		r.Chains[chain.ChainName] = chain
+	}
+
+	return true, nil
+}
+
+// SaveCache writes the chain list to the cache file at path, unless the chains are fetched from a branch
+func (r *Registry) SaveCache(path string) error {
+	if r.Branch != "" {
+		return nil
+	}
+
+	c := cache{
+		Timestamp: time.Now(),
+		URL:       r.URL,
+		Chains:    make([]Chain, 0, len(r.Chains)),
+	}
+	for _, chain := range r.Chains {
+		c.Chains = append(c.Chains, chain)
+	}
+
+	bz, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to marshal chain registry cache: %w", err)
+	}
+
+	if err := os.WriteFile(path, bz, 0644); err != nil {
+		return fmt.Errorf("failed to write chain registry cache: %w", err)
+	}
+
+	return nil
+}
+
+// chainFileURL returns the URL of the chain.json of the chain in the branch, i.e. <raw-url>/<branch>/<chain>/chain.json
+func (r *Registry) chainFileURL(name string) string {
+	// keep the slashes of branch names such as feat/new-chain as path separators
+	segments := strings.Split(r.Branch, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	return fmt.Sprintf("%s/%s/%s/chain.json", strings.TrimSuffix(r.RawURL, "/"), strings.Join(segments, "/"), url.PathEscape(name))
+}
+
+// get returns the body of a GET request to the registry API
+func (r *Registry) get(url string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch chains: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("chain registry returned status %d: %w", resp.StatusCode, ErrChainNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("chain registry returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	return body, nil
+}
+
+func cleanGRPCEntries(entries []chainregistry.APIProvider) []chainregistry.APIProvider {
+	cleanEntries := make([]chainregistry.APIProvider, 0)
+	for _, api := range entries {
+		// clean-up the http(s):// prefix
+		if idx := strings.Index(api.Address, "://"); idx != -1 {
+			api.Address = api.Address[idx+3:]
+		}
+		// remove trailing slashes
+		api.Address = strings.TrimSuffix(api.Address, "/")
+
+		// remove addresses without a port
+		if !strings.Contains(api.Address, ":") {
+			continue
+		}
+
+		cleanEntries = append(cleanEntries, api)
+	}
+
+	return cleanEntries
+}
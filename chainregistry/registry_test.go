@@ -0,0 +1,380 @@
+package chainregistry
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ignite/cli/v29/ignite/pkg/chainregistry"
+	"gotest.tools/v3/assert"
+)
+
+func TestCleanGRPCEntries(t *testing.T) {
+	tests := []struct {
+		name     string
+		entries  []chainregistry.APIProvider
+		expected []chainregistry.APIProvider
+	}{
+		{
+			name: "Clean entries with http:// and https://",
+			entries: []chainregistry.APIProvider{
+				{Address: "http://example1.com:1234/"},
+				{Address: "https://example2.com:5678"},
+				{Address: "http://example3.com:9012"},
+			},
+			expected: []chainregistry.APIProvider{
+				{Address: "example1.com:1234"},
+				{Address: "example2.com:5678"},
+				{Address: "example3.com:9012"},
+			},
+		},
+		{
+			name: "Remove trailing slashes",
+			entries: []chainregistry.APIProvider{
+				{Address: "http://example.com:1234/"},
+				{Address: "https://example.com:5678/"},
+			},
+			expected: []chainregistry.APIProvider{
+				{Address: "example.com:1234"},
+				{Address: "example.com:5678"},
+			},
+		},
+		{
+			name: "Filter entries without ports",
+			entries: []chainregistry.APIProvider{
+				{Address: "example.com"},
+				{Address: "example.com:1234"},
+			},
+			expected: []chainregistry.APIProvider{
+				{Address: "example.com:1234"},
+			},
+		},
+		{
+			name:     "Empty input",
+			entries:  []chainregistry.APIProvider{},
+			expected: []chainregistry.APIProvider{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Copy the entries so that modifications in one test case
+			// don't affect others
+			input := make([]chainregistry.APIProvider, len(tt.entries))
+			copy(input, tt.entries)
+
+			result := cleanGRPCEntries(input)
+
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("cleanGRPCEntries() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRegistryLoadCached(t *testing.T) {
+	writeCache := func(t *testing.T, path string, timestamp time.Time, url string) {
+		t.Helper()
+
+		bz, err := json.Marshal(cache{
+			Timestamp: timestamp,
+			URL:       url,
+			Chains: []Chain{
+				{ChainName: "cosmoshub", Bech32Prefix: "cosmos"},
+			},
+		})
+		assert.NilError(t, err)
+		assert.NilError(t, os.WriteFile(path, bz, 0644))
+	}
+
+	tests := []struct {
+		name       string
+		setup      func(t *testing.T, path string)
+		branch     string
+		wantLoaded bool
+		wantErr    bool
+	}{
+		{
+			name:       "missing cache",
+			setup:      func(t *testing.T, path string) {},
+			wantLoaded: false,
+		},
+		{
+			name: "fresh cache",
+			setup: func(t *testing.T, path string) {
+				writeCache(t, path, time.Now().Add(-10*time.Minute), DefaultURL)
+			},
+			wantLoaded: true,
+		},
+		{
+			name: "stale cache",
+			setup: func(t *testing.T, path string) {
+				writeCache(t, path, time.Now().Add(-2*time.Hour), DefaultURL)
+			},
+			wantLoaded: false,
+		},
+		{
+			name: "cache from another registry",
+			setup: func(t *testing.T, path string) {
+				writeCache(t, path, time.Now().Add(-10*time.Minute), "http://localhost:8080")
+			},
+			wantLoaded: false,
+		},
+		{
+			name: "branch",
+			setup: func(t *testing.T, path string) {
+				writeCache(t, path, time.Now().Add(-10*time.Minute), DefaultURL)
+			},
+			branch:     "add-localnet",
+			wantLoaded: false,
+		},
+		{
+			name: "corrupted cache",
+			setup: func(t *testing.T, path string) {
+				assert.NilError(t, os.WriteFile(path, []byte("not json"), 0644))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "chain-registry-cache.json")
+			tt.setup(t, path)
+
+			registry := New(DefaultURL)
+			registry.Branch = tt.branch
+			loaded, err := registry.LoadCached(path, time.Hour)
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.Equal(t, loaded, tt.wantLoaded)
+			if tt.wantLoaded {
+				assert.Equal(t, registry.Chains["cosmoshub"].Bech32Prefix, "cosmos")
+			} else {
+				assert.Equal(t, len(registry.Chains), 0)
+			}
+		})
+	}
+}
+
+func TestRegistrySaveCacheRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "chain-registry-cache.json")
+
+	registry := New(DefaultURL)
+	registry.Chains["osmosis"] = Chain{ChainName: "osmosis", Bech32Prefix: "osmo"}
+	assert.NilError(t, registry.SaveCache(path))
+
+	cached := New(DefaultURL)
+	loaded, err := cached.LoadCached(path, time.Hour)
+	assert.NilError(t, err)
+	assert.Assert(t, loaded)
+	assert.Equal(t, cached.Chains["osmosis"].Bech32Prefix, "osmo")
+}
+
+// newRegistryServer starts a mock chain registry API serving the localnet chain
+func newRegistryServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			_, _ = w.Write([]byte(`{"chains": [{"chain_name": "localnet", "bech32_prefix": "local"}]}`))
+		case "/localnet":
+			_, _ = w.Write([]byte(`{"chain": {"chain_name": "localnet", "apis": {"rpc": [{"address": "http://localhost:26657"}], "grpc": [{"address": "https://localhost:9090/"}, {"address": "localhost"}]}}}`))
+		case "/invalid":
+			_, _ = w.Write([]byte(`not json`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestRegistryFetch(t *testing.T) {
+	server := newRegistryServer(t)
+
+	tests := []struct {
+		name    string
+		url     string
+		wantErr string
+	}{
+		{
+			name: "chain list",
+			url:  server.URL + "/",
+		},
+		{
+			name:    "unexpected status",
+			url:     server.URL + "/missing",
+			wantErr: "status 404",
+		},
+		{
+			name:    "invalid response",
+			url:     server.URL + "/invalid",
+			wantErr: "failed to unmarshal",
+		},
+		{
+			name:    "missing chains",
+			url:     server.URL + "/localnet",
+			wantErr: "cosmos.directory API may have changed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := New(tt.url)
+			err := registry.Fetch()
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.Equal(t, len(registry.Chains), 1)
+			assert.Equal(t, registry.Chains["localnet"].Bech32Prefix, "local")
+		})
+	}
+}
+
+func TestRegistryGet(t *testing.T) {
+	registry := New(DefaultURL)
+	registry.Chains["osmosis"] = Chain{ChainName: "osmosis", Bech32Prefix: "osmo"}
+
+	chain, err := registry.Get("osmosis")
+	assert.NilError(t, err)
+	assert.Equal(t, chain.Bech32Prefix, "osmo")
+
+	_, err = registry.Get("cosmoshub")
+	assert.ErrorContains(t, err, "chain 'cosmoshub' not found in registry")
+	assert.ErrorIs(t, err, ErrChainNotFound)
+}
+
+func TestRegistryEnrich(t *testing.T) {
+	server := newRegistryServer(t)
+
+	registry := New(server.URL + "/")
+	assert.NilError(t, registry.Fetch())
+
+	chain, err := registry.Get("localnet")
+	assert.NilError(t, err)
+
+	assert.NilError(t, registry.Enrich(&chain))
+	assert.Equal(t, chain.Bech32Prefix, "local")
+	assert.Equal(t, len(chain.APIs.RPC), 1)
+	assert.Equal(t, chain.APIs.RPC[0].Address, "http://localhost:26657")
+	// The gRPC entries are cleaned up
+	assert.DeepEqual(t, chain.APIs.Grpc, []chainregistry.APIProvider{{Address: "localhost:9090"}})
+
+	unknown := Chain{ChainName: "unknown"}
+	assert.ErrorContains(t, registry.Enrich(&unknown), "status 404")
+}
+
+func TestRegistryBranch(t *testing.T) {
+	tests := []struct {
+		name     string
+		branch   string
+		wantPath string
+	}{
+		{
+			name:     "branch",
+			branch:   "main",
+			wantPath: "/main/localnet/chain.json",
+		},
+		{
+			name:     "branch with slash",
+			branch:   "feat/new chain",
+			wantPath: "/feat/new%20chain/localnet/chain.json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var paths []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				paths = append(paths, r.URL.EscapedPath())
+				if !strings.HasSuffix(r.URL.Path, "/localnet/chain.json") {
+					http.NotFound(w, r)
+					return
+				}
+				_, _ = w.Write([]byte(`{"chain_name": "localnet", "bech32_prefix": "local", "apis": {"rpc": [{"address": "http://localhost:26657"}], "grpc": [{"address": "https://localhost:9090/"}]}}`))
+			}))
+			t.Cleanup(server.Close)
+
+			// The API is never queried for a branch
+			registry := New("http://127.0.0.1:0")
+			registry.RawURL = server.URL + "/"
+			registry.Branch = tt.branch
+			assert.NilError(t, registry.Fetch())
+
+			chain, err := registry.Get("localnet")
+			assert.NilError(t, err)
+			assert.NilError(t, registry.Enrich(&chain))
+			assert.Equal(t, chain.Bech32Prefix, "local")
+			assert.Equal(t, chain.APIs.RPC[0].Address, "http://localhost:26657")
+			assert.DeepEqual(t, chain.APIs.Grpc, []chainregistry.APIProvider{{Address: "localhost:9090"}})
+
+			// The chain is fetched once
+			_, err = registry.Get("localnet")
+			assert.NilError(t, err)
+			assert.DeepEqual(t, paths, []string{tt.wantPath})
+
+			_, err = registry.Get("unknown")
+			assert.Assert(t, errors.Is(err, ErrChainNotFound))
+		})
+	}
+}
+
+func TestValidateURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		wantErr bool
+	}{
+		{
+			name: "default registry",
+			url:  DefaultURL,
+		},
+		{
+			name: "local registry",
+			url:  "http://localhost:8080",
+		},
+		{
+			name:    "missing scheme",
+			url:     "chains.cosmos.directory",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported scheme",
+			url:     "ftp://chains.cosmos.directory",
+			wantErr: true,
+		},
+		{
+			name:    "unparsable url",
+			url:     "http://[::1",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateURL(tt.url)
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ignite/cli/v29/ignite/pkg/cosmosclient"
+)
+
+// runPreflightChecks runs the pre-flight checks of spamTransactions without sending any transaction.
+// Each check depends on the previous ones, so the checks stop at the first failure.
+func runPreflightChecks(ctx context.Context, config Config) []validationCheck {
+	chainCheck := validationCheck{
+		Name: "chain info",
+		Fix:  fmt.Sprintf("check the chain name, or set --%s and --%s for a chain missing from the chain registry", flagRPC, flagChainPrefix),
+	}
+
+	rpcEndpoint, bech32Prefix, _, err := resolveChainEndpoint(config)
+	if err != nil {
+		chainCheck.Detail = err.Error()
+		return []validationCheck{chainCheck}
+	}
+	chainCheck.Passed = true
+	chainCheck.Detail = fmt.Sprintf("rpc %s, prefix %s", rpcEndpoint, bech32Prefix)
+	checks := []validationCheck{chainCheck}
+
+	nodeCheck := validationCheck{
+		Name: "node connection",
+		Fix:  "check that the RPC endpoint is up, or set --rpc to another endpoint",
+	}
+
+	keyringBackend, err := resolveKeyringBackend(config.KeyringBackend)
+	if err != nil {
+		nodeCheck.Detail = err.Error()
+		return append(checks, nodeCheck)
+	}
+
+	keyringDir, err := getKeyringHome()
+	if err != nil {
+		nodeCheck.Detail = fmt.Sprintf("failed to get keyring home: %v", err)
+		return append(checks, nodeCheck)
+	}
+
+	options := []cosmosclient.Option{
+		cosmosclient.WithNodeAddress(rpcEndpoint),
+		cosmosclient.WithBech32Prefix(bech32Prefix),
+		cosmosclient.WithKeyringDir(keyringDir),
+		cosmosclient.WithKeyringBackend(clientKeyringBackend(keyringBackend)),
+		cosmosclient.WithKeyringServiceName(DefaultKeyringServiceName),
+	}
+
+	// Query the node the same way spam does: over gRPC, or skipping the TLS certificate verification
+	if config.GRPC != "" {
+		grpcClient, err := newGRPCRPC(config.GRPC)
+		if err != nil {
+			nodeCheck.Detail = err.Error()
+			return append(checks, nodeCheck)
+		}
+		defer func() {
+			_ = grpcClient.Close()
+		}()
+		options = append(options, cosmosclient.WithRPCClient(grpcClient))
+	} else if config.InsecureSkipTLS {
+		warnInsecureSkipTLS()
+
+		rpc, err := newInsecureRPC(rpcEndpoint)
+		if err != nil {
+			nodeCheck.Detail = err.Error()
+			return append(checks, nodeCheck)
+		}
+		options = append(options, cosmosclient.WithRPCClient(rpc))
+	}
+
+	client, err := newSpamClient(ctx, config, options...)
+	if err != nil {
+		nodeCheck.Detail = err.Error()
+		return append(checks, nodeCheck)
+	}
+	nodeCheck.Passed = true
+	nodeCheck.Detail = fmt.Sprintf("chain-id %s", client.Context().ChainID)
+	checks = append(checks, nodeCheck)
+
+	var minBalance sdk.Coins
+	if config.MinBalance != "" {
+		if minBalance, err = parseAmount(config.MinBalance); err != nil {
+			return append(checks, validationCheck{
+				Name:   "account balance",
+				Detail: fmt.Sprintf("invalid minimum balance: %v", err),
+				Fix:    "set --min-balance to a valid amount, e.g. 1000000uatom",
+			})
+		}
+	}
+
+	return append(checks, checkAccount(ctx, client, config.Account, bech32Prefix, minBalance)...)
+}
+
+// checkAccount checks that the account is in the keyring, exists on chain, holds at least minBalance and has a sequence
+func checkAccount(ctx context.Context, client cosmosclient.Client, accountName, bech32Prefix string, minBalance sdk.Coins) []validationCheck {
+	keyringCheck := validationCheck{
+		Name: "keyring account",
+		Fix:  "create or import the account with spamtx keyring create or spamtx keyring import",
+	}
+
+	account, err := client.Account(accountName)
+	if err != nil {
+		keyringCheck.Detail = fmt.Sprintf("account '%s' not found: %v", accountName, err)
+		return []validationCheck{keyringCheck}
+	}
+
+	accountAddr, err := account.Address(bech32Prefix)
+	if err != nil {
+		keyringCheck.Detail = fmt.Sprintf("failed to get address: %v", err)
+		return []validationCheck{keyringCheck}
+	}
+	keyringCheck.Passed = true
+	keyringCheck.Detail = accountAddr
+	checks := []validationCheck{keyringCheck}
+
+	onChainCheck := validationCheck{
+		Name: "account on chain",
+		Fix:  "fund the account, so that it exists on chain",
+	}
+	if err := verifyAccountExists(ctx, client, accountAddr); err != nil {
+		onChainCheck.Detail = err.Error()
+		return append(checks, onChainCheck)
+	}
+	onChainCheck.Passed = true
+	checks = append(checks, onChainCheck)
+
+	balanceCheck := checkAccountBalance(ctx, client, accountAddr, minBalance)
+	checks = append(checks, balanceCheck)
+	if !balanceCheck.Passed {
+		return checks
+	}
+
+	sequenceCheck := validationCheck{
+		Name: "account sequence",
+		Fix:  "check that the RPC endpoint serves account queries",
+	}
+	sequence, err := fetchAccountSequence(ctx, client, accountAddr)
+	if err != nil {
+		sequenceCheck.Detail = err.Error()
+		return append(checks, sequenceCheck)
+	}
+	sequenceCheck.Passed = true
+	sequenceCheck.Detail = fmt.Sprintf("%d", sequence)
+
+	return append(checks, sequenceCheck)
+}
+
+// checkAccountBalance queries the account balance, which must not be empty and hold at least minBalance when set
+func checkAccountBalance(ctx context.Context, client cosmosclient.Client, address string, minBalance sdk.Coins) validationCheck {
+	check := validationCheck{
+		Name: "account balance",
+		Fix:  "fund the account",
+	}
+
+	queryCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	balance, err := client.BankBalances(queryCtx, address, nil)
+	if err != nil {
+		check.Detail = fmt.Sprintf("failed to query balance of %s: %v", address, err)
+		return check
+	}
+
+	if balance.IsZero() {
+		check.Detail = "the account has no balance"
+		return check
+	}
+
+	if shortfall := balanceShortfall(balance, minBalance); !shortfall.IsZero() {
+		check.Detail = fmt.Sprintf("has %s, missing %s to reach the minimum balance of %s", balance, shortfall, minBalance)
+		return check
+	}
+
+	check.Passed = true
+	check.Detail = balance.String()
+	return check
+}
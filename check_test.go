@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ignite/cli/v29/ignite/pkg/cosmosclient"
+	"gotest.tools/v3/assert"
+)
+
+func TestRunPreflightChecksCustomChain(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// A chain missing from the chain registry is checked with a custom endpoint and prefix, as spam does
+	checks := runPreflightChecks(context.Background(), Config{
+		Chain:          "privatenet",
+		Account:        "alice",
+		RPC:            "http://127.0.0.1:1",
+		Bech32Prefix:   "private",
+		KeyringBackend: DefaultKeyringBackend,
+	})
+	assert.Equal(t, len(checks), 2)
+	assert.Equal(t, checks[0].Name, "chain info")
+	assert.Assert(t, checks[0].Passed, checks[0].Detail)
+	assert.Equal(t, checks[0].Detail, "rpc http://127.0.0.1:1, prefix private")
+	assert.Equal(t, checks[1].Name, "node connection")
+	assert.Assert(t, !checks[1].Passed)
+}
+
+func TestCheckAccount(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	client, err := cosmosclient.New(context.Background(),
+		cosmosclient.WithRPCClient(newMockRPC()),
+		cosmosclient.WithBech32Prefix(mockBech32Prefix),
+		cosmosclient.WithKeyringDir(t.TempDir()),
+		cosmosclient.WithKeyringBackend(DefaultKeyringBackend),
+	)
+	assert.NilError(t, err)
+
+	// A missing keyring account stops the checks
+	checks := checkAccount(context.Background(), client, "alice", mockBech32Prefix, nil)
+	assert.Equal(t, len(checks), 1)
+	assert.Equal(t, checks[0].Name, "keyring account")
+	assert.Assert(t, !checks[0].Passed)
+
+	account, _, err := client.AccountRegistry.Create("alice")
+	assert.NilError(t, err)
+	accountAddr, err := account.Address(mockBech32Prefix)
+	assert.NilError(t, err)
+
+	// The mock node rejects account queries, so the account is not found on chain
+	checks = checkAccount(context.Background(), client, "alice", mockBech32Prefix, nil)
+	assert.Equal(t, len(checks), 2)
+	assert.Assert(t, checks[0].Passed)
+	assert.Equal(t, checks[0].Detail, accountAddr)
+	assert.Equal(t, checks[1].Name, "account on chain")
+	assert.Assert(t, !checks[1].Passed)
+}
+
+func TestCheckAccountBalance(t *testing.T) {
+	tests := []struct {
+		name       string
+		balance    sdk.Coins
+		queryErr   error
+		minBalance string
+		wantPassed bool
+		wantDetail string
+	}{
+		{
+			name:       "funded account",
+			balance:    sdk.NewCoins(sdk.NewInt64Coin("uatom", 2000)),
+			wantPassed: true,
+			wantDetail: "2000uatom",
+		},
+		{
+			name:       "above minimum",
+			balance:    sdk.NewCoins(sdk.NewInt64Coin("uatom", 2000)),
+			minBalance: "1000uatom",
+			wantPassed: true,
+			wantDetail: "2000uatom",
+		},
+		{
+			name:       "below minimum",
+			balance:    sdk.NewCoins(sdk.NewInt64Coin("uatom", 500)),
+			minBalance: "1000uatom",
+			wantDetail: "has 500uatom, missing 500uatom",
+		},
+		{
+			name:       "empty balance",
+			wantDetail: "the account has no balance",
+		},
+		{
+			name:       "query error",
+			queryErr:   errors.New("connection refused"),
+			wantDetail: "failed to query balance",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := cosmosclient.New(context.Background(),
+				cosmosclient.WithRPCClient(newMockRPC()),
+				cosmosclient.WithBankQueryClient(balanceQueryClient{balance: tt.balance, err: tt.queryErr}),
+				cosmosclient.WithKeyringDir(t.TempDir()),
+				cosmosclient.WithKeyringBackend(DefaultKeyringBackend),
+			)
+			assert.NilError(t, err)
+
+			minBalance, err := sdk.ParseCoinsNormalized(tt.minBalance)
+			assert.NilError(t, err)
+
+			check := checkAccountBalance(context.Background(), client, "cosmos1abc123", minBalance)
+			assert.Equal(t, check.Passed, tt.wantPassed, check.Detail)
+			assert.Assert(t, strings.Contains(check.Detail, tt.wantDetail), check.Detail)
+		})
+	}
+}
@@ -2,49 +2,775 @@ package main
 
 import (
 	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ignite/cli/v29/ignite/pkg/cosmosaccount"
 )
 
 var (
-	flagFrom         = "from"
-	flagFees         = "fees"
-	flagGasLimit     = "gas-limit"
-	flagMemo         = "memo"
-	flagTPS          = "tps"
-	flagRPC          = "rpc"
-	flagHeavy        = "heavy"
-	flagAddressCount = "address-count"
+	flagFrom              = "from"
+	flagFees              = "fees"
+	flagGasLimit          = "gas-limit"
+	flagGasLimitMap       = "gas-limit-map"
+	flagMemo              = "memo"
+	flagTPS               = "tps"
+	flagRPC               = "rpc"
+	flagHeavy             = "heavy"
+	flagAddressCount      = "address-count"
+	flagConsensusCheck    = "consensus-params-check"
+	flagLogInterval       = "log-interval"
+	flagMaxErrors         = "max-errors"
+	flagRequireZeroErrors = "require-zero-errors"
+	flagRegistryTTL       = "registry-ttl"
+	flagNoCache           = "no-cache"
+	flagRegistryURL       = "chain-registry-url"
+	flagRegistryBranch    = "chain-registry-branch"
+	flagLogLevel          = "log-level"
+	flagLogFile           = "log-file"
+	flagLogMaxSizeMB      = "log-max-size-mb"
+	flagFeeDenom          = "fee-denom"
+	flagPprofAddr         = "pprof-addr"
+	flagErrorFile         = "error-file"
+	flagMemoMaxLen        = "memo-max-len"
+	flagTxNote            = "tx-note"
+	flagBech32Prefix      = "bech32-prefix"
+	flagMemoTemplate      = "memo-template"
+	flagMemoFile          = "memo-file"
+	flagType              = "type"
+	flagGroupID           = "group-id"
+	flagGroupMetadata     = "group-metadata"
+	flagGroupMessageJSON  = "group-message-json"
+	flagDryRun            = "dry-run"
+	flagOTELEndpoint      = "output-opentelemetry"
+	flagMockMode          = "chain-mock-mode"
+	flagWatchBlock        = "watch-block"
+	flagWatchMempool      = "watch-mempool"
+	flagEventLog          = "event-log"
+	flagChainID           = "chain-id"
+	flagWithdrawAddress   = "withdraw-address"
+	flagSequence          = "sequence"
+	flagRetry             = "retry"
+	flagRetryDelay        = "retry-delay"
+	flagKeyringBackend    = "keyring-backend"
+	flagVaultPath         = "vault-path"
+	flagProfile           = "profile"
+	flagProfileFile       = "profile-file"
+	flagMetricsAddr       = "metrics-addr"
+	flagSignMode          = "sign-mode"
+	flagTimeoutOffset     = "timeout-height-offset"
+	flagCount             = "count"
+	flagFeePct            = "fee-pct"
+	flagAmount            = "amount"
+	flagSendAll           = "send-all"
+	flagConcurrent        = "concurrent"
+	flagProposalID        = "proposal-id"
+	flagVoteOption        = "vote-option"
+	flagHome              = "home"
+	flagGRPCAddr          = "grpc-addr"
+	flagFeeGranter        = "fee-granter"
+	flagAuthzGranter      = "authz-granter"
+	flagNoteCounter       = "note-counter"
+	flagInsecureSkipTLS   = "insecure-skip-tls"
+	flagFromEnv           = "from-env"
+	flagGasLimitAuto      = "gas-limit-auto"
+	flagGasAdjustment     = "gas-adjustment"
+	flagGasResimInterval  = "gas-resim-interval"
+	flagMaxTPS            = "max-tps"
+	flagStageDuration     = "stage-duration"
+	flagRampUp            = "ramp-up"
+	flagWalletHDPath      = "wallet-hd-path"
+	flagTxTimeout         = "tx-timeout"
+	flagBroadcastMode     = "broadcast-mode"
+	flagStartAfter        = "start-after"
+	flagWaitSync          = "wait-sync"
+	flagWaitSyncTimeout   = "wait-sync-timeout"
+	flagMinNodeVersion    = "min-node-version"
+	flagNodeVersionStrict = "node-version-strict"
+	flagStopAtHeight      = "stop-at-height"
+	flagMinBalance        = "min-balance"
+	flagStopBalance       = "stop-balance"
+	flagCheckBalance      = "check-balance-interval"
+	flagRPCPool           = "rpc-pool"
+	flagChains            = "chains"
+	flagHeightPoll        = "height-poll-interval"
+	flagRateLimitStrategy = "rate-limit-strategy"
+	flagAddressPoolFile   = "address-pool-file"
+	flagRecipientGen      = "recipient-gen"
+	flagRecipientGenSeed  = "recipient-gen-seed"
+	flagHistogram         = "histogram"
+	flagHistogramInterval = "histogram-interval"
+	flagCooldown          = "cooldown"
+	flagBatchSize         = "batch-size"
+	flagValidator         = "validator"
+	flagValidatorSrc      = "validator-src"
+	flagValidatorDst      = "validator-dst"
+	flagNonceFile         = "nonce-file"
+	flagNonceMaxAge       = "nonce-max-age"
+	flagChainPrefix       = "chain-prefix"
+	flagChainInfo         = "chain-info"
+	flagWeightMap         = "weight-map"
+	flagRandomSeed        = "random-seed"
+	flagStallTimeout      = "stall-timeout"
+	flagStallMaxCount     = "stall-max-count"
+	flagExtraMsg          = "extra-msg"
+	flagMsgsPerTx         = "msgs-per-tx"
+	flagBlockThrottle     = "block-throttle"
+	flagBlockTimeEstimate = "block-time-estimate"
+	flagSimulateOnly      = "simulate-only"
+	flagSimulateCount     = "simulate-count"
+	flagGrantee           = "grantee"
+	flagAuthzMsgType      = "authz-msg-type"
+	flagGrantExpiry       = "grant-expiry"
+)
+
+const (
+	txTypeBankSend            = "bank-send"
+	txTypeGroupSubmitProposal = "group-submit-proposal"
+	txTypeSetWithdrawAddress  = "distribution-set-withdraw-address"
+	txTypeGovVote             = "gov-vote"
+	txTypeStakingUndelegate   = "staking-undelegate"
+	txTypeStakingRedelegate   = "staking-redelegate"
+	txTypeAuthzGrant          = "authz-grant"
 )
 
+// txTypes lists the supported transaction types
+var txTypes = []string{txTypeBankSend, txTypeGroupSubmitProposal, txTypeSetWithdrawAddress, txTypeGovVote, txTypeStakingUndelegate, txTypeStakingRedelegate, txTypeAuthzGrant}
+
+// maxMsgsPerTx is the maximum number of messages batched in a transaction with --msgs-per-tx
+const maxMsgsPerTx = 100
+
 // Config holds the command line configuration
 type Config struct {
-	Chain             string
-	Account           string
-	Fees              string
-	Memo              string
-	TPS               uint64
-	GasLimit          uint64
-	RPC               string
-	Heavy             bool
-	HeavyAddressCount uint64
+	Chain    string
+	Chains   []string
+	Account  string
+	Fees     string
+	Memo     string
+	TPS      uint64
+	GasLimit uint64
+	// GasLimitMap overrides the gas limit per transaction type
+	GasLimitMap map[string]uint64
+	RPC         string
+	// Bech32Prefix is the address prefix of the chain, skipping the chain registry when an endpoint is set
+	Bech32Prefix         string
+	Heavy                bool
+	AddressPoolFile      string
+	RecipientGen         bool
+	RecipientGenSeed     uint64
+	HeavyAddressCount    uint64
+	ConsensusCheck       bool
+	LogInterval          uint64
+	MaxErrors            uint64
+	RequireZeroErrors    bool
+	MemoTemplate         string
+	MemoFile             string
+	MemoMaxLen           uint64
+	TxNote               string
+	TxType               string
+	WeightMap            map[string]uint64
+	RandomSeed           int64
+	ExtraMsg             string
+	MsgsPerTx            int
+	GroupID              uint64
+	GroupMetadata        string
+	GroupMessageJSON     string
+	DryRun               bool
+	OTELEndpoint         string
+	MockMode             bool
+	WatchBlock           bool
+	WatchMempool         bool
+	EventLog             bool
+	ChainID              string
+	WithdrawAddress      string
+	StartSequence        uint64
+	NonceFile            string
+	NonceMaxAge          time.Duration
+	SequenceOverride     bool
+	Retry                uint64
+	RetryDelay           time.Duration
+	KeyringBackend       cosmosaccount.KeyringBackend
+	VaultPath            string
+	MetricsAddr          string
+	PprofAddr            string
+	ErrorFile            string
+	SignMode             string
+	TimeoutHeightOffset  uint64
+	Count                uint64
+	FeePct               float64
+	FeeDenom             string
+	Amount               string
+	SendAll              bool
+	Concurrent           uint64
+	ProposalID           uint64
+	Validator            string
+	ValidatorSrc         string
+	ValidatorDst         string
+	Grantee              string
+	AuthzMsgType         string
+	GrantExpiry          time.Duration
+	VoteOption           string
+	GRPC                 string
+	RPCPool              string
+	FeeGranter           string
+	AuthzGranter         string
+	NoteCounter          bool
+	InsecureSkipTLS      bool
+	GasLimitAuto         bool
+	GasAdjustment        float64
+	GasResimInterval     uint64
+	StageDuration        time.Duration
+	RampUp               time.Duration
+	RateLimitStrategy    string
+	Cooldown             time.Duration
+	BatchSize            uint64
+	StallTimeout         time.Duration
+	StallMaxCount        uint64
+	BlockThrottle        bool
+	BlockTimeEstimate    time.Duration
+	TxTimeout            time.Duration
+	BroadcastMode        string
+	StartAfter           uint64
+	WaitSync             bool
+	WaitSyncTimeout      time.Duration
+	MinNodeVersion       string
+	NodeVersionStrict    bool
+	StopAtHeight         uint64
+	MinBalance           string
+	StopBalance          string
+	CheckBalanceInterval uint64
+	HeightPollInterval   uint64
+	Histogram            bool
+	HistogramInterval    time.Duration
+	SimulateOnly         bool
+	SimulateCount        int
+
+	// gasEstimator holds the simulated gas limit at runtime when GasLimitAuto is set
+	gasEstimator *gasEstimator
+	// mempoolChecker counts the transactions entering the mempool at runtime when WatchMempool is set
+	mempoolChecker *mempoolChecker
+	// latencyHistogram records the broadcast latencies at runtime when Histogram is set
+	latencyHistogram *latencyHistogram
+	// extraMsg is the decoded ExtraMsg appended to every transaction at runtime
+	extraMsg sdk.Msg
+	// blockMonitor reports the block production stalls at runtime when BlockThrottle is set
+	blockMonitor *blockTimeMonitor
+}
+
+// GasLimitForType returns the gas limit of the transaction type from the gas limit map, defaultGasLimit when not set
+func (c Config) GasLimitForType(txType string, defaultGasLimit uint64) uint64 {
+	if txType == "" {
+		txType = txTypeBankSend
+	}
+
+	if gasLimit, ok := c.GasLimitMap[txType]; ok {
+		return gasLimit
+	}
+
+	return defaultGasLimit
+}
+
+// SentTxTypes returns the transaction types sent by the run, sorted: the types of the weight map when set, the configured type otherwise
+func (c Config) SentTxTypes() []string {
+	if len(c.WeightMap) > 0 {
+		return slices.Sorted(maps.Keys(c.WeightMap))
+	}
+	if c.TxType == "" {
+		return []string{txTypeBankSend}
+	}
+
+	return []string{c.TxType}
+}
+
+// sendsTxType reports whether the run sends transactions of the type
+func (c Config) sendsTxType(txType string) bool {
+	return slices.Contains(c.SentTxTypes(), txType)
 }
 
 // validateConfig validates the configuration parameters
 func validateConfig(config Config) error {
-	if config.Chain == "" {
+	if config.Chain == "" && len(config.Chains) == 0 {
 		return errors.New("chain name is required")
 	}
+	if len(config.Chains) > 0 {
+		if err := validateChains(config); err != nil {
+			return err
+		}
+	}
 	if config.Account == "" {
 		return errors.New("account address is required")
 	}
-	if config.Fees == "" {
-		return errors.New("fees are required")
+	if config.Fees == "" && config.FeePct == 0 {
+		return errors.New("fees or fee percentage is required")
+	}
+	if config.Fees != "" && config.FeePct != 0 {
+		return errors.New("fees and fee percentage are mutually exclusive")
+	}
+	if config.FeePct < 0 {
+		return errors.New("fee percentage must be greater than 0")
+	}
+	if config.FeePct > 0 && config.Amount == "" {
+		return errors.New("fee percentage requires an amount")
+	}
+	if config.Amount != "" {
+		if _, err := parseAmount(config.Amount); err != nil {
+			return fmt.Errorf("invalid amount: %w", err)
+		}
+	}
+	if config.FeeDenom != "" {
+		if err := sdk.ValidateDenom(config.FeeDenom); err != nil {
+			return fmt.Errorf("invalid fee denom: %w", err)
+		}
+	}
+	if config.FeeGranter != "" {
+		if err := validateFeeGranter(config.FeeGranter); err != nil {
+			return err
+		}
+	}
+	if config.AuthzGranter != "" {
+		if err := validateAuthzGranter(config.AuthzGranter); err != nil {
+			return err
+		}
+	}
+	if config.Memo == "" && config.MemoTemplate == "" && config.MemoFile == "" {
+		return errors.New("memo, memo template or memo file is required")
+	}
+	if config.Memo != "" && config.MemoTemplate != "" {
+		return errors.New("memo and memo template are mutually exclusive")
 	}
-	if config.Memo == "" {
-		return errors.New("memo is required")
+	if config.MemoFile != "" && (config.Memo != "" || config.MemoTemplate != "") {
+		return errors.New("memo file is mutually exclusive with memo and memo template")
+	}
+	if len(config.TxNote) > maxTxNoteLen {
+		return fmt.Errorf("tx note is %d bytes long, above the maximum of %d bytes", len(config.TxNote), maxTxNoteLen)
+	}
+	if err := checkMemoLength(decorateMemo(config.TxNote, config.Memo), config.MemoMaxLen); err != nil {
+		return err
+	}
+	if config.NoteCounter && config.MemoTemplate != "" {
+		return errors.New("note counter and memo template are mutually exclusive")
+	}
+	if config.MemoTemplate != "" {
+		if _, err := parseMemoTemplate(config.MemoTemplate); err != nil {
+			return err
+		}
+	}
+	if err := validateEndpointConfig(config); err != nil {
+		return err
+	}
+	if config.GasLimitAuto {
+		if config.GasLimit != 0 {
+			return errors.New("gas limit and gas limit auto are mutually exclusive")
+		}
+		if config.GasAdjustment <= 0 {
+			return errors.New("gas adjustment must be greater than 0")
+		}
+		if config.MockMode {
+			return errors.New("gas limit auto and chain mock mode are mutually exclusive")
+		}
+		if len(config.GasLimitMap) > 0 {
+			return errors.New("gas limit map and gas limit auto are mutually exclusive")
+		}
+	}
+	if _, err := resolveKeyringBackend(config.KeyringBackend); err != nil {
+		return err
+	}
+	if config.KeyringBackend == keyringBackendVault && config.VaultPath == "" {
+		return errors.New("vault keyring backend requires a vault path")
+	}
+	if config.VaultPath != "" && config.KeyringBackend != keyringBackendVault {
+		return errors.New("vault path requires the vault keyring backend")
+	}
+	if _, err := parseSignMode(config.SignMode); err != nil {
+		return err
 	}
 	if config.TPS == 0 {
 		return errors.New("tps must be greater than 0")
 	}
+	if config.RampUp < 0 {
+		return errors.New("ramp up must not be negative")
+	}
+	if config.NonceFile != "" {
+		if config.DryRun {
+			return errors.New("nonce file and dry run are mutually exclusive")
+		}
+		if config.NonceMaxAge <= 0 {
+			return errors.New("nonce max age must be greater than 0")
+		}
+	}
+	if config.SimulateOnly {
+		if config.DryRun || config.MockMode {
+			return errors.New("simulate only requires a live chain, it is not supported with dry run or chain mock mode")
+		}
+		if config.SimulateCount <= 0 {
+			return errors.New("simulate count must be greater than 0")
+		}
+	}
+	if config.Cooldown < 0 {
+		return errors.New("cooldown must not be negative")
+	}
+	if config.BatchSize > 0 && config.Cooldown == 0 {
+		return errors.New("batch size requires a cooldown")
+	}
+	if config.StallTimeout < 0 {
+		return errors.New("stall timeout must not be negative")
+	}
+	if config.StallTimeout > 0 && config.StallTimeout <= time.Second/time.Duration(config.TPS) {
+		return errors.New("stall timeout must be longer than the interval between two transactions")
+	}
+	if config.BlockThrottle {
+		if config.BlockTimeEstimate <= 0 {
+			return errors.New("block time estimate must be greater than 0")
+		}
+		if config.MockMode || config.DryRun {
+			return errors.New("block throttle requires a live chain, it is not supported with chain mock mode or dry run")
+		}
+		if config.RampUp > 0 || config.RateLimitStrategy == rateLimitTokenBucket {
+			return fmt.Errorf("block throttle is only supported with the %s rate limit strategy, without ramp up", rateLimitTicker)
+		}
+	}
+	if config.HistogramInterval < 0 {
+		return errors.New("histogram interval must not be negative")
+	}
+	if config.HistogramInterval > 0 && !config.Histogram {
+		return errors.New("histogram interval requires the histogram")
+	}
+	switch config.BroadcastMode {
+	case "", broadcastModeAsync, broadcastModeSync:
+	case broadcastModeBlock:
+		if config.BlockTimeEstimate <= 0 {
+			return errors.New("block time estimate must be greater than 0")
+		}
+		if config.DryRun {
+			return errors.New("block broadcast mode and dry run are mutually exclusive")
+		}
+		if config.RampUp > 0 || config.RateLimitStrategy == rateLimitTokenBucket {
+			return fmt.Errorf("block broadcast mode is only supported with the %s rate limit strategy, without ramp up", rateLimitTicker)
+		}
+	default:
+		return fmt.Errorf("unknown broadcast mode %q, must be one of: %s, %s, %s", config.BroadcastMode, broadcastModeAsync, broadcastModeSync, broadcastModeBlock)
+	}
+
+	switch config.RateLimitStrategy {
+	case "", rateLimitTicker:
+	case rateLimitTokenBucket:
+		if config.RampUp > 0 {
+			return fmt.Errorf("ramp up is only supported with the %s rate limit strategy", rateLimitTicker)
+		}
+	default:
+		return fmt.Errorf("unknown rate limit strategy %q, must be one of: %s, %s", config.RateLimitStrategy, rateLimitTicker, rateLimitTokenBucket)
+	}
+	if config.TxTimeout != 0 && config.TxTimeout < time.Second {
+		return errors.New("tx timeout must be at least 1s")
+	}
+
+	if len(config.WeightMap) > 0 {
+		if config.GasLimitAuto {
+			return fmt.Errorf("weight map and gas limit auto are mutually exclusive, set the gas limits per transaction type with --%s", flagGasLimitMap)
+		}
+		if config.SimulateOnly {
+			return errors.New("weight map and simulate only are mutually exclusive")
+		}
+		var total uint64
+		for _, txType := range config.SentTxTypes() {
+			weight := config.WeightMap[txType]
+			if weight == 0 {
+				return fmt.Errorf("weight of %s in weight map must be greater than 0", txType)
+			}
+			if weight > math.MaxInt64-total {
+				return fmt.Errorf("sum of the weights in weight map must not exceed %d", int64(math.MaxInt64))
+			}
+			total += weight
+		}
+	}
+	if config.ExtraMsg != "" {
+		if err := validateExtraMsg(config.ExtraMsg); err != nil {
+			return err
+		}
+	}
+	if config.RandomSeed != 0 && len(config.WeightMap) == 0 {
+		return errors.New("random seed requires a weight map")
+	}
+	for _, txType := range config.SentTxTypes() {
+		if err := validateTxType(config, txType); err != nil {
+			return err
+		}
+	}
+
+	if config.Validator != "" && !config.sendsTxType(txTypeStakingUndelegate) {
+		return fmt.Errorf("validator is only supported with the %s transaction type", txTypeStakingUndelegate)
+	}
+	if (config.ValidatorSrc != "" || config.ValidatorDst != "") && !config.sendsTxType(txTypeStakingRedelegate) {
+		return fmt.Errorf("source and destination validators are only supported with the %s transaction type", txTypeStakingRedelegate)
+	}
+
+	if (config.Grantee != "" || config.AuthzMsgType != "") && !config.sendsTxType(txTypeAuthzGrant) {
+		return fmt.Errorf("grantee and authz message type are only supported with the %s transaction type", txTypeAuthzGrant)
+	}
+
+	for _, txType := range slices.Sorted(maps.Keys(config.GasLimitMap)) {
+		if !slices.Contains(txTypes, txType) {
+			return fmt.Errorf("unknown transaction type %q in gas limit map, must be one of: %s", txType, strings.Join(txTypes, ", "))
+		}
+		if config.GasLimitMap[txType] == 0 {
+			return fmt.Errorf("gas limit of %s in gas limit map must be greater than 0", txType)
+		}
+	}
+
+	if config.WithdrawAddress != "" && !config.sendsTxType(txTypeSetWithdrawAddress) {
+		return fmt.Errorf("withdraw address is only supported with the %s transaction type", txTypeSetWithdrawAddress)
+	}
+
+	if config.StartAfter > 0 && config.MockMode {
+		return errors.New("start after and chain mock mode are mutually exclusive")
+	}
+	if config.WaitSync && config.WaitSyncTimeout <= 0 {
+		return errors.New("wait sync timeout must be greater than 0")
+	}
+	if config.MinNodeVersion != "" {
+		if config.MockMode {
+			return errors.New("min node version and chain mock mode are mutually exclusive")
+		}
+		if _, err := canonicalVersion(config.MinNodeVersion); err != nil {
+			return fmt.Errorf("invalid min node version: %w", err)
+		}
+	}
+	if config.NodeVersionStrict && config.MinNodeVersion == "" {
+		return errors.New("node version strict requires a min node version")
+	}
+	if config.MinBalance != "" {
+		if config.MockMode {
+			return errors.New("min balance and chain mock mode are mutually exclusive")
+		}
+		if _, err := parseAmount(config.MinBalance); err != nil {
+			return fmt.Errorf("invalid min balance: %w", err)
+		}
+	}
+	if config.StopBalance != "" {
+		if config.CheckBalanceInterval == 0 {
+			return errors.New("stop balance requires a check balance interval")
+		}
+		if _, err := parseAmount(config.StopBalance); err != nil {
+			return fmt.Errorf("invalid stop balance: %w", err)
+		}
+	}
+	if config.CheckBalanceInterval > 0 {
+		if config.MockMode {
+			return errors.New("check balance interval and chain mock mode are mutually exclusive")
+		}
+		if config.MinBalance == "" && config.StopBalance == "" {
+			return errors.New("check balance interval requires a min balance or a stop balance")
+		}
+	}
+	if config.StopAtHeight > 0 {
+		if config.MockMode {
+			return errors.New("stop at height and chain mock mode are mutually exclusive")
+		}
+		if config.HeightPollInterval == 0 {
+			return errors.New("height poll interval must be greater than 0")
+		}
+		if config.StopAtHeight <= config.StartAfter {
+			return errors.New("stop at height must be greater than start after")
+		}
+	}
+
+	if config.WatchBlock && config.DryRun {
+		return errors.New("watch block and dry run are mutually exclusive")
+	}
+
+	if config.WatchMempool && (config.DryRun || config.MockMode || config.GRPC != "") {
+		return errors.New("watch mempool requires an RPC endpoint, it is not supported with dry run, chain mock mode or a grpc endpoint")
+	}
+	if config.EventLog && (config.DryRun || config.MockMode || config.GRPC != "") {
+		return errors.New("event log requires an RPC endpoint, it is not supported with dry run, chain mock mode or a grpc endpoint")
+	}
+
+	if config.MsgsPerTx < 1 {
+		return errors.New("msgs per tx must be at least 1")
+	}
+	if config.MsgsPerTx > maxMsgsPerTx {
+		return fmt.Errorf("msgs per tx must not exceed %d", maxMsgsPerTx)
+	}
+	if config.MsgsPerTx > 1 {
+		if !config.sendsTxType(txTypeBankSend) {
+			return fmt.Errorf("msgs per tx is only supported with the %s transaction type", txTypeBankSend)
+		}
+		if config.Heavy {
+			return errors.New("msgs per tx and heavy mode are mutually exclusive")
+		}
+	}
+
+	if config.Heavy && !config.sendsTxType(txTypeBankSend) {
+		return fmt.Errorf("heavy mode is only supported with the %s transaction type", txTypeBankSend)
+	}
+	if config.AddressPoolFile != "" {
+		if !config.sendsTxType(txTypeBankSend) {
+			return fmt.Errorf("address pool file is only supported with the %s transaction type", txTypeBankSend)
+		}
+		if config.Heavy {
+			return errors.New("address pool file and heavy mode are mutually exclusive")
+		}
+	}
+	if config.RecipientGen {
+		if !config.sendsTxType(txTypeBankSend) {
+			return fmt.Errorf("recipient gen is only supported with the %s transaction type", txTypeBankSend)
+		}
+		if config.Heavy || config.AddressPoolFile != "" {
+			return errors.New("recipient gen is mutually exclusive with heavy mode and address pool file")
+		}
+	}
+	if config.RecipientGenSeed != 0 && !config.RecipientGen {
+		return errors.New("recipient gen seed requires recipient gen")
+	}
+	if config.SendAll {
+		if !config.sendsTxType(txTypeBankSend) {
+			return fmt.Errorf("send all is only supported with the %s transaction type", txTypeBankSend)
+		}
+		if config.Heavy || config.Amount != "" || config.MsgsPerTx > 1 {
+			return errors.New("send all is mutually exclusive with heavy mode, amount and msgs per tx")
+		}
+		if config.DryRun || config.MockMode {
+			return errors.New("send all is not supported with dry run and chain mock mode")
+		}
+		// The balance must be up to date before each transaction, or every pending one tries to send it all
+		if config.BroadcastMode != broadcastModeBlock || concurrency(config) > 1 {
+			return fmt.Errorf("send all requires the %s broadcast mode, without concurrent transactions", broadcastModeBlock)
+		}
+	}
+
+	return nil
+}
+
+// validateEndpointConfig validates the flags selecting the endpoint of the chain, shared by spam and check
+func validateEndpointConfig(config Config) error {
+	if config.RPCPool != "" {
+		if config.RPC != "" || config.GRPC != "" {
+			return errors.New("rpc pool and custom rpc or grpc endpoint are mutually exclusive")
+		}
+		if config.MockMode || config.DryRun {
+			return errors.New("rpc pool and chain mock mode or dry run are mutually exclusive")
+		}
+		if _, err := parseRPCPool(config.RPCPool); err != nil {
+			return err
+		}
+	}
+	if config.Bech32Prefix != "" && config.RPC == "" && config.GRPC == "" && config.RPCPool == "" {
+		return fmt.Errorf("chain prefix requires --%s, --%s or --%s", flagRPC, flagGRPCAddr, flagRPCPool)
+	}
+	if config.ChainID != "" && config.RPC == "" && config.GRPC == "" && config.RPCPool == "" {
+		return errors.New("chain id requires a custom rpc, grpc or rpc pool endpoint")
+	}
+	if config.InsecureSkipTLS {
+		if err := checkInsecureSkipTLS(config.ChainID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateChains validates a multi-chain run, whose chains are resolved from the chain registry
+func validateChains(config Config) error {
+	seen := make(map[string]bool)
+	for _, chain := range config.Chains {
+		if chain == "" {
+			return errors.New("chains must not contain an empty chain name")
+		}
+		if seen[chain] {
+			return fmt.Errorf("chain %s is listed twice", chain)
+		}
+		seen[chain] = true
+	}
+
+	if config.TPS < uint64(len(config.Chains)) {
+		return fmt.Errorf("tps must be at least the number of chains (%d), it is divided between them", len(config.Chains))
+	}
+	if config.Count > 0 && config.Count < uint64(len(config.Chains)) {
+		return fmt.Errorf("count must be at least the number of chains (%d), it is divided between them", len(config.Chains))
+	}
+	if config.RPC != "" || config.GRPC != "" || config.RPCPool != "" {
+		return errors.New("chains are resolved from the chain registry, they are not supported with a custom endpoint")
+	}
+	if config.NonceFile != "" || config.ErrorFile != "" || config.MetricsAddr != "" || config.Histogram || config.WatchMempool || config.EventLog ||
+		config.StopAtHeight > 0 || config.CheckBalanceInterval > 0 || config.BlockThrottle || config.SimulateOnly {
+		return errors.New("chains are not supported with nonce file, error file, metrics, histogram, watch mempool, event log, stop at height, balance checks, block throttle or simulate only")
+	}
+
+	return nil
+}
+
+// validateTxType validates the parameters of a transaction type sent by the run
+func validateTxType(config Config, txType string) error {
+	switch txType {
+	case "", txTypeBankSend:
+	case txTypeGroupSubmitProposal:
+		if config.GroupID == 0 {
+			return errors.New("group id must be greater than 0")
+		}
+		if config.GroupMessageJSON == "" {
+			return errors.New("group message json file is required")
+		}
+	case txTypeSetWithdrawAddress:
+	case txTypeGovVote:
+		if config.ProposalID == 0 {
+			return errors.New("proposal id must be greater than 0")
+		}
+		if _, err := parseVoteOption(config.VoteOption); err != nil {
+			return err
+		}
+	case txTypeStakingUndelegate:
+		if config.Validator == "" {
+			return fmt.Errorf("%s requires a validator", txTypeStakingUndelegate)
+		}
+		if err := validateValidatorAddress(flagValidator, config.Validator); err != nil {
+			return err
+		}
+		if config.Amount == "" {
+			return fmt.Errorf("%s requires an amount", txTypeStakingUndelegate)
+		}
+		if _, err := stakingAmount(config.Amount); err != nil {
+			return err
+		}
+	case txTypeStakingRedelegate:
+		if config.ValidatorSrc == "" || config.ValidatorDst == "" {
+			return fmt.Errorf("%s requires a source and a destination validator", txTypeStakingRedelegate)
+		}
+		if err := validateValidatorAddress(flagValidatorSrc, config.ValidatorSrc); err != nil {
+			return err
+		}
+		if err := validateValidatorAddress(flagValidatorDst, config.ValidatorDst); err != nil {
+			return err
+		}
+		if config.ValidatorSrc == config.ValidatorDst {
+			return errors.New("source and destination validators must be different")
+		}
+		if config.Amount == "" {
+			return fmt.Errorf("%s requires an amount", txTypeStakingRedelegate)
+		}
+		if _, err := stakingAmount(config.Amount); err != nil {
+			return err
+		}
+	case txTypeAuthzGrant:
+		if config.Grantee == "" {
+			return fmt.Errorf("%s requires a grantee", txTypeAuthzGrant)
+		}
+		if err := validateGrantee(config.Grantee); err != nil {
+			return err
+		}
+		if err := validateAuthzMsgType(config.AuthzMsgType); err != nil {
+			return err
+		}
+		if config.GrantExpiry <= 0 {
+			return errors.New("grant expiry must be greater than 0")
+		}
+	default:
+		return fmt.Errorf("unknown transaction type %q, must be one of: %s", txType, strings.Join(txTypes, ", "))
+	}
 
 	return nil
 }
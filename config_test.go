@@ -1,7 +1,10 @@
 package main
 
 import (
+	"math"
+	"strings"
 	"testing"
+	"time"
 
 	"gotest.tools/v3/assert"
 )
@@ -22,73 +25,2249 @@ func TestValidateConfig(t *testing.T) {
 				TPS:               10,
 				Heavy:             true,
 				HeavyAddressCount: 69,
+				MsgsPerTx:         1,
 			},
 			wantErr: false,
 		},
 		{
 			name: "valid config with custom RPC",
 			config: Config{
-				Chain:   "cosmoshub",
-				Account: "cosmos1abc123",
-				Fees:    "1000uatom",
-				Memo:    "test memo",
-				TPS:     10,
-				RPC:     "http://localhost:26657",
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				RPC:       "http://localhost:26657",
+				MsgsPerTx: 1,
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid dry-run config",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				DryRun:    true,
+				MsgsPerTx: 1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid chain-id with rpc",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				RPC:       "http://localhost:26657",
+				ChainID:   "cosmoshub-4",
+				MsgsPerTx: 1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "chain-id without rpc",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				ChainID:   "cosmoshub-4",
+				MsgsPerTx: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid set withdraw address config",
+			config: Config{
+				Chain:           "cosmoshub",
+				Account:         "cosmos1abc123",
+				Fees:            "1000uatom",
+				Memo:            "test memo",
+				TPS:             10,
+				TxType:          txTypeSetWithdrawAddress,
+				WithdrawAddress: "cosmos1withdraw",
+				MsgsPerTx:       1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid gov vote config",
+			config: Config{
+				Chain:      "cosmoshub",
+				Account:    "cosmos1abc123",
+				Fees:       "1000uatom",
+				Memo:       "test memo",
+				TPS:        10,
+				TxType:     txTypeGovVote,
+				ProposalID: 42,
+				VoteOption: "no-with-veto",
+				MsgsPerTx:  1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "gov vote without proposal id",
+			config: Config{
+				Chain:      "cosmoshub",
+				Account:    "cosmos1abc123",
+				Fees:       "1000uatom",
+				Memo:       "test memo",
+				TPS:        10,
+				TxType:     txTypeGovVote,
+				VoteOption: "yes",
+				MsgsPerTx:  1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "gov vote with invalid vote option",
+			config: Config{
+				Chain:      "cosmoshub",
+				Account:    "cosmos1abc123",
+				Fees:       "1000uatom",
+				Memo:       "test memo",
+				TPS:        10,
+				TxType:     txTypeGovVote,
+				ProposalID: 42,
+				VoteOption: "maybe",
+				MsgsPerTx:  1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid fee granter config",
+			config: Config{
+				Chain:      "cosmoshub",
+				Account:    "cosmos1abc123",
+				Fees:       "1000uatom",
+				Memo:       "test memo",
+				TPS:        10,
+				FeeGranter: "cosmos1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5lzv7xu",
+				MsgsPerTx:  1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid fee granter",
+			config: Config{
+				Chain:      "cosmoshub",
+				Account:    "cosmos1abc123",
+				Fees:       "1000uatom",
+				Memo:       "test memo",
+				TPS:        10,
+				FeeGranter: "cosmos1invalid",
+				MsgsPerTx:  1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid authz granter config",
+			config: Config{
+				Chain:        "cosmoshub",
+				Account:      "cosmos1abc123",
+				Fees:         "1000uatom",
+				Memo:         "test memo",
+				TPS:          10,
+				AuthzGranter: "cosmos1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5lzv7xu",
+				MsgsPerTx:    1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid authz granter",
+			config: Config{
+				Chain:        "cosmoshub",
+				Account:      "cosmos1abc123",
+				Fees:         "1000uatom",
+				Memo:         "test memo",
+				TPS:          10,
+				AuthzGranter: "cosmos1invalid",
+				MsgsPerTx:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid note counter config",
+			config: Config{
+				Chain:       "cosmoshub",
+				Account:     "cosmos1abc123",
+				Fees:        "1000uatom",
+				Memo:        "test memo",
+				TPS:         10,
+				NoteCounter: true,
+				MsgsPerTx:   1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "note counter with memo template",
+			config: Config{
+				Chain:        "cosmoshub",
+				Account:      "cosmos1abc123",
+				Fees:         "1000uatom",
+				MemoTemplate: "tx {{.TxNum}}",
+				TPS:          10,
+				NoteCounter:  true,
+				MsgsPerTx:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "insecure skip tls on testnet",
+			config: Config{
+				Chain:           "cosmoshub",
+				Account:         "cosmos1abc123",
+				Fees:            "1000uatom",
+				Memo:            "test memo",
+				TPS:             10,
+				RPC:             "https://localhost:26657",
+				ChainID:         "testnet-1",
+				InsecureSkipTLS: true,
+				MsgsPerTx:       1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "insecure skip tls on mainnet",
+			config: Config{
+				Chain:           "cosmoshub",
+				Account:         "cosmos1abc123",
+				Fees:            "1000uatom",
+				Memo:            "test memo",
+				TPS:             10,
+				RPC:             "https://localhost:26657",
+				ChainID:         "cosmoshub-4",
+				InsecureSkipTLS: true,
+				MsgsPerTx:       1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid gas limit auto config",
+			config: Config{
+				Chain:         "cosmoshub",
+				Account:       "cosmos1abc123",
+				Fees:          "1000uatom",
+				Memo:          "test memo",
+				TPS:           10,
+				GasLimitAuto:  true,
+				GasAdjustment: 1.3,
+				MsgsPerTx:     1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "gas limit auto with gas limit",
+			config: Config{
+				Chain:         "cosmoshub",
+				Account:       "cosmos1abc123",
+				Fees:          "1000uatom",
+				Memo:          "test memo",
+				TPS:           10,
+				GasLimit:      200000,
+				GasLimitAuto:  true,
+				GasAdjustment: 1.3,
+				MsgsPerTx:     1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "gas limit auto without gas adjustment",
+			config: Config{
+				Chain:        "cosmoshub",
+				Account:      "cosmos1abc123",
+				Fees:         "1000uatom",
+				Memo:         "test memo",
+				TPS:          10,
+				GasLimitAuto: true,
+				MsgsPerTx:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid tx timeout",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				TxTimeout: time.Second,
+				MsgsPerTx: 1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "tx timeout below 1s",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				TxTimeout: 500 * time.Millisecond,
+				MsgsPerTx: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "start after with chain mock mode",
+			config: Config{
+				Chain:      "cosmoshub",
+				Account:    "cosmos1abc123",
+				Fees:       "1000uatom",
+				Memo:       "test memo",
+				TPS:        10,
+				StartAfter: 100,
+				MockMode:   true,
+				MsgsPerTx:  1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "stop at height",
+			config: Config{
+				Chain:              "cosmoshub",
+				Account:            "cosmos1abc123",
+				Fees:               "1000uatom",
+				Memo:               "test memo",
+				TPS:                10,
+				StartAfter:         100,
+				StopAtHeight:       200,
+				HeightPollInterval: 100,
+				MsgsPerTx:          1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "stop at height without poll interval",
+			config: Config{
+				Chain:        "cosmoshub",
+				Account:      "cosmos1abc123",
+				Fees:         "1000uatom",
+				Memo:         "test memo",
+				TPS:          10,
+				StopAtHeight: 200,
+				MsgsPerTx:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "stop at height before start after",
+			config: Config{
+				Chain:              "cosmoshub",
+				Account:            "cosmos1abc123",
+				Fees:               "1000uatom",
+				Memo:               "test memo",
+				TPS:                10,
+				StartAfter:         200,
+				StopAtHeight:       200,
+				HeightPollInterval: 100,
+				MsgsPerTx:          1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "stop at height with chain mock mode",
+			config: Config{
+				Chain:              "cosmoshub",
+				Account:            "cosmos1abc123",
+				Fees:               "1000uatom",
+				Memo:               "test memo",
+				TPS:                10,
+				StopAtHeight:       200,
+				HeightPollInterval: 100,
+				MockMode:           true,
+				MsgsPerTx:          1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "amount",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Amount:    "1uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				MsgsPerTx: 1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid amount",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Amount:    "uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				MsgsPerTx: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "min balance",
+			config: Config{
+				Chain:      "cosmoshub",
+				Account:    "cosmos1abc123",
+				Fees:       "1000uatom",
+				Memo:       "test memo",
+				TPS:        10,
+				MinBalance: "1000000uatom",
+				MsgsPerTx:  1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid min balance",
+			config: Config{
+				Chain:      "cosmoshub",
+				Account:    "cosmos1abc123",
+				Fees:       "1000uatom",
+				Memo:       "test memo",
+				TPS:        10,
+				MinBalance: "1000000",
+				MsgsPerTx:  1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "min balance with chain mock mode",
+			config: Config{
+				Chain:      "cosmoshub",
+				Account:    "cosmos1abc123",
+				Fees:       "1000uatom",
+				Memo:       "test memo",
+				TPS:        10,
+				MinBalance: "1000000uatom",
+				MockMode:   true,
+				MsgsPerTx:  1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "rpc pool",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				RPCPool:   "http://node1:26657,http://node2:26657",
+				ChainID:   "cosmoshub-4",
+				MsgsPerTx: 1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "rpc pool with rpc",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				RPC:       "http://node1:26657",
+				RPCPool:   "http://node1:26657,http://node2:26657",
+				MsgsPerTx: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "rpc pool with empty endpoint",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				RPCPool:   "http://node1:26657,",
+				MsgsPerTx: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "rpc pool with chain mock mode",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				RPCPool:   "http://node1:26657,http://node2:26657",
+				MockMode:  true,
+				MsgsPerTx: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "memo file",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				MemoFile:  "memos.txt",
+				TPS:       10,
+				MsgsPerTx: 1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "memo file with memo",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				MemoFile:  "memos.txt",
+				TPS:       10,
+				MsgsPerTx: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "memo file with memo template",
+			config: Config{
+				Chain:        "cosmoshub",
+				Account:      "cosmos1abc123",
+				Fees:         "1000uatom",
+				MemoTemplate: "tx {{.TxNum}}",
+				MemoFile:     "memos.txt",
+				TPS:          10,
+				MsgsPerTx:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "fee denom",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom,500stake",
+				FeeDenom:  "uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				MsgsPerTx: 1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid fee denom",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				FeeDenom:  "1atom",
+				Memo:      "test memo",
+				TPS:       10,
+				MsgsPerTx: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "memo within max length",
+			config: Config{
+				Chain:      "cosmoshub",
+				Account:    "cosmos1abc123",
+				Fees:       "1000uatom",
+				Memo:       "test memo",
+				MemoMaxLen: 9,
+				TPS:        10,
+				MsgsPerTx:  1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "memo above max length",
+			config: Config{
+				Chain:      "cosmoshub",
+				Account:    "cosmos1abc123",
+				Fees:       "1000uatom",
+				Memo:       "test memo",
+				MemoMaxLen: 8,
+				TPS:        10,
+				MsgsPerTx:  1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "watch mempool",
+			config: Config{
+				Chain:        "cosmoshub",
+				Account:      "cosmos1abc123",
+				Fees:         "1000uatom",
+				Memo:         "test memo",
+				TPS:          10,
+				WatchMempool: true,
+				MsgsPerTx:    1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "watch mempool with chain mock mode",
+			config: Config{
+				Chain:        "cosmoshub",
+				Account:      "cosmos1abc123",
+				Fees:         "1000uatom",
+				Memo:         "test memo",
+				TPS:          10,
+				WatchMempool: true,
+				MockMode:     true,
+				MsgsPerTx:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "vault keyring backend",
+			config: Config{
+				Chain:          "cosmoshub",
+				Account:        "alice",
+				Fees:           "1000uatom",
+				Memo:           "test memo",
+				TPS:            10,
+				KeyringBackend: keyringBackendVault,
+				VaultPath:      "secret/data/spamtx/alice",
+				MsgsPerTx:      1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "vault keyring backend without vault path",
+			config: Config{
+				Chain:          "cosmoshub",
+				Account:        "alice",
+				Fees:           "1000uatom",
+				Memo:           "test memo",
+				TPS:            10,
+				KeyringBackend: keyringBackendVault,
+				MsgsPerTx:      1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "vault path without vault keyring backend",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "alice",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				VaultPath: "secret/data/spamtx/alice",
+				MsgsPerTx: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "ramp up",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       100,
+				RampUp:    time.Minute,
+				MsgsPerTx: 1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "negative ramp up",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       100,
+				RampUp:    -time.Minute,
+				MsgsPerTx: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "wait sync",
+			config: Config{
+				Chain:           "cosmoshub",
+				Account:         "cosmos1abc123",
+				Fees:            "1000uatom",
+				Memo:            "test memo",
+				TPS:             10,
+				WaitSync:        true,
+				WaitSyncTimeout: 5 * time.Minute,
+				MsgsPerTx:       1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "wait sync without timeout",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				WaitSync:  true,
+				MsgsPerTx: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "gas limit map",
+			config: Config{
+				Chain:       "cosmoshub",
+				Account:     "cosmos1abc123",
+				Fees:        "1000uatom",
+				Memo:        "test memo",
+				TPS:         10,
+				GasLimitMap: map[string]uint64{txTypeBankSend: 200000, txTypeGovVote: 500000},
+				MsgsPerTx:   1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "gas limit map with unknown tx type",
+			config: Config{
+				Chain:       "cosmoshub",
+				Account:     "cosmos1abc123",
+				Fees:        "1000uatom",
+				Memo:        "test memo",
+				TPS:         10,
+				GasLimitMap: map[string]uint64{"ibc-transfer": 200000},
+				MsgsPerTx:   1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "gas limit map with zero gas limit",
+			config: Config{
+				Chain:       "cosmoshub",
+				Account:     "cosmos1abc123",
+				Fees:        "1000uatom",
+				Memo:        "test memo",
+				TPS:         10,
+				GasLimitMap: map[string]uint64{txTypeGovVote: 0},
+				MsgsPerTx:   1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "gas limit map with gas limit auto",
+			config: Config{
+				Chain:        "cosmoshub",
+				Account:      "cosmos1abc123",
+				Fees:         "1000uatom",
+				Memo:         "test memo",
+				TPS:          10,
+				GasLimitAuto: true,
+				GasLimitMap:  map[string]uint64{txTypeGovVote: 500000},
+				MsgsPerTx:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "min node version",
+			config: Config{
+				Chain:             "cosmoshub",
+				Account:           "cosmos1abc123",
+				Fees:              "1000uatom",
+				Memo:              "test memo",
+				TPS:               10,
+				MinNodeVersion:    "0.38.0",
+				NodeVersionStrict: true,
+				MsgsPerTx:         1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid min node version",
+			config: Config{
+				Chain:          "cosmoshub",
+				Account:        "cosmos1abc123",
+				Fees:           "1000uatom",
+				Memo:           "test memo",
+				TPS:            10,
+				MinNodeVersion: "latest",
+				MsgsPerTx:      1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "node version strict without min node version",
+			config: Config{
+				Chain:             "cosmoshub",
+				Account:           "cosmos1abc123",
+				Fees:              "1000uatom",
+				Memo:              "test memo",
+				TPS:               10,
+				NodeVersionStrict: true,
+				MsgsPerTx:         1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "token bucket rate limit strategy",
+			config: Config{
+				Chain:             "cosmoshub",
+				Account:           "cosmos1abc123",
+				Fees:              "1000uatom",
+				Memo:              "test memo",
+				TPS:               100,
+				RateLimitStrategy: rateLimitTokenBucket,
+				MsgsPerTx:         1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown rate limit strategy",
+			config: Config{
+				Chain:             "cosmoshub",
+				Account:           "cosmos1abc123",
+				Fees:              "1000uatom",
+				Memo:              "test memo",
+				TPS:               100,
+				RateLimitStrategy: "leaky-bucket",
+				MsgsPerTx:         1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "token bucket with ramp up",
+			config: Config{
+				Chain:             "cosmoshub",
+				Account:           "cosmos1abc123",
+				Fees:              "1000uatom",
+				Memo:              "test memo",
+				TPS:               100,
+				RampUp:            time.Minute,
+				RateLimitStrategy: rateLimitTokenBucket,
+				MsgsPerTx:         1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "address pool file",
+			config: Config{
+				Chain:           "cosmoshub",
+				Account:         "cosmos1abc123",
+				Fees:            "1000uatom",
+				Memo:            "test memo",
+				TPS:             10,
+				AddressPoolFile: "addresses.txt",
+				MsgsPerTx:       1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "address pool file with gov vote",
+			config: Config{
+				Chain:           "cosmoshub",
+				Account:         "cosmos1abc123",
+				Fees:            "1000uatom",
+				Memo:            "test memo",
+				TPS:             10,
+				TxType:          txTypeGovVote,
+				ProposalID:      1,
+				VoteOption:      "yes",
+				AddressPoolFile: "addresses.txt",
+				MsgsPerTx:       1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "address pool file with heavy mode",
+			config: Config{
+				Chain:           "cosmoshub",
+				Account:         "cosmos1abc123",
+				Fees:            "1000uatom",
+				Memo:            "test memo",
+				TPS:             10,
+				Heavy:           true,
+				AddressPoolFile: "addresses.txt",
+				MsgsPerTx:       1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "histogram with interval",
+			config: Config{
+				Chain:             "cosmoshub",
+				Account:           "cosmos1abc123",
+				Fees:              "1000uatom",
+				Memo:              "test memo",
+				TPS:               10,
+				Histogram:         true,
+				HistogramInterval: 30 * time.Second,
+				MsgsPerTx:         1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "histogram interval without histogram",
+			config: Config{
+				Chain:             "cosmoshub",
+				Account:           "cosmos1abc123",
+				Fees:              "1000uatom",
+				Memo:              "test memo",
+				TPS:               10,
+				HistogramInterval: 30 * time.Second,
+				MsgsPerTx:         1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "cooldown with batch size",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				Cooldown:  5 * time.Second,
+				BatchSize: 50,
+				MsgsPerTx: 1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "batch size without cooldown",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				BatchSize: 50,
+				MsgsPerTx: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative cooldown",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				Cooldown:  -time.Second,
+				MsgsPerTx: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "staking undelegate",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				TxType:    txTypeStakingUndelegate,
+				Validator: "cosmosvaloper1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqkh52tw",
+				Amount:    "1uatom",
+				MsgsPerTx: 1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "staking undelegate without validator",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				TxType:    txTypeStakingUndelegate,
+				Amount:    "1uatom",
+				MsgsPerTx: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "staking undelegate without amount",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				TxType:    txTypeStakingUndelegate,
+				Validator: "cosmosvaloper1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqkh52tw",
+				MsgsPerTx: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "staking redelegate",
+			config: Config{
+				Chain:        "cosmoshub",
+				Account:      "cosmos1abc123",
+				Fees:         "1000uatom",
+				Memo:         "test memo",
+				TPS:          10,
+				TxType:       txTypeStakingRedelegate,
+				ValidatorSrc: "cosmosvaloper1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqkh52tw",
+				ValidatorDst: "cosmosvaloper1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqptpqlku",
+				Amount:       "1uatom",
+				MsgsPerTx:    1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "staking redelegate to the same validator",
+			config: Config{
+				Chain:        "cosmoshub",
+				Account:      "cosmos1abc123",
+				Fees:         "1000uatom",
+				Memo:         "test memo",
+				TPS:          10,
+				TxType:       txTypeStakingRedelegate,
+				ValidatorSrc: "cosmosvaloper1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqkh52tw",
+				ValidatorDst: "cosmosvaloper1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqkh52tw",
+				Amount:       "1uatom",
+				MsgsPerTx:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "staking redelegate with multiple coins",
+			config: Config{
+				Chain:        "cosmoshub",
+				Account:      "cosmos1abc123",
+				Fees:         "1000uatom",
+				Memo:         "test memo",
+				TPS:          10,
+				TxType:       txTypeStakingRedelegate,
+				ValidatorSrc: "cosmosvaloper1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqkh52tw",
+				ValidatorDst: "cosmosvaloper1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqptpqlku",
+				Amount:       "1uatom,1stake",
+				MsgsPerTx:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "validator with bank send",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				Validator: "cosmosvaloper1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqkh52tw",
+				MsgsPerTx: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "nonce file",
+			config: Config{
+				Chain:       "cosmoshub",
+				Account:     "cosmos1abc123",
+				Fees:        "1000uatom",
+				Memo:        "test memo",
+				TPS:         10,
+				NonceFile:   "nonce.json",
+				NonceMaxAge: 10 * time.Second,
+				MsgsPerTx:   1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "nonce file without max age",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				NonceFile: "nonce.json",
+				MsgsPerTx: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "nonce file with dry run",
+			config: Config{
+				Chain:       "cosmoshub",
+				Account:     "cosmos1abc123",
+				Fees:        "1000uatom",
+				Memo:        "test memo",
+				TPS:         10,
+				NonceFile:   "nonce.json",
+				NonceMaxAge: 10 * time.Second,
+				DryRun:      true,
+				MsgsPerTx:   1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "chain prefix with rpc",
+			config: Config{
+				Chain:        "private-testnet",
+				Account:      "cosmos1abc123",
+				Fees:         "1000stake",
+				Memo:         "test memo",
+				TPS:          10,
+				RPC:          "http://localhost:26657",
+				Bech32Prefix: "private",
+				MsgsPerTx:    1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "chain prefix without endpoint",
+			config: Config{
+				Chain:        "private-testnet",
+				Account:      "cosmos1abc123",
+				Fees:         "1000stake",
+				Memo:         "test memo",
+				TPS:          10,
+				Bech32Prefix: "private",
+				MsgsPerTx:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "simulate only",
+			config: Config{
+				Chain:         "cosmoshub",
+				Account:       "cosmos1abc123",
+				Fees:          "1000uatom",
+				Memo:          "test memo",
+				TPS:           10,
+				SimulateOnly:  true,
+				SimulateCount: 10,
+				MsgsPerTx:     1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "simulate only with dry run",
+			config: Config{
+				Chain:         "cosmoshub",
+				Account:       "cosmos1abc123",
+				Fees:          "1000uatom",
+				Memo:          "test memo",
+				TPS:           10,
+				SimulateOnly:  true,
+				SimulateCount: 10,
+				DryRun:        true,
+				MsgsPerTx:     1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "simulate only with zero count",
+			config: Config{
+				Chain:        "cosmoshub",
+				Account:      "cosmos1abc123",
+				Fees:         "1000uatom",
+				Memo:         "test memo",
+				TPS:          10,
+				SimulateOnly: true,
+				MsgsPerTx:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "tx note at max length",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				TxNote:    strings.Repeat("n", maxTxNoteLen),
+				MsgsPerTx: 1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "tx note too long",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				TxNote:    strings.Repeat("n", maxTxNoteLen+1),
+				MsgsPerTx: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "check balance interval with stop balance",
+			config: Config{
+				Chain:                "cosmoshub",
+				Account:              "cosmos1abc123",
+				Fees:                 "1000uatom",
+				Memo:                 "test memo",
+				TPS:                  10,
+				CheckBalanceInterval: 100,
+				StopBalance:          "100uatom",
+				MsgsPerTx:            1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "check balance interval without balance",
+			config: Config{
+				Chain:                "cosmoshub",
+				Account:              "cosmos1abc123",
+				Fees:                 "1000uatom",
+				Memo:                 "test memo",
+				TPS:                  10,
+				CheckBalanceInterval: 100,
+				MsgsPerTx:            1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "stop balance without check balance interval",
+			config: Config{
+				Chain:       "cosmoshub",
+				Account:     "cosmos1abc123",
+				Fees:        "1000uatom",
+				Memo:        "test memo",
+				TPS:         10,
+				StopBalance: "100uatom",
+				MsgsPerTx:   1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid stop balance",
+			config: Config{
+				Chain:                "cosmoshub",
+				Account:              "cosmos1abc123",
+				Fees:                 "1000uatom",
+				Memo:                 "test memo",
+				TPS:                  10,
+				CheckBalanceInterval: 100,
+				StopBalance:          "uatom",
+				MsgsPerTx:            1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "authz grant",
+			config: Config{
+				Chain:        "cosmoshub",
+				Account:      "cosmos1abc123",
+				Fees:         "1000uatom",
+				Memo:         "test memo",
+				TPS:          10,
+				TxType:       txTypeAuthzGrant,
+				Grantee:      "cosmos1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqnrql8a",
+				AuthzMsgType: "/cosmos.bank.v1beta1.MsgSend",
+				GrantExpiry:  time.Hour,
+				MsgsPerTx:    1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "authz grant without grantee",
+			config: Config{
+				Chain:        "cosmoshub",
+				Account:      "cosmos1abc123",
+				Fees:         "1000uatom",
+				Memo:         "test memo",
+				TPS:          10,
+				TxType:       txTypeAuthzGrant,
+				AuthzMsgType: "/cosmos.bank.v1beta1.MsgSend",
+				GrantExpiry:  time.Hour,
+				MsgsPerTx:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "authz grant with invalid msg type",
+			config: Config{
+				Chain:        "cosmoshub",
+				Account:      "cosmos1abc123",
+				Fees:         "1000uatom",
+				Memo:         "test memo",
+				TPS:          10,
+				TxType:       txTypeAuthzGrant,
+				Grantee:      "cosmos1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqnrql8a",
+				AuthzMsgType: "MsgSend",
+				GrantExpiry:  time.Hour,
+				MsgsPerTx:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "authz grant without expiry",
+			config: Config{
+				Chain:        "cosmoshub",
+				Account:      "cosmos1abc123",
+				Fees:         "1000uatom",
+				Memo:         "test memo",
+				TPS:          10,
+				TxType:       txTypeAuthzGrant,
+				Grantee:      "cosmos1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqnrql8a",
+				AuthzMsgType: "/cosmos.bank.v1beta1.MsgSend",
+				MsgsPerTx:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "grantee with bank send",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				TxType:    txTypeBankSend,
+				Grantee:   "cosmos1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqnrql8a",
+				MsgsPerTx: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "weight map",
+			config: Config{
+				Chain:      "cosmoshub",
+				Account:    "cosmos1abc123",
+				Fees:       "1000uatom",
+				Memo:       "test memo",
+				TPS:        10,
+				WeightMap:  map[string]uint64{txTypeBankSend: 70, txTypeGovVote: 30},
+				ProposalID: 1,
+				VoteOption: "yes",
+				RandomSeed: 42,
+				MsgsPerTx:  1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "weight map missing type flags",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				WeightMap: map[string]uint64{txTypeBankSend: 70, txTypeGovVote: 30},
+				MsgsPerTx: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "weight map with unknown type",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				WeightMap: map[string]uint64{txTypeBankSend: 70, "ibc-transfer": 30},
+				MsgsPerTx: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "weight map with zero weight",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				WeightMap: map[string]uint64{txTypeBankSend: 70, txTypeSetWithdrawAddress: 0},
+				MsgsPerTx: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "weight map total overflowing",
+			config: Config{
+				Chain:      "cosmoshub",
+				Account:    "cosmos1abc123",
+				Fees:       "1000uatom",
+				Memo:       "test memo",
+				TPS:        10,
+				WeightMap:  map[string]uint64{txTypeBankSend: 1 << 63, txTypeGovVote: 1 << 63},
+				ProposalID: 1,
+				VoteOption: "yes",
+				MsgsPerTx:  1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "weight map total above max int64",
+			config: Config{
+				Chain:      "cosmoshub",
+				Account:    "cosmos1abc123",
+				Fees:       "1000uatom",
+				Memo:       "test memo",
+				TPS:        10,
+				WeightMap:  map[string]uint64{txTypeBankSend: math.MaxInt64, txTypeGovVote: 1},
+				ProposalID: 1,
+				VoteOption: "yes",
+				MsgsPerTx:  1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "weight map with gas limit auto",
+			config: Config{
+				Chain:        "cosmoshub",
+				Account:      "cosmos1abc123",
+				Fees:         "1000uatom",
+				Memo:         "test memo",
+				TPS:          10,
+				WeightMap:    map[string]uint64{txTypeBankSend: 70, txTypeSetWithdrawAddress: 30},
+				GasLimitAuto: true,
+				MsgsPerTx:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "random seed without weight map",
+			config: Config{
+				Chain:      "cosmoshub",
+				Account:    "cosmos1abc123",
+				Fees:       "1000uatom",
+				Memo:       "test memo",
+				TPS:        10,
+				RandomSeed: 42,
+				MsgsPerTx:  1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "validator with staking in weight map",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				WeightMap: map[string]uint64{txTypeBankSend: 70, txTypeStakingUndelegate: 30},
+				Validator: "cosmosvaloper1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqkh52tw",
+				Amount:    "5uatom",
+				MsgsPerTx: 1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "stall timeout",
+			config: Config{
+				Chain:        "cosmoshub",
+				Account:      "cosmos1abc123",
+				Fees:         "1000uatom",
+				Memo:         "test memo",
+				TPS:          10,
+				StallTimeout: 30 * time.Second,
+				MsgsPerTx:    1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "negative stall timeout",
+			config: Config{
+				Chain:        "cosmoshub",
+				Account:      "cosmos1abc123",
+				Fees:         "1000uatom",
+				Memo:         "test memo",
+				TPS:          10,
+				StallTimeout: -time.Second,
+				MsgsPerTx:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "stall timeout shorter than the tick interval",
+			config: Config{
+				Chain:        "cosmoshub",
+				Account:      "cosmos1abc123",
+				Fees:         "1000uatom",
+				Memo:         "test memo",
+				TPS:          10,
+				StallTimeout: 50 * time.Millisecond,
+				MsgsPerTx:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "extra msg",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				ExtraMsg:  `{"@type":"/cosmos.bank.v1beta1.MsgSend"}`,
+				MsgsPerTx: 1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "extra msg without type",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				ExtraMsg:  `{"amount":[]}`,
+				MsgsPerTx: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "block throttle",
+			config: Config{
+				Chain:             "cosmoshub",
+				Account:           "cosmos1abc123",
+				Fees:              "1000uatom",
+				Memo:              "test memo",
+				TPS:               10,
+				BlockThrottle:     true,
+				BlockTimeEstimate: 6 * time.Second,
+				MsgsPerTx:         1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "block throttle without block time estimate",
+			config: Config{
+				Chain:         "cosmoshub",
+				Account:       "cosmos1abc123",
+				Fees:          "1000uatom",
+				Memo:          "test memo",
+				TPS:           10,
+				BlockThrottle: true,
+				MsgsPerTx:     1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "block throttle with token bucket",
+			config: Config{
+				Chain:             "cosmoshub",
+				Account:           "cosmos1abc123",
+				Fees:              "1000uatom",
+				Memo:              "test memo",
+				TPS:               10,
+				BlockThrottle:     true,
+				BlockTimeEstimate: 6 * time.Second,
+				RateLimitStrategy: rateLimitTokenBucket,
+				MsgsPerTx:         1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "batched messages",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				MsgsPerTx: 100,
+			},
+			wantErr: false,
+		},
+		{
+			name: "zero msgs per tx",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				MsgsPerTx: 0,
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative msgs per tx",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				MsgsPerTx: -1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "too many msgs per tx",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				MsgsPerTx: 101,
+			},
+			wantErr: true,
+		},
+		{
+			name: "batched messages with another transaction type",
+			config: Config{
+				Chain:      "cosmoshub",
+				Account:    "cosmos1abc123",
+				Fees:       "1000uatom",
+				Memo:       "test memo",
+				TPS:        10,
+				MsgsPerTx:  2,
+				TxType:     txTypeGovVote,
+				ProposalID: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "batched messages with heavy mode",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				MsgsPerTx: 2,
+				Heavy:     true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "generated recipients",
+			config: Config{
+				Chain:            "cosmoshub",
+				Account:          "cosmos1abc123",
+				Fees:             "1000uatom",
+				Memo:             "test memo",
+				TPS:              10,
+				RecipientGen:     true,
+				RecipientGenSeed: 42,
+				MsgsPerTx:        1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "recipient gen seed without recipient gen",
+			config: Config{
+				Chain:            "cosmoshub",
+				Account:          "cosmos1abc123",
+				Fees:             "1000uatom",
+				Memo:             "test memo",
+				TPS:              10,
+				RecipientGenSeed: 42,
+				MsgsPerTx:        1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "generated recipients with another transaction type",
+			config: Config{
+				Chain:        "cosmoshub",
+				Account:      "cosmos1abc123",
+				Fees:         "1000uatom",
+				Memo:         "test memo",
+				TPS:          10,
+				RecipientGen: true,
+				TxType:       txTypeGovVote,
+				ProposalID:   1,
+				MsgsPerTx:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "generated recipients with address pool file",
+			config: Config{
+				Chain:           "cosmoshub",
+				Account:         "cosmos1abc123",
+				Fees:            "1000uatom",
+				Memo:            "test memo",
+				TPS:             10,
+				RecipientGen:    true,
+				AddressPoolFile: "addresses.txt",
+				MsgsPerTx:       1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "sync broadcast mode",
+			config: Config{
+				Chain:         "cosmoshub",
+				Account:       "cosmos1abc123",
+				Fees:          "1000uatom",
+				Memo:          "test memo",
+				TPS:           10,
+				BroadcastMode: broadcastModeSync,
+				MsgsPerTx:     1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown broadcast mode",
+			config: Config{
+				Chain:         "cosmoshub",
+				Account:       "cosmos1abc123",
+				Fees:          "1000uatom",
+				Memo:          "test memo",
+				TPS:           10,
+				BroadcastMode: "block",
+				MsgsPerTx:     1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "block broadcast mode",
+			config: Config{
+				Chain:             "cosmoshub",
+				Account:           "cosmos1abc123",
+				Fees:              "1000uatom",
+				Memo:              "test memo",
+				TPS:               10,
+				BroadcastMode:     broadcastModeBlock,
+				BlockTimeEstimate: 6 * time.Second,
+				MsgsPerTx:         1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "block broadcast mode without block time estimate",
+			config: Config{
+				Chain:         "cosmoshub",
+				Account:       "cosmos1abc123",
+				Fees:          "1000uatom",
+				Memo:          "test memo",
+				TPS:           10,
+				BroadcastMode: broadcastModeBlock,
+				MsgsPerTx:     1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "block broadcast mode with dry run",
+			config: Config{
+				Chain:             "cosmoshub",
+				Account:           "cosmos1abc123",
+				Fees:              "1000uatom",
+				Memo:              "test memo",
+				TPS:               10,
+				BroadcastMode:     broadcastModeBlock,
+				BlockTimeEstimate: 6 * time.Second,
+				DryRun:            true,
+				MsgsPerTx:         1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "block broadcast mode with token bucket",
+			config: Config{
+				Chain:             "cosmoshub",
+				Account:           "cosmos1abc123",
+				Fees:              "1000uatom",
+				Memo:              "test memo",
+				TPS:               10,
+				BroadcastMode:     broadcastModeBlock,
+				BlockTimeEstimate: 6 * time.Second,
+				RateLimitStrategy: rateLimitTokenBucket,
+				MsgsPerTx:         1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "multiple chains",
+			config: Config{
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				Chains:    []string{"cosmoshub", "osmosis"},
+				MsgsPerTx: 1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "duplicate chains",
+			config: Config{
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				Chains:    []string{"cosmoshub", "cosmoshub"},
+				MsgsPerTx: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "more chains than tps",
+			config: Config{
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				Chains:    []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k"},
+				MsgsPerTx: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "more chains than count",
+			config: Config{
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				Count:     2,
+				Chains:    []string{"cosmoshub", "osmosis", "juno"},
+				MsgsPerTx: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "multiple chains with custom rpc",
+			config: Config{
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				Chains:    []string{"cosmoshub", "osmosis"},
+				RPC:       "http://localhost:26657",
+				MsgsPerTx: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "multiple chains with nonce file",
+			config: Config{
+				Account:     "cosmos1abc123",
+				Fees:        "1000uatom",
+				Memo:        "test memo",
+				TPS:         10,
+				Chains:      []string{"cosmoshub", "osmosis"},
+				NonceFile:   "nonce.json",
+				NonceMaxAge: time.Hour,
+				MsgsPerTx:   1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "event log",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				EventLog:  true,
+				MsgsPerTx: 1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "event log with chain mock mode",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				EventLog:  true,
+				MockMode:  true,
+				MsgsPerTx: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "event log with grpc endpoint",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				EventLog:  true,
+				GRPC:      "localhost:9090",
+				MsgsPerTx: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid send all",
+			config: Config{
+				Chain:             "cosmoshub",
+				Account:           "cosmos1abc123",
+				Fees:              "1000uatom",
+				Memo:              "test memo",
+				TPS:               10,
+				SendAll:           true,
+				BroadcastMode:     broadcastModeBlock,
+				BlockTimeEstimate: 6 * time.Second,
+				MsgsPerTx:         1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "send all with async broadcast",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				SendAll:   true,
+				MsgsPerTx: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "send all with concurrent transactions",
+			config: Config{
+				Chain:             "cosmoshub",
+				Account:           "cosmos1abc123",
+				Fees:              "1000uatom",
+				Memo:              "test memo",
+				TPS:               10,
+				SendAll:           true,
+				BroadcastMode:     broadcastModeBlock,
+				BlockTimeEstimate: 6 * time.Second,
+				Concurrent:        2,
+				MsgsPerTx:         1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "send all with staking",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				SendAll:   true,
+				TxType:    txTypeStakingUndelegate,
+				MsgsPerTx: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "send all with amount",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				SendAll:   true,
+				Amount:    "10uatom",
+				MsgsPerTx: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "send all with msgs per tx",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				SendAll:   true,
+				MsgsPerTx: 2,
+			},
+			wantErr: true,
+		},
+		{
+			name: "send all with chain mock mode",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				SendAll:   true,
+				MockMode:  true,
+				MsgsPerTx: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "withdraw address with bank send",
+			config: Config{
+				Chain:           "cosmoshub",
+				Account:         "cosmos1abc123",
+				Fees:            "1000uatom",
+				Memo:            "test memo",
+				TPS:             10,
+				WithdrawAddress: "cosmos1withdraw",
+				MsgsPerTx:       1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid fee percentage config",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				FeePct:    0.5,
+				Amount:    "100000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				MsgsPerTx: 1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "fees and fee percentage",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				FeePct:    0.5,
+				Amount:    "100000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				MsgsPerTx: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "fee percentage without amount",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				FeePct:    0.5,
+				Memo:      "test memo",
+				TPS:       10,
+				MsgsPerTx: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative fee percentage",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				FeePct:    -1,
+				Amount:    "100000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				MsgsPerTx: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown sign mode",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				SignMode:  "textual",
+				MsgsPerTx: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsupported keyring backend",
+			config: Config{
+				Chain:          "cosmoshub",
+				Account:        "cosmos1abc123",
+				Fees:           "1000uatom",
+				Memo:           "test memo",
+				TPS:            10,
+				KeyringBackend: "vault",
+				MsgsPerTx:      1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "watch block with dry-run",
+			config: Config{
+				Chain:      "cosmoshub",
+				Account:    "cosmos1abc123",
+				Fees:       "1000uatom",
+				Memo:       "test memo",
+				TPS:        10,
+				DryRun:     true,
+				WatchBlock: true,
+				MsgsPerTx:  1,
+			},
+			wantErr: true,
+		},
 		{
 			name: "empty chain",
 			config: Config{
-				Chain:   "",
-				Account: "cosmos1abc123",
-				Fees:    "1000uatom",
-				Memo:    "test memo",
-				TPS:     10,
+				Chain:     "",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				MsgsPerTx: 1,
 			},
 			wantErr: true,
 		},
 		{
 			name: "empty account",
 			config: Config{
-				Chain:   "cosmoshub",
-				Account: "",
-				Fees:    "1000uatom",
-				Memo:    "test memo",
-				TPS:     10,
+				Chain:     "cosmoshub",
+				Account:   "",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				MsgsPerTx: 1,
 			},
 			wantErr: true,
 		},
 		{
 			name: "empty fees",
 			config: Config{
-				Chain:   "cosmoshub",
-				Account: "cosmos1abc123",
-				Fees:    "",
-				Memo:    "test memo",
-				TPS:     10,
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "",
+				Memo:      "test memo",
+				TPS:       10,
+				MsgsPerTx: 1,
 			},
 			wantErr: true,
 		},
 		{
 			name: "empty memo",
 			config: Config{
-				Chain:   "cosmoshub",
-				Account: "cosmos1abc123",
-				Fees:    "1000uatom",
-				Memo:    "",
-				TPS:     10,
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "",
+				TPS:       10,
+				MsgsPerTx: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid config with memo template",
+			config: Config{
+				Chain:        "cosmoshub",
+				Account:      "cosmos1abc123",
+				Fees:         "1000uatom",
+				MemoTemplate: "tx {{.TxNum}}",
+				TPS:          10,
+				MsgsPerTx:    1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "memo and memo template",
+			config: Config{
+				Chain:        "cosmoshub",
+				Account:      "cosmos1abc123",
+				Fees:         "1000uatom",
+				Memo:         "test memo",
+				MemoTemplate: "tx {{.TxNum}}",
+				TPS:          10,
+				MsgsPerTx:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid memo template",
+			config: Config{
+				Chain:        "cosmoshub",
+				Account:      "cosmos1abc123",
+				Fees:         "1000uatom",
+				MemoTemplate: "tx {{.TxNum",
+				TPS:          10,
+				MsgsPerTx:    1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid group proposal config",
+			config: Config{
+				Chain:            "cosmoshub",
+				Account:          "cosmos1abc123",
+				Fees:             "1000uatom",
+				Memo:             "test memo",
+				TPS:              10,
+				TxType:           txTypeGroupSubmitProposal,
+				GroupID:          1,
+				GroupMessageJSON: "msg.json",
+				MsgsPerTx:        1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "group proposal without group id",
+			config: Config{
+				Chain:            "cosmoshub",
+				Account:          "cosmos1abc123",
+				Fees:             "1000uatom",
+				Memo:             "test memo",
+				TPS:              10,
+				TxType:           txTypeGroupSubmitProposal,
+				GroupMessageJSON: "msg.json",
+				MsgsPerTx:        1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "group proposal without message file",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				TxType:    txTypeGroupSubmitProposal,
+				GroupID:   1,
+				MsgsPerTx: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "heavy group proposal",
+			config: Config{
+				Chain:            "cosmoshub",
+				Account:          "cosmos1abc123",
+				Fees:             "1000uatom",
+				Memo:             "test memo",
+				TPS:              10,
+				TxType:           txTypeGroupSubmitProposal,
+				GroupID:          1,
+				GroupMessageJSON: "msg.json",
+				Heavy:            true,
+				MsgsPerTx:        1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown tx type",
+			config: Config{
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       10,
+				TxType:    "unknown",
+				MsgsPerTx: 1,
 			},
 			wantErr: true,
 		},
 		{
 			name: "zero tps",
 			config: Config{
-				Chain:   "cosmoshub",
-				Account: "cosmos1abc123",
-				Fees:    "1000uatom",
-				Memo:    "test memo",
-				TPS:     0,
+				Chain:     "cosmoshub",
+				Account:   "cosmos1abc123",
+				Fees:      "1000uatom",
+				Memo:      "test memo",
+				TPS:       0,
+				MsgsPerTx: 1,
 			},
 			wantErr: true,
 		},
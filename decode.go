@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/ignite/cli/v29/ignite/pkg/cosmosclient"
+)
+
+// runDecodeTx decodes a base64-encoded transaction, or the transaction with the given hash, and prints it as JSON
+func runDecodeTx(ctx context.Context, chainName, rpcOverride, tx string) error {
+	client, err := newDecodeClient(ctx, chainName, rpcOverride)
+	if err != nil {
+		return err
+	}
+
+	txBytes, err := resolveTxBytes(ctx, client, tx)
+	if err != nil {
+		return err
+	}
+
+	bz, err := decodeTx(client.Context().Codec, txBytes)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(bz))
+	return nil
+}
+
+// newDecodeClient creates a cosmos client connected to the chain node, whose codec knows the transaction types
+func newDecodeClient(ctx context.Context, chainName, rpcOverride string) (cosmosclient.Client, error) {
+	rpcEndpoint, bech32Prefix, err := getChainInfo(chainName)
+	if err != nil {
+		return cosmosclient.Client{}, fmt.Errorf("failed to get chain info: %w", err)
+	}
+	if rpcOverride != "" {
+		rpcEndpoint = rpcOverride
+	}
+
+	keyringDir, err := getKeyringHome()
+	if err != nil {
+		return cosmosclient.Client{}, fmt.Errorf("failed to get keyring home: %w", err)
+	}
+
+	client, err := cosmosclient.New(ctx,
+		cosmosclient.WithNodeAddress(rpcEndpoint),
+		cosmosclient.WithBech32Prefix(bech32Prefix),
+		cosmosclient.WithKeyringDir(keyringDir),
+		cosmosclient.WithKeyringBackend(DefaultKeyringBackend),
+		cosmosclient.WithKeyringServiceName(DefaultKeyringServiceName),
+	)
+	if err != nil {
+		return cosmosclient.Client{}, fmt.Errorf("failed to create cosmos client: %w", err)
+	}
+	registerMsgInterfaces(client.Context().InterfaceRegistry)
+
+	return client, nil
+}
+
+// isTxHash reports whether tx is a hex-encoded transaction hash rather than base64-encoded transaction bytes
+func isTxHash(tx string) bool {
+	if len(tx) != 64 {
+		return false
+	}
+
+	_, err := hex.DecodeString(tx)
+	return err == nil
+}
+
+// resolveTxBytes returns the raw transaction, fetching it from the node when tx is a transaction hash
+func resolveTxBytes(ctx context.Context, client cosmosclient.Client, tx string) ([]byte, error) {
+	tx = strings.TrimSpace(tx)
+
+	if isTxHash(tx) {
+		hash, err := hex.DecodeString(tx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode transaction hash: %w", err)
+		}
+
+		resp, err := client.RPC.Tx(ctx, hash, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch transaction %s: %w", strings.ToUpper(tx), err)
+		}
+
+		return resp.Tx, nil
+	}
+
+	txBytes, err := base64.StdEncoding.DecodeString(tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode base64 transaction: %w", err)
+	}
+
+	return txBytes, nil
+}
+
+// decodeTx decodes a raw protobuf transaction, unpacking its messages, and returns it as indented JSON
+func decodeTx(cdc codec.Codec, txBytes []byte) ([]byte, error) {
+	var tx txtypes.Tx
+	if err := cdc.Unmarshal(txBytes, &tx); err != nil {
+		return nil, fmt.Errorf("failed to decode transaction: %w", err)
+	}
+
+	bz, err := cdc.MarshalJSON(&tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode transaction: %w", err)
+	}
+
+	var out bytes.Buffer
+	if err := json.Indent(&out, bz, "", "  "); err != nil {
+		return nil, fmt.Errorf("failed to indent transaction: %w", err)
+	}
+
+	return out.Bytes(), nil
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	"github.com/ignite/cli/v29/ignite/pkg/cosmosclient"
+	"gotest.tools/v3/assert"
+)
+
+// storedTxRPC returns the same transaction for every hash lookup
+type storedTxRPC struct {
+	rpcclient.Client
+	tx []byte
+}
+
+func (r storedTxRPC) Tx(_ context.Context, hash []byte, _ bool) (*ctypes.ResultTx, error) {
+	return &ctypes.ResultTx{Hash: hash, Tx: r.tx}, nil
+}
+
+func TestIsTxHash(t *testing.T) {
+	tests := []struct {
+		name   string
+		tx     string
+		expect bool
+	}{
+		{
+			name:   "uppercase hash",
+			tx:     "E3B0C44298FC1C149AFBF4C8996FB92427AE41E4649B934CA495991B7852B855",
+			expect: true,
+		},
+		{
+			name:   "lowercase hash",
+			tx:     "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+			expect: true,
+		},
+		{
+			name:   "base64 transaction",
+			tx:     "CpABCo0BChwvY29zbW9zLmJhbmsudjFiZXRhMS5Nc2dTZW5kEm0KLWNvc21vcw==",
+			expect: false,
+		},
+		{
+			name:   "short hex",
+			tx:     "E3B0C442",
+			expect: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, isTxHash(tt.tx), tt.expect)
+		})
+	}
+}
+
+func TestDecodeTx(t *testing.T) {
+	client, err := cosmosclient.New(context.Background(),
+		cosmosclient.WithRPCClient(newMockRPC()),
+		cosmosclient.WithAccountRetriever(mockAccountRetriever{}),
+		cosmosclient.WithBech32Prefix(mockBech32Prefix),
+		cosmosclient.WithKeyringDir(t.TempDir()),
+		cosmosclient.WithKeyringBackend(DefaultKeyringBackend),
+	)
+	assert.NilError(t, err)
+
+	account, _, err := client.AccountRegistry.Create("alice")
+	assert.NilError(t, err)
+	accountAddr, err := account.Address(mockBech32Prefix)
+	assert.NilError(t, err)
+
+	txBytes, err := buildSimTx(client, account, Config{Fees: "1000uatom"}, "decode memo", 3, &banktypes.MsgSend{
+		FromAddress: accountAddr,
+		ToAddress:   accountAddr,
+		Amount:      sdk.NewCoins(sdk.NewInt64Coin("uatom", 1)),
+	})
+	assert.NilError(t, err)
+
+	tests := []struct {
+		name    string
+		client  cosmosclient.Client
+		tx      string
+		wantErr string
+	}{
+		{
+			name:   "base64 transaction",
+			client: client,
+			tx:     base64.StdEncoding.EncodeToString(txBytes),
+		},
+		{
+			name:   "transaction hash",
+			client: cosmosclient.Client{RPC: storedTxRPC{tx: txBytes}},
+			tx:     hex.EncodeToString(make([]byte, 32)),
+		},
+		{
+			name:    "invalid base64",
+			client:  client,
+			tx:      "not base64!",
+			wantErr: "failed to decode base64 transaction",
+		},
+		{
+			name:    "invalid transaction",
+			client:  client,
+			tx:      base64.StdEncoding.EncodeToString([]byte{0xff, 0xff, 0xff}),
+			wantErr: "failed to decode transaction",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bz, err := resolveTxBytes(context.Background(), tt.client, tt.tx)
+			if err == nil {
+				bz, err = decodeTx(client.Context().Codec, bz)
+			}
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+
+			out := string(bz)
+			assert.Assert(t, strings.Contains(out, `"@type": "/cosmos.bank.v1beta1.MsgSend"`), out)
+			assert.Assert(t, strings.Contains(out, `"memo": "decode memo"`), out)
+			assert.Assert(t, strings.Contains(out, `"sequence": "3"`), out)
+			assert.Assert(t, strings.Contains(out, accountAddr), out)
+		})
+	}
+}
+
+func TestDecodeTxModuleMessage(t *testing.T) {
+	client, err := cosmosclient.New(context.Background(),
+		cosmosclient.WithRPCClient(newMockRPC()),
+		cosmosclient.WithAccountRetriever(mockAccountRetriever{}),
+		cosmosclient.WithBech32Prefix(mockBech32Prefix),
+		cosmosclient.WithKeyringDir(t.TempDir()),
+		cosmosclient.WithKeyringBackend(DefaultKeyringBackend),
+	)
+	assert.NilError(t, err)
+	registerMsgInterfaces(client.Context().InterfaceRegistry)
+
+	account, _, err := client.AccountRegistry.Create("alice")
+	assert.NilError(t, err)
+	accountAddr, err := account.Address(mockBech32Prefix)
+	assert.NilError(t, err)
+
+	txBytes, err := buildSimTx(client, account, Config{Fees: "1000uatom"}, "", 0, govv1.NewMsgVote(sdk.MustAccAddressFromBech32(accountAddr), 7, govv1.OptionYes, ""))
+	assert.NilError(t, err)
+
+	bz, err := decodeTx(client.Context().Codec, txBytes)
+	assert.NilError(t, err)
+
+	out := string(bz)
+	assert.Assert(t, strings.Contains(out, `"@type": "/cosmos.gov.v1.MsgVote"`), out)
+	assert.Assert(t, strings.Contains(out, `"proposal_id": "7"`), out)
+}
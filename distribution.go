@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	distributiontypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	"github.com/ignite/cli/v29/ignite/pkg/cosmosaccount"
+	"github.com/ignite/cli/v29/ignite/pkg/cosmosclient"
+)
+
+// withdrawAddressCount is the number of withdraw addresses cycled through when none is set
+const withdrawAddressCount = 10
+
+// validateWithdrawAddress checks that the withdraw address is a valid address of the chain
+func validateWithdrawAddress(address, bech32Prefix string) error {
+	hrp, _, err := bech32.DecodeAndConvert(address)
+	if err != nil {
+		return fmt.Errorf("invalid withdraw address %s: %w", address, err)
+	}
+
+	if hrp != bech32Prefix {
+		return fmt.Errorf("withdraw address %s has prefix %s, expected %s", address, hrp, bech32Prefix)
+	}
+
+	return nil
+}
+
+// deriveWithdrawAddresses returns the account address followed by count-1 addresses deterministically derived from it
+func deriveWithdrawAddresses(accountAddr sdk.AccAddress, bech32Prefix string, count int) ([]string, error) {
+	addresses := make([]string, 0, count)
+	for i := range count {
+		addr := accountAddr
+		if i > 0 {
+			addr = tmhash.SumTruncated(fmt.Appendf(accountAddr.Bytes(), "/withdraw/%d", i))
+		}
+
+		encoded, err := sdk.Bech32ifyAddressBytes(bech32Prefix, addr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode withdraw address: %w", err)
+		}
+		addresses = append(addresses, encoded)
+	}
+
+	return addresses, nil
+}
+
+// sendSetWithdrawAddressTransaction sets the distribution rewards withdraw address of the account
+func sendSetWithdrawAddressTransaction(ctx context.Context, client cosmosclient.Client, account cosmosaccount.Account, config Config, withdrawAddress string, txNum uint64, addressPrefix, memo string, sequence uint64) error {
+	accountAddr, err := account.Address(addressPrefix)
+	if err != nil {
+		return fmt.Errorf("failed to get account address: %w", err)
+	}
+
+	setWithdrawAddressMsg := &distributiontypes.MsgSetWithdrawAddress{
+		DelegatorAddress: msgSender(config, accountAddr),
+		WithdrawAddress:  withdrawAddress,
+	}
+
+	msg, err := buildMsg(config, accountAddr, setWithdrawAddressMsg)
+	if err != nil {
+		return err
+	}
+
+	response, err := broadcastTx(ctx, client, account, config, txNum, memo, sequence, msg)
+	if err != nil {
+		return err
+	}
+
+	logger.Debug("🔗 Set withdraw address broadcasted", "tx", txNum, "sequence", sequence, "hash", response.TxHash, "withdraw_address", withdrawAddress, "memo", memo)
+
+	return nil
+}
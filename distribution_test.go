@@ -0,0 +1,72 @@
+package main
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"gotest.tools/v3/assert"
+)
+
+func TestValidateWithdrawAddress(t *testing.T) {
+	addr := sdk.AccAddress([]byte("withdraw_address____"))
+	cosmosAddr, err := sdk.Bech32ifyAddressBytes("cosmos", addr)
+	assert.NilError(t, err)
+	osmoAddr, err := sdk.Bech32ifyAddressBytes("osmo", addr)
+	assert.NilError(t, err)
+
+	tests := []struct {
+		name    string
+		address string
+		wantErr string
+	}{
+		{
+			name:    "matching prefix",
+			address: cosmosAddr,
+		},
+		{
+			name:    "other chain prefix",
+			address: osmoAddr,
+			wantErr: "expected cosmos",
+		},
+		{
+			name:    "invalid address",
+			address: "cosmos1invalid",
+			wantErr: "invalid withdraw address",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateWithdrawAddress(tt.address, "cosmos")
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NilError(t, err)
+			}
+		})
+	}
+}
+
+func TestDeriveWithdrawAddresses(t *testing.T) {
+	accountAddr := sdk.AccAddress([]byte("account_address_____"))
+	selfAddr, err := sdk.Bech32ifyAddressBytes("cosmos", accountAddr)
+	assert.NilError(t, err)
+
+	addresses, err := deriveWithdrawAddresses(accountAddr, "cosmos", withdrawAddressCount)
+	assert.NilError(t, err)
+	assert.Equal(t, len(addresses), withdrawAddressCount)
+
+	// The account itself comes first, followed by unique addresses of the same chain
+	assert.Equal(t, addresses[0], selfAddr)
+	seen := make(map[string]bool)
+	for _, address := range addresses {
+		assert.NilError(t, validateWithdrawAddress(address, "cosmos"))
+		assert.Assert(t, !seen[address], "duplicate address %s", address)
+		seen[address] = true
+	}
+
+	// Derivation is deterministic
+	again, err := deriveWithdrawAddresses(accountAddr, "cosmos", withdrawAddressCount)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, again, addresses)
+}
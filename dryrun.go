@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// dryRunRPC is an RPC client that accepts signed transactions without broadcasting them.
+// All other calls are forwarded to the underlying client.
+type dryRunRPC struct {
+	rpcclient.Client
+}
+
+// BroadcastTxSync returns a successful response without sending the transaction
+func (dryRunRPC) BroadcastTxSync(_ context.Context, tx cmttypes.Tx) (*ctypes.ResultBroadcastTx, error) {
+	return &ctypes.ResultBroadcastTx{Hash: tx.Hash()}, nil
+}
+
+// BroadcastTxAsync returns a successful response without sending the transaction
+func (dryRunRPC) BroadcastTxAsync(_ context.Context, tx cmttypes.Tx) (*ctypes.ResultBroadcastTx, error) {
+	return &ctypes.ResultBroadcastTx{Hash: tx.Hash()}, nil
+}
+
+// BroadcastTxCommit returns a successful response without sending the transaction
+func (dryRunRPC) BroadcastTxCommit(_ context.Context, tx cmttypes.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
+	return &ctypes.ResultBroadcastTxCommit{Hash: tx.Hash()}, nil
+}
+
+// dryRunAccountRetriever lets transactions be built for accounts that do not exist on chain yet
+type dryRunAccountRetriever struct {
+	client.AccountRetriever
+}
+
+// EnsureExists never fails, so unfunded accounts can be used in dry-run mode
+func (dryRunAccountRetriever) EnsureExists(client.Context, sdk.AccAddress) error {
+	return nil
+}
+
+// GetAccountNumberSequence falls back to zero values when the account does not exist on chain
+func (r dryRunAccountRetriever) GetAccountNumberSequence(clientCtx client.Context, addr sdk.AccAddress) (uint64, uint64, error) {
+	accNum, accSeq, err := r.AccountRetriever.GetAccountNumberSequence(clientCtx, addr)
+	if err != nil {
+		return 0, 0, nil
+	}
+
+	return accNum, accSeq, nil
+}
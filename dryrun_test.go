@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"gotest.tools/v3/assert"
+)
+
+func TestDryRunRPCDoesNotBroadcast(t *testing.T) {
+	// The embedded client is nil: any forwarded call would panic
+	rpc := dryRunRPC{}
+	tx := cmttypes.Tx("signed tx bytes")
+
+	syncResp, err := rpc.BroadcastTxSync(context.Background(), tx)
+	assert.NilError(t, err)
+	assert.Equal(t, syncResp.Code, uint32(0))
+	assert.DeepEqual(t, []byte(syncResp.Hash), tx.Hash())
+
+	asyncResp, err := rpc.BroadcastTxAsync(context.Background(), tx)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []byte(asyncResp.Hash), tx.Hash())
+
+	commitResp, err := rpc.BroadcastTxCommit(context.Background(), tx)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []byte(commitResp.Hash), tx.Hash())
+}
+
+type failingAccountRetriever struct {
+	client.AccountRetriever
+}
+
+func (failingAccountRetriever) GetAccountNumberSequence(client.Context, sdk.AccAddress) (uint64, uint64, error) {
+	return 0, 0, errors.New("account not found")
+}
+
+type staticAccountRetriever struct {
+	client.AccountRetriever
+}
+
+func (staticAccountRetriever) GetAccountNumberSequence(client.Context, sdk.AccAddress) (uint64, uint64, error) {
+	return 7, 42, nil
+}
+
+func TestDryRunAccountRetriever(t *testing.T) {
+	retriever := dryRunAccountRetriever{AccountRetriever: failingAccountRetriever{}}
+	assert.NilError(t, retriever.EnsureExists(client.Context{}, nil))
+
+	accNum, accSeq, err := retriever.GetAccountNumberSequence(client.Context{}, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, accNum, uint64(0))
+	assert.Equal(t, accSeq, uint64(0))
+
+	retriever = dryRunAccountRetriever{AccountRetriever: staticAccountRetriever{}}
+	accNum, accSeq, err = retriever.GetAccountNumberSequence(client.Context{}, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, accNum, uint64(7))
+	assert.Equal(t, accSeq, uint64(42))
+}
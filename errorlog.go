@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// ErrorRecord is a failed transaction written to the error file
+type ErrorRecord struct {
+	TxNum     uint64    `json:"tx_num"`
+	Seq       uint64    `json:"seq"`
+	Error     string    `json:"error"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// ErrorLog appends failed transactions to a file, one JSON record per line
+type ErrorLog struct {
+	// mu guards file, so that concurrent records are not interleaved
+	mu   sync.Mutex
+	file *os.File
+}
+
+// Open opens the error file for append, creating it if needed
+func (l *ErrorLog) Open(path string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open error file: %w", err)
+	}
+	l.file = file
+
+	return nil
+}
+
+// Write appends the record to the error file. Each record is written at once, unbuffered.
+func (l *ErrorLog) Write(record ErrorRecord) error {
+	bz, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode error record: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return errors.New("error file is not open")
+	}
+
+	if _, err := l.file.Write(append(bz, '\n')); err != nil {
+		return fmt.Errorf("failed to write error record: %w", err)
+	}
+
+	return nil
+}
+
+// Close closes the error file
+func (l *ErrorLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
+
+// logErrors wraps send to write the failed transactions to the error log, ignoring those cut short by the end of the run
+func logErrors(errorLog *ErrorLog, send sendFunc) sendFunc {
+	return func(ctx context.Context, txNum, sequence uint64) error {
+		err := send(ctx, txNum, sequence)
+		if !isTxFailure(ctx, err) {
+			return err
+		}
+
+		if writeErr := errorLog.Write(ErrorRecord{
+			TxNum:     txNum,
+			Seq:       sequence,
+			Error:     err.Error(),
+			Timestamp: time.Now().UTC(),
+		}); writeErr != nil {
+			logger.Warn("⚠️ Failed to record failed transaction", "error", writeErr)
+		}
+
+		return err
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+// readErrorRecords reads the JSON records of an error file
+func readErrorRecords(t *testing.T, path string) []ErrorRecord {
+	t.Helper()
+
+	file, err := os.Open(path)
+	assert.NilError(t, err)
+	defer func() {
+		_ = file.Close()
+	}()
+
+	var records []ErrorRecord
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record ErrorRecord
+		assert.NilError(t, json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+	assert.NilError(t, scanner.Err())
+
+	return records
+}
+
+func TestErrorLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.jsonl")
+	timestamp := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	var errorLog ErrorLog
+	assert.NilError(t, errorLog.Open(path))
+	assert.NilError(t, errorLog.Write(ErrorRecord{TxNum: 1, Seq: 11, Error: "insufficient fees", Timestamp: timestamp}))
+
+	// Records are readable before the file is closed
+	assert.Equal(t, len(readErrorRecords(t, path)), 1)
+	assert.NilError(t, errorLog.Close())
+
+	// Reopening appends to the existing records
+	assert.NilError(t, errorLog.Open(path))
+	assert.NilError(t, errorLog.Write(ErrorRecord{TxNum: 2, Seq: 12, Error: "out of gas", Timestamp: timestamp}))
+	assert.NilError(t, errorLog.Close())
+
+	assert.DeepEqual(t, readErrorRecords(t, path), []ErrorRecord{
+		{TxNum: 1, Seq: 11, Error: "insufficient fees", Timestamp: timestamp},
+		{TxNum: 2, Seq: 12, Error: "out of gas", Timestamp: timestamp},
+	})
+}
+
+func TestErrorLogClosed(t *testing.T) {
+	var errorLog ErrorLog
+	assert.ErrorContains(t, errorLog.Write(ErrorRecord{}), "not open")
+	assert.NilError(t, errorLog.Close())
+}
+
+func TestLogErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.jsonl")
+
+	var errorLog ErrorLog
+	assert.NilError(t, errorLog.Open(path))
+
+	send := logErrors(&errorLog, func(_ context.Context, txNum, _ uint64) error {
+		if txNum%2 == 1 {
+			return errors.New("account sequence mismatch")
+		}
+		return nil
+	})
+	for i := range uint64(4) {
+		_ = send(context.Background(), i, 100+i)
+	}
+	assert.NilError(t, errorLog.Close())
+
+	// Only the failed transactions are recorded
+	records := readErrorRecords(t, path)
+	assert.Equal(t, len(records), 2)
+	for i, txNum := range []uint64{1, 3} {
+		assert.Equal(t, records[i].TxNum, txNum)
+		assert.Equal(t, records[i].Seq, 100+txNum)
+		assert.Equal(t, records[i].Error, "account sequence mismatch")
+		assert.Assert(t, !records[i].Timestamp.IsZero())
+	}
+}
+
+func TestLogErrorsIgnoresCancelledSends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.jsonl")
+
+	var errorLog ErrorLog
+	assert.NilError(t, errorLog.Open(path))
+
+	send := logErrors(&errorLog, func(ctx context.Context, _, _ uint64) error {
+		return fmt.Errorf("failed to broadcast: %w", ctx.Err())
+	})
+
+	// A send cut short by the end of the run is not a failed transaction
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, send(ctx, 0, 100), context.Canceled)
+	assert.NilError(t, errorLog.Close())
+
+	assert.Equal(t, len(readErrorRecords(t, path)), 0)
+}
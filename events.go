@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+)
+
+const (
+	// eventSubscriber is the subscriber name of the event subscriptions
+	eventSubscriber = "spamtx"
+	// eventBufferSize is the number of events buffered by the subscription before the node drops it
+	eventBufferSize = 100
+)
+
+// EventData is a transaction included in a block, as received from an event subscription
+type EventData struct {
+	Height int64
+	TxHash string
+	Code   uint32
+	Log    string
+}
+
+// eventClient subscribes to the events of a node, as done by the CometBFT RPC client
+type eventClient interface {
+	Start() error
+	Stop() error
+	Subscribe(ctx context.Context, subscriber, query string, outCapacity ...int) (<-chan ctypes.ResultEvent, error)
+}
+
+// EventSubscriber subscribes to the transaction events of a node through its WebSocket endpoint
+type EventSubscriber struct {
+	newClient func(wsURL string) (eventClient, error)
+}
+
+// NewEventSubscriber creates an event subscriber connecting to the /websocket endpoint of the nodes
+func NewEventSubscriber() *EventSubscriber {
+	return &EventSubscriber{
+		newClient: func(wsURL string) (eventClient, error) {
+			return rpchttp.New(wsURL, "/websocket")
+		},
+	}
+}
+
+// Subscribe connects to the node at wsURL and returns the transaction events matching the query.
+// The channel is closed and the connection stopped once the context is cancelled.
+func (s *EventSubscriber) Subscribe(ctx context.Context, wsURL, query string) (<-chan EventData, error) {
+	client, err := s.newClient(wsURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create event client: %w", err)
+	}
+
+	if err := client.Start(); err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", wsURL, err)
+	}
+
+	events, err := client.Subscribe(ctx, eventSubscriber, query, eventBufferSize)
+	if err != nil {
+		_ = client.Stop()
+		return nil, fmt.Errorf("failed to subscribe to %q: %w", query, err)
+	}
+
+	out := make(chan EventData)
+	go func() {
+		defer close(out)
+		defer func() {
+			_ = client.Stop()
+		}()
+
+		for {
+			var event ctypes.ResultEvent
+			var ok bool
+			select {
+			case event, ok = <-events:
+				if !ok {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+
+			data, ok := event.Data.(cmttypes.EventDataTx)
+			if !ok {
+				continue
+			}
+
+			select {
+			case out <- EventData{
+				Height: data.Height,
+				TxHash: fmt.Sprintf("%X", cmttypes.Tx(data.Tx).Hash()),
+				Code:   data.Result.Code,
+				Log:    data.Result.Log,
+			}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// senderTxQuery returns the query matching the transactions transferring funds from the sender
+func senderTxQuery(sender string) string {
+	return fmt.Sprintf("tm.event = 'Tx' AND transfer.sender = '%s'", sender)
+}
+
+// eventWebsocketURL returns the WebSocket URL of an RPC endpoint, e.g. ws://localhost:26657 for http://localhost:26657
+func eventWebsocketURL(rpcEndpoint string) string {
+	if rest, ok := strings.CutPrefix(rpcEndpoint, "https://"); ok {
+		return "wss://" + rest
+	}
+	if rest, ok := strings.CutPrefix(rpcEndpoint, "http://"); ok {
+		return "ws://" + rest
+	}
+
+	return rpcEndpoint
+}
+
+// logEvents logs the transaction events until the channel is closed
+func logEvents(events <-chan EventData) {
+	for event := range events {
+		if event.Code != 0 {
+			logger.Warn("📨 Transaction included but failed", "height", event.Height, "hash", event.TxHash, "code", event.Code, "log", event.Log)
+			continue
+		}
+
+		logger.Info("📨 Transaction included", "height", event.Height, "hash", event.TxHash)
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"gotest.tools/v3/assert"
+)
+
+// fakeEventClient serves the events of its channel to a single subscription
+type fakeEventClient struct {
+	events       chan ctypes.ResultEvent
+	subscribeErr error
+	query        string
+	stopped      chan struct{}
+}
+
+func (c *fakeEventClient) Start() error {
+	return nil
+}
+
+func (c *fakeEventClient) Stop() error {
+	close(c.stopped)
+	return nil
+}
+
+func (c *fakeEventClient) Subscribe(_ context.Context, _, query string, _ ...int) (<-chan ctypes.ResultEvent, error) {
+	c.query = query
+	return c.events, c.subscribeErr
+}
+
+func TestEventSubscriberSubscribe(t *testing.T) {
+	client := &fakeEventClient{
+		events:  make(chan ctypes.ResultEvent, 2),
+		stopped: make(chan struct{}),
+	}
+	subscriber := &EventSubscriber{
+		newClient: func(wsURL string) (eventClient, error) {
+			assert.Equal(t, wsURL, "ws://localhost:26657")
+			return client, nil
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	query := senderTxQuery("cosmos1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqnrql8a")
+	events, err := subscriber.Subscribe(ctx, "ws://localhost:26657", query)
+	assert.NilError(t, err)
+	assert.Equal(t, client.query, "tm.event = 'Tx' AND transfer.sender = 'cosmos1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqnrql8a'")
+
+	// Events other than transactions are skipped
+	tx := cmttypes.Tx("tx")
+	client.events <- ctypes.ResultEvent{Data: cmttypes.EventDataNewBlock{}}
+	client.events <- ctypes.ResultEvent{Data: cmttypes.EventDataTx{TxResult: abci.TxResult{
+		Height: 7,
+		Tx:     tx,
+		Result: abci.ExecTxResult{Code: 5, Log: "insufficient funds"},
+	}}}
+
+	select {
+	case event := <-events:
+		assert.DeepEqual(t, event, EventData{Height: 7, TxHash: fmt.Sprintf("%X", tx.Hash()), Code: 5, Log: "insufficient funds"})
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event received")
+	}
+
+	// The channel is closed and the client stopped once the context is cancelled
+	cancel()
+	_, ok := <-events
+	assert.Assert(t, !ok)
+	select {
+	case <-client.stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("client not stopped")
+	}
+}
+
+func TestEventSubscriberSubscribeFails(t *testing.T) {
+	client := &fakeEventClient{
+		subscribeErr: errors.New("max subscriptions reached"),
+		stopped:      make(chan struct{}),
+	}
+	subscriber := &EventSubscriber{
+		newClient: func(string) (eventClient, error) {
+			return client, nil
+		},
+	}
+
+	_, err := subscriber.Subscribe(context.Background(), "ws://localhost:26657", senderTxQuery("cosmos1abc"))
+	assert.ErrorContains(t, err, "max subscriptions reached")
+
+	// The connection is released on failure
+	select {
+	case <-client.stopped:
+	default:
+		t.Fatal("client not stopped")
+	}
+}
+
+func TestEventWebsocketURL(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		expected string
+	}{
+		{endpoint: "http://localhost:26657", expected: "ws://localhost:26657"},
+		{endpoint: "https://rpc.cosmos.directory/cosmoshub", expected: "wss://rpc.cosmos.directory/cosmoshub"},
+		{endpoint: "tcp://localhost:26657", expected: "tcp://localhost:26657"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			assert.Equal(t, eventWebsocketURL(tt.endpoint), tt.expected)
+		})
+	}
+}
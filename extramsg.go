@@ -0,0 +1,34 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// validateExtraMsg checks that the extra message is a JSON object with an @type field
+func validateExtraMsg(extraMsg string) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(extraMsg), &fields); err != nil {
+		return fmt.Errorf("invalid extra message: %w", err)
+	}
+
+	if _, ok := fields["@type"]; !ok {
+		return errors.New("invalid extra message: missing @type field")
+	}
+
+	return nil
+}
+
+// parseExtraMsg decodes a proto-JSON encoded sdk.Msg (with an @type field), whose type must be registered in the codec
+func parseExtraMsg(cdc codec.Codec, extraMsg string) (sdk.Msg, error) {
+	var msg sdk.Msg
+	if err := cdc.UnmarshalInterfaceJSON([]byte(extraMsg), &msg); err != nil {
+		return nil, fmt.Errorf("failed to decode extra message as an sdk.Msg: %w", err)
+	}
+
+	return msg, nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"gotest.tools/v3/assert"
+)
+
+func TestValidateExtraMsg(t *testing.T) {
+	tests := []struct {
+		name     string
+		extraMsg string
+		wantErr  string
+	}{
+		{
+			name:     "message with type",
+			extraMsg: `{"@type":"/cosmos.bank.v1beta1.MsgSend"}`,
+		},
+		{
+			name:     "missing type",
+			extraMsg: `{"from_address":"cosmos1from"}`,
+			wantErr:  "missing @type field",
+		},
+		{
+			name:     "invalid json",
+			extraMsg: `{"@type":`,
+			wantErr:  "invalid extra message",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateExtraMsg(tt.extraMsg)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+		})
+	}
+}
+
+func TestExtraMsgInTxBody(t *testing.T) {
+	client, txs := newStakingTestClient(t)
+
+	account, _, err := client.AccountRegistry.Create("alice")
+	assert.NilError(t, err)
+	accountAddr, err := account.Address(mockBech32Prefix)
+	assert.NilError(t, err)
+
+	// The extra message round-trips through the codec of the client
+	extraMsg := `{"@type":"/cosmos.bank.v1beta1.MsgSend","from_address":"` + accountAddr + `","to_address":"` + accountAddr + `","amount":[{"denom":"uatom","amount":"7"}]}`
+	msg, err := parseExtraMsg(client.Context().Codec, extraMsg)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, msg, sdk.Msg(&banktypes.MsgSend{
+		FromAddress: accountAddr,
+		ToAddress:   accountAddr,
+		Amount:      sdk.NewCoins(sdk.NewInt64Coin("uatom", 7)),
+	}))
+
+	_, err = parseExtraMsg(client.Context().Codec, `{"@type":"/cosmos.unknown.v1.MsgUnknown"}`)
+	assert.ErrorContains(t, err, "failed to decode extra message")
+
+	// The extra message is appended after the message of the transaction type
+	config := Config{Fees: "1000uatom", GasLimit: 200000, MsgsPerTx: 1, extraMsg: msg}
+	err = sendTransaction(context.Background(), client, account, config, sdk.NewCoins(sdk.NewInt64Coin("uatom", 1)), "", 0, mockBech32Prefix, "extra", 1)
+	assert.NilError(t, err)
+
+	assert.Equal(t, len(*txs), 1)
+	msgs := (*txs)[0].GetMsgs()
+	assert.Equal(t, len(msgs), 2)
+	assert.DeepEqual(t, msgs[1], msg)
+}
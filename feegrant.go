@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+)
+
+// validateFeeGranter checks that the fee granter is a valid bech32 address
+func validateFeeGranter(address string) error {
+	if _, _, err := bech32.DecodeAndConvert(address); err != nil {
+		return fmt.Errorf("invalid fee granter address %s: %w", address, err)
+	}
+
+	return nil
+}
+
+// parseFeeGranter decodes the fee granter address, which must use the chain bech32 prefix
+func parseFeeGranter(address, bech32Prefix string) (sdk.AccAddress, error) {
+	granter, err := sdk.GetFromBech32(address, bech32Prefix)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fee granter address %s: %w", address, err)
+	}
+
+	return granter, nil
+}
+
+// feeGranterSigner sets the fee granter of each transaction before signing it,
+// so the fees are paid by the granter through x/feegrant
+type feeGranterSigner struct {
+	granter sdk.AccAddress
+}
+
+// Sign sets the fee granter and signs the transaction
+func (s feeGranterSigner) Sign(ctx context.Context, txf tx.Factory, name string, txBuilder client.TxBuilder, overwriteSig bool) error {
+	txBuilder.SetFeeGranter(s.granter)
+	return tx.Sign(ctx, txf, name, txBuilder, overwriteSig)
+}
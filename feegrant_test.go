@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/ignite/cli/v29/ignite/pkg/cosmosclient"
+	"gotest.tools/v3/assert"
+)
+
+func TestValidateFeeGranter(t *testing.T) {
+	granter, err := sdk.Bech32ifyAddressBytes("cosmos", sdk.AccAddress("granter_____________"))
+	assert.NilError(t, err)
+
+	assert.NilError(t, validateFeeGranter(granter))
+	assert.ErrorContains(t, validateFeeGranter("cosmos1invalid"), "invalid fee granter address")
+	assert.ErrorContains(t, validateFeeGranter("granter"), "invalid fee granter address")
+}
+
+func TestParseFeeGranter(t *testing.T) {
+	addr := sdk.AccAddress("granter_____________")
+	granter, err := sdk.Bech32ifyAddressBytes("cosmos", addr)
+	assert.NilError(t, err)
+
+	parsed, err := parseFeeGranter(granter, "cosmos")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, parsed, addr)
+
+	_, err = parseFeeGranter(granter, "osmo")
+	assert.ErrorContains(t, err, "invalid fee granter address")
+}
+
+func TestFeeGranterSignerSetsFeeGranter(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	client, err := cosmosclient.New(context.Background(),
+		cosmosclient.WithRPCClient(newMockRPC()),
+		cosmosclient.WithAccountRetriever(mockAccountRetriever{}),
+		cosmosclient.WithBech32Prefix(mockBech32Prefix),
+		cosmosclient.WithKeyringDir(t.TempDir()),
+		cosmosclient.WithKeyringBackend(DefaultKeyringBackend),
+	)
+	assert.NilError(t, err)
+
+	account, _, err := client.AccountRegistry.Create("alice")
+	assert.NilError(t, err)
+	accountAddr, err := account.Address(mockBech32Prefix)
+	assert.NilError(t, err)
+
+	txBuilder := client.Context().TxConfig.NewTxBuilder()
+	assert.NilError(t, txBuilder.SetMsgs(&banktypes.MsgSend{
+		FromAddress: accountAddr,
+		ToAddress:   accountAddr,
+		Amount:      sdk.NewCoins(sdk.NewInt64Coin("uatom", 1)),
+	}))
+
+	granter := sdk.AccAddress("granter_____________")
+	err = feeGranterSigner{granter: granter}.Sign(context.Background(), client.TxFactory, "alice", txBuilder, true)
+	assert.NilError(t, err)
+
+	bz, err := client.Context().TxConfig.TxEncoder()(txBuilder.GetTx())
+	assert.NilError(t, err)
+
+	var signedTx txtypes.Tx
+	assert.NilError(t, client.Context().Codec.Unmarshal(bz, &signedTx))
+	assert.Equal(t, signedTx.AuthInfo.Fee.Granter, sdk.MustBech32ifyAddressBytes(mockBech32Prefix, granter))
+	assert.Equal(t, len(signedTx.Signatures), 1)
+}
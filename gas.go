@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/ignite/cli/v29/ignite/pkg/cosmosaccount"
+	"github.com/ignite/cli/v29/ignite/pkg/cosmosclient"
+)
+
+// simulateGas simulates a transaction through the gRPC simulate endpoint and returns the gas it used
+func simulateGas(ctx context.Context, client cosmosclient.Client, txBytes []byte) (uint64, error) {
+	resp, err := txtypes.NewServiceClient(client.Context()).Simulate(ctx, &txtypes.SimulateRequest{
+		TxBytes: txBytes,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to simulate transaction: %w", err)
+	}
+
+	return resp.GasInfo.GasUsed, nil
+}
+
+// buildSimTx encodes the messages as an unsigned transaction for simulation
+func buildSimTx(client cosmosclient.Client, account cosmosaccount.Account, config Config, memo string, sequence uint64, msgs ...sdk.Msg) ([]byte, error) {
+	txf := client.TxFactory.
+		WithFromName(account.Name).
+		WithSimulateAndExecute(true).
+		WithSequence(sequence).
+		WithMemo(memo).
+		WithFees(config.Fees)
+
+	txBytes, err := txf.BuildSimTx(msgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build simulation transaction: %w", err)
+	}
+
+	return txBytes, nil
+}
+
+// isOutOfGas reports whether a transaction failed because it ran out of gas
+func isOutOfGas(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "out of gas")
+}
+
+// gasEstimator holds the gas limit estimated by simulating a transaction, shared by all transactions of a run.
+// The estimate is invalidated after resimInterval out-of-gas failures, so the next transaction is simulated again.
+type gasEstimator struct {
+	adjustment    float64
+	resimInterval uint64
+
+	// mu guards limit and outOfGas, and is held during simulation so that a single transaction is simulated
+	mu       sync.Mutex
+	limit    uint64
+	outOfGas uint64
+}
+
+// newGasEstimator creates a gas estimator multiplying the simulated gas by adjustment
+func newGasEstimator(adjustment float64, resimInterval uint64) *gasEstimator {
+	return &gasEstimator{
+		adjustment:    adjustment,
+		resimInterval: resimInterval,
+	}
+}
+
+// GasLimit returns the estimated gas limit, simulating the given messages when there is no estimate yet
+func (g *gasEstimator) GasLimit(ctx context.Context, client cosmosclient.Client, account cosmosaccount.Account, config Config, memo string, sequence uint64, msgs ...sdk.Msg) (uint64, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.limit > 0 {
+		return g.limit, nil
+	}
+
+	txBytes, err := buildSimTx(client, account, config, memo, sequence, msgs...)
+	if err != nil {
+		return 0, err
+	}
+
+	gasUsed, err := simulateGas(ctx, client, txBytes)
+	if err != nil {
+		return 0, err
+	}
+
+	g.limit = g.adjust(gasUsed)
+	logger.Debug("⛽ Simulated gas", "gas_used", gasUsed, "gas_limit", g.limit, "adjustment", g.adjustment)
+
+	return g.limit, nil
+}
+
+// RecordOutOfGas counts an out-of-gas failure, invalidating the estimate once resimInterval is exceeded
+func (g *gasEstimator) RecordOutOfGas() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.outOfGas++
+	if g.resimInterval == 0 || g.outOfGas <= g.resimInterval {
+		return
+	}
+
+	logger.Warn("⛽ Transactions ran out of gas, simulating again", "out_of_gas", g.outOfGas, "gas_limit", g.limit)
+	g.limit = 0
+	g.outOfGas = 0
+}
+
+// adjust multiplies the simulated gas by the gas adjustment
+func (g *gasEstimator) adjust(gasUsed uint64) uint64 {
+	return uint64(float64(gasUsed) * g.adjustment)
+}
+
+// parseGasLimitMap parses a comma-separated list of tx-type=gas-limit pairs, such as bank-send=200000,gov-vote=500000
+func parseGasLimitMap(value string) (map[string]uint64, error) {
+	gasLimits := make(map[string]uint64)
+	if value == "" {
+		return gasLimits, nil
+	}
+
+	for pair := range strings.SplitSeq(value, ",") {
+		txType, gasLimit, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid gas limit map entry %q, must be tx-type=gas-limit", pair)
+		}
+
+		limit, err := strconv.ParseUint(gasLimit, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gas limit %q for %s: %w", gasLimit, txType, err)
+		}
+		gasLimits[txType] = limit
+	}
+
+	return gasLimits, nil
+}
+
+// gasLimitMapValue is the flag value of --gas-limit-map, parsed into a map of gas limits per transaction type
+type gasLimitMapValue struct {
+	gasLimits *map[string]uint64
+}
+
+// String returns the gas limits as sorted tx-type=gas-limit pairs
+func (v gasLimitMapValue) String() string {
+	if v.gasLimits == nil {
+		return ""
+	}
+
+	pairs := make([]string, 0, len(*v.gasLimits))
+	for txType, gasLimit := range *v.gasLimits {
+		pairs = append(pairs, fmt.Sprintf("%s=%d", txType, gasLimit))
+	}
+	slices.Sort(pairs)
+
+	return strings.Join(pairs, ",")
+}
+
+// Set parses the gas limits
+func (v gasLimitMapValue) Set(value string) error {
+	gasLimits, err := parseGasLimitMap(value)
+	if err != nil {
+		return err
+	}
+	*v.gasLimits = gasLimits
+
+	return nil
+}
+
+// Type returns the type shown in the flag usage
+func (v gasLimitMapValue) Type() string {
+	return "string"
+}
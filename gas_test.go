@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/ignite/cli/v29/ignite/pkg/cosmosclient"
+	"gotest.tools/v3/assert"
+)
+
+func TestGasEstimatorAdjust(t *testing.T) {
+	tests := []struct {
+		name       string
+		adjustment float64
+		gasUsed    uint64
+		expected   uint64
+	}{
+		{
+			name:       "default adjustment",
+			adjustment: 1.3,
+			gasUsed:    100000,
+			expected:   130000,
+		},
+		{
+			name:       "no adjustment",
+			adjustment: 1,
+			gasUsed:    85432,
+			expected:   85432,
+		},
+		{
+			name:       "rounded down",
+			adjustment: 1.5,
+			gasUsed:    3,
+			expected:   4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, newGasEstimator(tt.adjustment, 0).adjust(tt.gasUsed), tt.expected)
+		})
+	}
+}
+
+func TestGasEstimatorRecordOutOfGas(t *testing.T) {
+	tests := []struct {
+		name          string
+		resimInterval uint64
+		failures      int
+		expectLimit   uint64
+	}{
+		{
+			name:          "below interval",
+			resimInterval: 3,
+			failures:      3,
+			expectLimit:   100000,
+		},
+		{
+			name:          "above interval",
+			resimInterval: 3,
+			failures:      4,
+			expectLimit:   0,
+		},
+		{
+			name:          "never re-simulate",
+			resimInterval: 0,
+			failures:      100,
+			expectLimit:   100000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			estimator := newGasEstimator(1.3, tt.resimInterval)
+			estimator.limit = 100000
+
+			for range tt.failures {
+				estimator.RecordOutOfGas()
+			}
+
+			assert.Equal(t, estimator.limit, tt.expectLimit)
+		})
+	}
+}
+
+func TestIsOutOfGas(t *testing.T) {
+	assert.Assert(t, isOutOfGas(errors.New("error code: '11' msg: 'out of gas in location: WriteFlat; gasWanted: 100, gasUsed: 1000: out of gas'")))
+	assert.Assert(t, !isOutOfGas(errors.New("error code: '32' msg: 'account sequence mismatch'")))
+	assert.Assert(t, !isOutOfGas(nil))
+}
+
+func TestBuildSimTx(t *testing.T) {
+	client, err := cosmosclient.New(context.Background(),
+		cosmosclient.WithRPCClient(newMockRPC()),
+		cosmosclient.WithAccountRetriever(mockAccountRetriever{}),
+		cosmosclient.WithBech32Prefix(mockBech32Prefix),
+		cosmosclient.WithKeyringDir(t.TempDir()),
+		cosmosclient.WithKeyringBackend(DefaultKeyringBackend),
+	)
+	assert.NilError(t, err)
+
+	account, _, err := client.AccountRegistry.Create("alice")
+	assert.NilError(t, err)
+	accountAddr, err := account.Address(mockBech32Prefix)
+	assert.NilError(t, err)
+
+	bz, err := buildSimTx(client, account, Config{Fees: "1000uatom"}, "sim memo", 7, &banktypes.MsgSend{
+		FromAddress: accountAddr,
+		ToAddress:   accountAddr,
+		Amount:      sdk.NewCoins(sdk.NewInt64Coin("uatom", 1)),
+	})
+	assert.NilError(t, err)
+
+	var simTx txtypes.Tx
+	assert.NilError(t, client.Context().Codec.Unmarshal(bz, &simTx))
+	assert.Equal(t, simTx.Body.Memo, "sim memo")
+	assert.Equal(t, len(simTx.Body.Messages), 1)
+	assert.Equal(t, simTx.AuthInfo.Fee.Amount.String(), "1000uatom")
+	assert.Equal(t, len(simTx.AuthInfo.SignerInfos), 1)
+	assert.Equal(t, simTx.AuthInfo.SignerInfos[0].Sequence, uint64(7))
+}
+
+func TestParseGasLimitMap(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    map[string]uint64
+		wantErr string
+	}{
+		{
+			name:  "empty",
+			value: "",
+			want:  map[string]uint64{},
+		},
+		{
+			name:  "single entry",
+			value: "bank-send=200000",
+			want:  map[string]uint64{txTypeBankSend: 200000},
+		},
+		{
+			name:  "multiple entries with spaces",
+			value: "bank-send=200000, gov-vote=500000",
+			want:  map[string]uint64{txTypeBankSend: 200000, txTypeGovVote: 500000},
+		},
+		{
+			name:    "missing gas limit",
+			value:   "bank-send",
+			wantErr: "must be tx-type=gas-limit",
+		},
+		{
+			name:    "invalid gas limit",
+			value:   "gov-vote=lots",
+			wantErr: "invalid gas limit",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseGasLimitMap(tt.value)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, got, tt.want)
+		})
+	}
+}
+
+func TestGasLimitMapValue(t *testing.T) {
+	var gasLimits map[string]uint64
+	value := gasLimitMapValue{gasLimits: &gasLimits}
+
+	assert.NilError(t, value.Set("gov-vote=500000,bank-send=200000"))
+	assert.DeepEqual(t, gasLimits, map[string]uint64{txTypeBankSend: 200000, txTypeGovVote: 500000})
+	assert.Equal(t, value.String(), "bank-send=200000,gov-vote=500000")
+}
+
+func TestGasLimitForType(t *testing.T) {
+	config := Config{
+		GasLimitMap: map[string]uint64{txTypeBankSend: 200000, txTypeGovVote: 500000},
+	}
+
+	assert.Equal(t, config.GasLimitForType(txTypeGovVote, 100000), uint64(500000))
+	assert.Equal(t, config.GasLimitForType(txTypeBankSend, 100000), uint64(200000))
+	// The default transaction type is a bank send
+	assert.Equal(t, config.GasLimitForType("", 100000), uint64(200000))
+	// Types missing from the map fall back to the default
+	assert.Equal(t, config.GasLimitForType(txTypeSetWithdrawAddress, 100000), uint64(100000))
+	assert.Equal(t, Config{}.GasLimitForType(txTypeGovVote, 100000), uint64(100000))
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	"github.com/ignite/cli/v29/ignite/pkg/cosmosaccount"
+	"github.com/ignite/cli/v29/ignite/pkg/cosmosclient"
+)
+
+// voteOptions maps the accepted --vote-option values to governance vote options
+var voteOptions = map[string]govv1.VoteOption{
+	"yes":          govv1.OptionYes,
+	"no":           govv1.OptionNo,
+	"abstain":      govv1.OptionAbstain,
+	"no-with-veto": govv1.OptionNoWithVeto,
+}
+
+// parseVoteOption converts a --vote-option value to a governance vote option
+func parseVoteOption(option string) (govv1.VoteOption, error) {
+	voteOption, ok := voteOptions[option]
+	if !ok {
+		return govv1.OptionEmpty, fmt.Errorf("unknown vote option %q, must be one of: yes, no, abstain, no-with-veto", option)
+	}
+
+	return voteOption, nil
+}
+
+// sendGovVoteTransaction votes on a governance proposal
+func sendGovVoteTransaction(ctx context.Context, client cosmosclient.Client, account cosmosaccount.Account, config Config, voteOption govv1.VoteOption, txNum uint64, addressPrefix, memo string, sequence uint64) error {
+	accountAddr, err := account.Address(addressPrefix)
+	if err != nil {
+		return fmt.Errorf("failed to get account address: %w", err)
+	}
+
+	voteMsg := &govv1.MsgVote{
+		ProposalId: config.ProposalID,
+		Voter:      msgSender(config, accountAddr),
+		Option:     voteOption,
+	}
+
+	msg, err := buildMsg(config, accountAddr, voteMsg)
+	if err != nil {
+		return err
+	}
+
+	response, err := broadcastTx(ctx, client, account, config, txNum, memo, sequence, msg)
+	if err != nil {
+		return err
+	}
+
+	logger.Debug("🔗 Vote broadcasted", "tx", txNum, "sequence", sequence, "hash", response.TxHash, "proposal", config.ProposalID, "option", voteOption, "memo", memo)
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"testing"
+
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	"gotest.tools/v3/assert"
+)
+
+func TestParseVoteOption(t *testing.T) {
+	tests := []struct {
+		option   string
+		expected govv1.VoteOption
+		wantErr  bool
+	}{
+		{option: "yes", expected: govv1.OptionYes},
+		{option: "no", expected: govv1.OptionNo},
+		{option: "abstain", expected: govv1.OptionAbstain},
+		{option: "no-with-veto", expected: govv1.OptionNoWithVeto},
+		{option: "", wantErr: true},
+		{option: "YES", wantErr: true},
+		{option: "VOTE_OPTION_YES", wantErr: true},
+		{option: "veto", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.option, func(t *testing.T) {
+			option, err := parseVoteOption(tt.option)
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "unknown vote option")
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, option, tt.expected)
+		})
+	}
+}
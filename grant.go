@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	authztypes "github.com/cosmos/cosmos-sdk/x/authz"
+	"github.com/ignite/cli/v29/ignite/pkg/cosmosaccount"
+	"github.com/ignite/cli/v29/ignite/pkg/cosmosclient"
+)
+
+// validateGrantee checks that the grantee is a valid bech32 address
+func validateGrantee(address string) error {
+	if _, _, err := bech32.DecodeAndConvert(address); err != nil {
+		return fmt.Errorf("invalid grantee address %s: %w", address, err)
+	}
+
+	return nil
+}
+
+// validateAuthzMsgType checks that the message type is a type URL, e.g. /cosmos.bank.v1beta1.MsgSend
+func validateAuthzMsgType(msgType string) error {
+	if !strings.HasPrefix(msgType, "/") || len(msgType) == 1 {
+		return fmt.Errorf("invalid authz message type %q, must be a type URL such as /cosmos.bank.v1beta1.MsgSend", msgType)
+	}
+
+	return nil
+}
+
+// grantExpiration returns the expiration of a grant created at now
+func grantExpiration(now time.Time, expiry time.Duration) time.Time {
+	return now.Add(expiry).UTC()
+}
+
+// newGrantMsg creates the message granting the grantee a generic authorization to execute msgType on behalf of the granter
+func newGrantMsg(granter, grantee, msgType string, expiration time.Time) (*authztypes.MsgGrant, error) {
+	authorization, err := codectypes.NewAnyWithValue(authztypes.NewGenericAuthorization(msgType))
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack authorization: %w", err)
+	}
+
+	return &authztypes.MsgGrant{
+		Granter: granter,
+		Grantee: grantee,
+		Grant: authztypes.Grant{
+			Authorization: authorization,
+			Expiration:    &expiration,
+		},
+	}, nil
+}
+
+// sendAuthzGrantTransaction grants the grantee a generic authorization expiring after the grant expiry
+func sendAuthzGrantTransaction(ctx context.Context, client cosmosclient.Client, account cosmosaccount.Account, config Config, txNum uint64, addressPrefix, memo string, sequence uint64) error {
+	accountAddr, err := account.Address(addressPrefix)
+	if err != nil {
+		return fmt.Errorf("failed to get account address: %w", err)
+	}
+
+	grantMsg, err := newGrantMsg(msgSender(config, accountAddr), config.Grantee, config.AuthzMsgType, grantExpiration(time.Now(), config.GrantExpiry))
+	if err != nil {
+		return err
+	}
+
+	msg, err := buildMsg(config, accountAddr, grantMsg)
+	if err != nil {
+		return err
+	}
+
+	response, err := broadcastTx(ctx, client, account, config, txNum, memo, sequence, msg)
+	if err != nil {
+		return err
+	}
+
+	logger.Debug("🔗 Authz grant broadcasted", "tx", txNum, "sequence", sequence, "hash", response.TxHash, "grantee", config.Grantee, "msg_type", config.AuthzMsgType, "memo", memo)
+
+	return nil
+}
+
+// checkGrantee checks that the grantee is an account address of the chain, different from the granter
+func checkGrantee(grantee, granter, bech32Prefix string) error {
+	if _, err := sdk.GetFromBech32(grantee, bech32Prefix); err != nil {
+		return fmt.Errorf("invalid grantee address %s: %w", grantee, err)
+	}
+	if grantee == granter {
+		return fmt.Errorf("grantee %s must be different from the granter", grantee)
+	}
+
+	return nil
+}
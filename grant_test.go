@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authztypes "github.com/cosmos/cosmos-sdk/x/authz"
+	"gotest.tools/v3/assert"
+)
+
+func TestValidateAuthzMsgType(t *testing.T) {
+	assert.NilError(t, validateAuthzMsgType("/cosmos.bank.v1beta1.MsgSend"))
+	assert.ErrorContains(t, validateAuthzMsgType("cosmos.bank.v1beta1.MsgSend"), "invalid authz message type")
+	assert.ErrorContains(t, validateAuthzMsgType("/"), "invalid authz message type")
+	assert.ErrorContains(t, validateAuthzMsgType(""), "invalid authz message type")
+}
+
+func TestGrantExpiration(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.FixedZone("CET", 3600))
+
+	assert.Equal(t, grantExpiration(now, time.Hour), time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC))
+	assert.Equal(t, grantExpiration(now, 48*time.Hour), time.Date(2025, 1, 4, 2, 4, 5, 0, time.UTC))
+}
+
+func TestNewGrantMsg(t *testing.T) {
+	expiration := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	msg, err := newGrantMsg("cosmos1granter", "cosmos1grantee", "/cosmos.bank.v1beta1.MsgSend", expiration)
+	assert.NilError(t, err)
+	assert.Equal(t, msg.Granter, "cosmos1granter")
+	assert.Equal(t, msg.Grantee, "cosmos1grantee")
+	assert.Equal(t, *msg.Grant.Expiration, expiration)
+
+	authorization, ok := msg.Grant.Authorization.GetCachedValue().(*authztypes.GenericAuthorization)
+	assert.Assert(t, ok)
+	assert.Equal(t, authorization.MsgTypeURL(), "/cosmos.bank.v1beta1.MsgSend")
+}
+
+func TestCheckGrantee(t *testing.T) {
+	granter := sdk.MustBech32ifyAddressBytes("cosmos", make([]byte, 20))
+	grantee := sdk.MustBech32ifyAddressBytes("cosmos", append(make([]byte, 19), 1))
+
+	assert.NilError(t, checkGrantee(grantee, granter, "cosmos"))
+	assert.ErrorContains(t, checkGrantee(grantee, granter, "osmo"), "invalid grantee address")
+	assert.ErrorContains(t, checkGrantee(granter, granter, "cosmos"), "must be different from the granter")
+}
+
+func TestSendAuthzGrantTransaction(t *testing.T) {
+	client, txs := newStakingTestClient(t)
+	authztypes.RegisterInterfaces(client.Context().InterfaceRegistry)
+
+	account, _, err := client.AccountRegistry.Create("alice")
+	assert.NilError(t, err)
+	accountAddr, err := account.Address(mockBech32Prefix)
+	assert.NilError(t, err)
+
+	grantee := sdk.MustBech32ifyAddressBytes(mockBech32Prefix, make([]byte, 20))
+	config := Config{
+		Fees:         "1000uatom",
+		GasLimit:     200000,
+		TxType:       txTypeAuthzGrant,
+		Grantee:      grantee,
+		AuthzMsgType: "/cosmos.bank.v1beta1.MsgSend",
+		GrantExpiry:  time.Hour,
+	}
+
+	before := time.Now()
+	err = sendAuthzGrantTransaction(context.Background(), client, account, config, 0, mockBech32Prefix, "grant", 1)
+	assert.NilError(t, err)
+
+	assert.Equal(t, len(*txs), 1)
+	msgs := (*txs)[0].GetMsgs()
+	assert.Equal(t, len(msgs), 1)
+
+	grantMsg, ok := msgs[0].(*authztypes.MsgGrant)
+	assert.Assert(t, ok)
+	assert.Equal(t, grantMsg.Granter, accountAddr)
+	assert.Equal(t, grantMsg.Grantee, grantee)
+	assert.Assert(t, !grantMsg.Grant.Expiration.Before(before.Add(time.Hour).Truncate(time.Second)))
+	assert.Assert(t, !grantMsg.Grant.Expiration.After(time.Now().Add(time.Hour)))
+}
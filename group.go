@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	grouptypes "github.com/cosmos/cosmos-sdk/x/group"
+	"github.com/ignite/cli/v29/ignite/pkg/cosmosaccount"
+	"github.com/ignite/cli/v29/ignite/pkg/cosmosclient"
+)
+
+// loadGroupProposalMessage reads a proto-JSON encoded sdk.Msg (with an @type field) from a file
+func loadGroupProposalMessage(cdc codec.Codec, path string) (sdk.Msg, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read group message file: %w", err)
+	}
+
+	var msg sdk.Msg
+	if err := cdc.UnmarshalInterfaceJSON(bz, &msg); err != nil {
+		return nil, fmt.Errorf("failed to decode group message file %s as an sdk.Msg: %w", path, err)
+	}
+
+	return msg, nil
+}
+
+// fetchGroupPolicyAddress returns the address of the first policy of a group
+func fetchGroupPolicyAddress(ctx context.Context, client cosmosclient.Client, groupID uint64) (string, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	queryClient := grouptypes.NewQueryClient(client.Context())
+
+	resp, err := queryClient.GroupPoliciesByGroup(queryCtx, &grouptypes.QueryGroupPoliciesByGroupRequest{
+		GroupId: groupID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to query policies of group %d: %w", groupID, err)
+	}
+
+	if len(resp.GroupPolicies) == 0 {
+		return "", fmt.Errorf("group %d has no group policy", groupID)
+	}
+
+	return resp.GroupPolicies[0].Address, nil
+}
+
+// sendGroupSubmitProposalTransaction submits a group proposal containing the given message
+func sendGroupSubmitProposalTransaction(ctx context.Context, client cosmosclient.Client, account cosmosaccount.Account, config Config, policyAddress string, proposalMsg sdk.Msg, txNum uint64, addressPrefix, memo string, sequence uint64) error {
+	accountAddr, err := account.Address(addressPrefix)
+	if err != nil {
+		return fmt.Errorf("failed to get account address: %w", err)
+	}
+
+	submitProposalMsg := &grouptypes.MsgSubmitProposal{
+		GroupPolicyAddress: policyAddress,
+		Proposers:          []string{msgSender(config, accountAddr)},
+		Metadata:           config.GroupMetadata,
+		Title:              fmt.Sprintf("spamtx proposal #%d", txNum),
+	}
+	if err := submitProposalMsg.SetMsgs([]sdk.Msg{proposalMsg}); err != nil {
+		return fmt.Errorf("failed to set group proposal messages: %w", err)
+	}
+
+	msg, err := buildMsg(config, accountAddr, submitProposalMsg)
+	if err != nil {
+		return err
+	}
+
+	response, err := broadcastTx(ctx, client, account, config, txNum, memo, sequence, msg)
+	if err != nil {
+		return err
+	}
+
+	logger.Debug("🔗 Group proposal broadcasted", "tx", txNum, "sequence", sequence, "hash", response.TxHash, "group", config.GroupID, "memo", memo)
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"gotest.tools/v3/assert"
+)
+
+func TestLoadGroupProposalMessage(t *testing.T) {
+	interfaceRegistry := codectypes.NewInterfaceRegistry()
+	banktypes.RegisterInterfaces(interfaceRegistry)
+	cdc := codec.NewProtoCodec(interfaceRegistry)
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{
+			name:    "valid bank send message",
+			content: `{"@type":"/cosmos.bank.v1beta1.MsgSend","from_address":"cosmos1from","to_address":"cosmos1to","amount":[{"denom":"uatom","amount":"1"}]}`,
+			wantErr: false,
+		},
+		{
+			name:    "unknown message type",
+			content: `{"@type":"/cosmos.unknown.v1.MsgUnknown"}`,
+			wantErr: true,
+		},
+		{
+			name:    "missing type",
+			content: `{"from_address":"cosmos1from"}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid json",
+			content: `{"@type":`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "msg.json")
+			assert.NilError(t, os.WriteFile(path, []byte(tt.content), 0644))
+
+			msg, err := loadGroupProposalMessage(cdc, path)
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+
+			assert.NilError(t, err)
+			sendMsg, ok := msg.(*banktypes.MsgSend)
+			assert.Assert(t, ok, "expected *banktypes.MsgSend, got %T", msg)
+			assert.Equal(t, sendMsg.ToAddress, "cosmos1to")
+		})
+	}
+}
+
+func TestLoadGroupProposalMessageMissingFile(t *testing.T) {
+	cdc := codec.NewProtoCodec(codectypes.NewInterfaceRegistry())
+
+	_, err := loadGroupProposalMessage(cdc, filepath.Join(t.TempDir(), "missing.json"))
+	assert.ErrorContains(t, err, "failed to read group message file")
+}
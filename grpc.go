@@ -0,0 +1,265 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+	"github.com/cometbft/cometbft/p2p"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/client/grpc/cmtservice"
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	consensustypes "github.com/cosmos/cosmos-sdk/x/consensus/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// errGRPCUnsupported is returned for RPC calls that have no gRPC equivalent
+var errGRPCUnsupported = errors.New("not supported over gRPC")
+
+// grpcRPC is an RPC client sending queries and transactions to the gRPC endpoint of a node,
+// for nodes whose CometBFT RPC is not reachable.
+// Only the calls made by spamtx are implemented.
+type grpcRPC struct {
+	rpcclient.Client
+
+	conn *grpc.ClientConn
+}
+
+// newGRPCRPC creates an RPC client for the gRPC endpoint at addr (host:port)
+func newGRPCRPC(addr string) (*grpcRPC, error) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for %s: %w", addr, err)
+	}
+
+	return &grpcRPC{conn: conn}, nil
+}
+
+// Close closes the gRPC connection
+func (c *grpcRPC) Close() error {
+	return c.conn.Close()
+}
+
+// Status reports the node info and latest block of the node
+func (c *grpcRPC) Status(ctx context.Context) (*ctypes.ResultStatus, error) {
+	service := cmtservice.NewServiceClient(c.conn)
+
+	nodeInfoResp, err := service.GetNodeInfo(ctx, &cmtservice.GetNodeInfoRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query node info: %w", err)
+	}
+
+	nodeInfo, err := p2p.DefaultNodeInfoFromToProto(nodeInfoResp.DefaultNodeInfo)
+	if err != nil {
+		return nil, fmt.Errorf("invalid node info: %w", err)
+	}
+
+	blockResp, err := service.GetLatestBlock(ctx, &cmtservice.GetLatestBlockRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query latest block: %w", err)
+	}
+
+	syncingResp, err := service.GetSyncing(ctx, &cmtservice.GetSyncingRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query syncing status: %w", err)
+	}
+
+	return &ctypes.ResultStatus{
+		NodeInfo: nodeInfo,
+		SyncInfo: ctypes.SyncInfo{
+			LatestBlockHeight: blockResp.GetSdkBlock().GetHeader().Height,
+			LatestBlockTime:   blockResp.GetSdkBlock().GetHeader().Time,
+			CatchingUp:        syncingResp.Syncing,
+		},
+	}, nil
+}
+
+// ABCIQuery queries the application at the latest height
+func (c *grpcRPC) ABCIQuery(ctx context.Context, path string, data cmtbytes.HexBytes) (*ctypes.ResultABCIQuery, error) {
+	return c.ABCIQueryWithOptions(ctx, path, data, rpcclient.DefaultABCIQueryOptions)
+}
+
+// ABCIQueryWithOptions queries the application.
+// gRPC query paths, such as /cosmos.auth.v1beta1.Query/Account, are called directly on the gRPC endpoint,
+// while other ABCI query paths go through the CometBFT service.
+func (c *grpcRPC) ABCIQueryWithOptions(ctx context.Context, path string, data cmtbytes.HexBytes, opts rpcclient.ABCIQueryOptions) (*ctypes.ResultABCIQuery, error) {
+	if isABCIQueryPath(path) {
+		resp, err := cmtservice.NewServiceClient(c.conn).ABCIQuery(ctx, &cmtservice.ABCIQueryRequest{
+			Data:   data,
+			Path:   path,
+			Height: opts.Height,
+			Prove:  opts.Prove,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &ctypes.ResultABCIQuery{
+			Response: abci.ResponseQuery{
+				Code:      resp.Code,
+				Log:       resp.Log,
+				Info:      resp.Info,
+				Index:     resp.Index,
+				Key:       resp.Key,
+				Value:     resp.Value,
+				Height:    resp.Height,
+				Codespace: resp.Codespace,
+			},
+		}, nil
+	}
+
+	if opts.Height > 0 {
+		ctx = metadata.AppendToOutgoingContext(ctx, grpctypes.GRPCBlockHeightHeader, strconv.FormatInt(opts.Height, 10))
+	}
+
+	var header metadata.MD
+	var reply rawMessage
+	if err := c.conn.Invoke(ctx, path, rawMessage(data), &reply, grpc.ForceCodec(rawCodec{}), grpc.Header(&header)); err != nil {
+		return nil, err
+	}
+
+	var height int64
+	if values := header.Get(grpctypes.GRPCBlockHeightHeader); len(values) > 0 {
+		height, _ = strconv.ParseInt(values[0], 10, 64)
+	}
+
+	return &ctypes.ResultABCIQuery{
+		Response: abci.ResponseQuery{
+			Value:  reply,
+			Height: height,
+		},
+	}, nil
+}
+
+// BroadcastTxSync broadcasts a transaction and waits for its CheckTx result
+func (c *grpcRPC) BroadcastTxSync(ctx context.Context, tx cmttypes.Tx) (*ctypes.ResultBroadcastTx, error) {
+	return c.broadcastTx(ctx, tx, txtypes.BroadcastMode_BROADCAST_MODE_SYNC)
+}
+
+// BroadcastTxAsync broadcasts a transaction without waiting for its CheckTx result
+func (c *grpcRPC) BroadcastTxAsync(ctx context.Context, tx cmttypes.Tx) (*ctypes.ResultBroadcastTx, error) {
+	return c.broadcastTx(ctx, tx, txtypes.BroadcastMode_BROADCAST_MODE_ASYNC)
+}
+
+// BroadcastTxCommit is not supported, the gRPC tx service no longer waits for blocks
+func (c *grpcRPC) BroadcastTxCommit(context.Context, cmttypes.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
+	return nil, fmt.Errorf("broadcast tx commit %w", errGRPCUnsupported)
+}
+
+// broadcastTx broadcasts a transaction through the gRPC tx service
+func (c *grpcRPC) broadcastTx(ctx context.Context, tx cmttypes.Tx, mode txtypes.BroadcastMode) (*ctypes.ResultBroadcastTx, error) {
+	resp, err := txtypes.NewServiceClient(c.conn).BroadcastTx(ctx, &txtypes.BroadcastTxRequest{
+		TxBytes: tx,
+		Mode:    mode,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ctypes.ResultBroadcastTx{
+		Code:      resp.TxResponse.Code,
+		Log:       resp.TxResponse.RawLog,
+		Codespace: resp.TxResponse.Codespace,
+		Hash:      tx.Hash(),
+	}, nil
+}
+
+// Tx returns an included transaction by hash
+func (c *grpcRPC) Tx(ctx context.Context, hash []byte, _ bool) (*ctypes.ResultTx, error) {
+	resp, err := txtypes.NewServiceClient(c.conn).GetTx(ctx, &txtypes.GetTxRequest{
+		Hash: strings.ToUpper(hex.EncodeToString(hash)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ctypes.ResultTx{
+		Hash:   hash,
+		Height: resp.TxResponse.Height,
+		TxResult: abci.ExecTxResult{
+			Code:      resp.TxResponse.Code,
+			Log:       resp.TxResponse.RawLog,
+			GasWanted: resp.TxResponse.GasWanted,
+			GasUsed:   resp.TxResponse.GasUsed,
+			Codespace: resp.TxResponse.Codespace,
+		},
+	}, nil
+}
+
+// ConsensusParams returns the consensus params stored in the consensus module, at the latest height
+func (c *grpcRPC) ConsensusParams(ctx context.Context, _ *int64) (*ctypes.ResultConsensusParams, error) {
+	resp, err := consensustypes.NewQueryClient(c.conn).Params(ctx, &consensustypes.QueryParamsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Params == nil {
+		return nil, errors.New("consensus params not set")
+	}
+
+	return &ctypes.ResultConsensusParams{
+		ConsensusParams: cmttypes.ConsensusParamsFromProto(*resp.Params),
+	}, nil
+}
+
+// BlockchainInfo is not supported, block metas are not exposed over gRPC
+func (c *grpcRPC) BlockchainInfo(context.Context, int64, int64) (*ctypes.ResultBlockchainInfo, error) {
+	return nil, fmt.Errorf("blockchain info %w", errGRPCUnsupported)
+}
+
+// isABCIQueryPath reports whether path is an ABCI query path (app, store, p2p or custom) rather than a gRPC method
+func isABCIQueryPath(path string) bool {
+	parts := baseapp.SplitABCIQueryPath(path)
+	if len(parts) == 0 {
+		return false
+	}
+
+	switch parts[0] {
+	case baseapp.QueryPathApp, baseapp.QueryPathStore, baseapp.QueryPathP2P, baseapp.QueryPathCustom:
+		return true
+	default:
+		return false
+	}
+}
+
+// rawMessage is an already encoded protobuf message
+type rawMessage []byte
+
+// rawCodec passes already encoded protobuf messages through gRPC calls
+type rawCodec struct{}
+
+// Marshal returns the encoded message
+func (rawCodec) Marshal(v any) ([]byte, error) {
+	msg, ok := v.(rawMessage)
+	if !ok {
+		return nil, fmt.Errorf("unexpected message type %T", v)
+	}
+
+	return msg, nil
+}
+
+// Unmarshal stores the encoded message
+func (rawCodec) Unmarshal(data []byte, v any) error {
+	msg, ok := v.(*rawMessage)
+	if !ok {
+		return fmt.Errorf("unexpected message type %T", v)
+	}
+
+	*msg = append((*msg)[:0], data...)
+	return nil
+}
+
+// Name returns the codec name, proto, as the messages are protobuf encoded
+func (rawCodec) Name() string {
+	return "proto"
+}
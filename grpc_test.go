@@ -0,0 +1,44 @@
+package main
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestIsABCIQueryPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		{path: "/store/acc/key", expected: true},
+		{path: "/app/simulate", expected: true},
+		{path: "/custom/gov/proposal", expected: true},
+		{path: "/p2p/filter/addr/127.0.0.1", expected: true},
+		{path: "/cosmos.auth.v1beta1.Query/Account", expected: false},
+		{path: "/cosmos.tx.v1beta1.Service/Simulate", expected: false},
+		{path: "", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, isABCIQueryPath(tt.path), tt.expected)
+		})
+	}
+}
+
+func TestRawCodec(t *testing.T) {
+	codec := rawCodec{}
+
+	bz, err := codec.Marshal(rawMessage("request"))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, bz, []byte("request"))
+
+	var reply rawMessage
+	assert.NilError(t, codec.Unmarshal([]byte("reply"), &reply))
+	assert.DeepEqual(t, []byte(reply), []byte("reply"))
+
+	_, err = codec.Marshal("request")
+	assert.ErrorContains(t, err, "unexpected message type")
+	assert.ErrorContains(t, codec.Unmarshal([]byte("reply"), &bz), "unexpected message type")
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/go-bip39"
+	"github.com/ignite/cli/v29/ignite/pkg/cosmosaccount"
+)
+
+// accountRegistry looks up, creates and imports keyring accounts
+type accountRegistry interface {
+	GetByName(name string) (cosmosaccount.Account, error)
+	Create(name string) (cosmosaccount.Account, string, error)
+	Import(name, secret, passphrase string) (cosmosaccount.Account, error)
+}
+
+// hdPathRegistry wraps an account registry to derive created and imported accounts at HDPath.
+// An empty HDPath uses the registry default, m/44'/<coin type>'/0'/0/0.
+type hdPathRegistry struct {
+	cosmosaccount.Registry
+
+	HDPath string
+}
+
+// Create creates a new account with a random mnemonic, derived at the HD path
+func (r hdPathRegistry) Create(name string) (cosmosaccount.Account, string, error) {
+	if r.HDPath == "" {
+		return r.Registry.Create(name)
+	}
+
+	if err := r.ensureNotExists(name); err != nil {
+		return cosmosaccount.Account{}, "", err
+	}
+
+	entropy, err := bip39.NewEntropy(256)
+	if err != nil {
+		return cosmosaccount.Account{}, "", err
+	}
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	if err != nil {
+		return cosmosaccount.Account{}, "", err
+	}
+
+	record, err := r.Keyring.NewAccount(name, mnemonic, "", r.HDPath, hd.Secp256k1)
+	if err != nil {
+		return cosmosaccount.Account{}, "", err
+	}
+
+	return cosmosaccount.Account{Name: name, Record: record}, mnemonic, nil
+}
+
+// Import imports an account from a mnemonic, derived at the HD path, or from a private key
+func (r hdPathRegistry) Import(name, secret, passphrase string) (cosmosaccount.Account, error) {
+	if r.HDPath == "" || !bip39.IsMnemonicValid(secret) {
+		return r.Registry.Import(name, secret, passphrase)
+	}
+
+	if err := r.ensureNotExists(name); err != nil {
+		return cosmosaccount.Account{}, err
+	}
+
+	if _, err := r.Keyring.NewAccount(name, secret, passphrase, r.HDPath, hd.Secp256k1); err != nil {
+		return cosmosaccount.Account{}, err
+	}
+
+	return r.GetByName(name)
+}
+
+// ensureNotExists returns cosmosaccount.ErrAccountExists when the account already exists
+func (r hdPathRegistry) ensureNotExists(name string) error {
+	_, err := r.GetByName(name)
+	if err == nil {
+		return cosmosaccount.ErrAccountExists
+	}
+
+	var accountDoesNotExistError *cosmosaccount.AccountDoesNotExistError
+	if !errors.As(err, &accountDoesNotExistError) {
+		return err
+	}
+
+	return nil
+}
+
+// validateHDPath checks that path is a BIP32 derivation path, such as m/44'/118'/0'/0/0
+func validateHDPath(path string) error {
+	components, ok := strings.CutPrefix(path, "m/")
+	if !ok {
+		return fmt.Errorf("invalid HD path %q: must start with m/", path)
+	}
+
+	for _, component := range strings.Split(components, "/") {
+		index := strings.TrimSuffix(component, "'")
+		// Indexes are below 2^31, the hardened bit being set by the apostrophe
+		if _, err := strconv.ParseUint(index, 10, 31); err != nil {
+			return fmt.Errorf("invalid HD path %q: invalid index %q", path, component)
+		}
+	}
+
+	return nil
+}
+
+// childHDPath returns the path of the child key at index: the account path, m/44'/118'/0'/0/0 when empty,
+// with its last index replaced, e.g. m/44'/118'/0'/0/3 for index 3
+func childHDPath(accountPath string, index uint32) string {
+	if accountPath == "" {
+		return hd.CreateHDPath(sdk.CoinType, 0, index).String()
+	}
+
+	parent := accountPath[:strings.LastIndex(accountPath, "/")]
+	return fmt.Sprintf("%s/%d", parent, index)
+}
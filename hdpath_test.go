@@ -0,0 +1,125 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/ignite/cli/v29/ignite/pkg/cosmosaccount"
+	"gotest.tools/v3/assert"
+)
+
+func TestValidateHDPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		wantErr string
+	}{
+		{
+			name: "default cosmos path",
+			path: "m/44'/118'/0'/0/0",
+		},
+		{
+			name: "custom account index",
+			path: "m/44'/60'/2'/0/7",
+		},
+		{
+			name:    "missing m prefix",
+			path:    "44'/118'/0'/0/0",
+			wantErr: "must start with m/",
+		},
+		{
+			name:    "non numeric index",
+			path:    "m/44'/atom'/0'/0/0",
+			wantErr: "invalid index",
+		},
+		{
+			name:    "empty index",
+			path:    "m/44'//0'/0/0",
+			wantErr: "invalid index",
+		},
+		{
+			name:    "index out of range",
+			path:    "m/44'/118'/0'/0/2147483648",
+			wantErr: "invalid index",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateHDPath(tt.path)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NilError(t, err)
+			}
+		})
+	}
+}
+
+func TestHDPathRegistryCreate(t *testing.T) {
+	registry, err := cosmosaccount.NewInMemory(cosmosaccount.WithBech32Prefix("cosmos"))
+	assert.NilError(t, err)
+
+	custom := hdPathRegistry{Registry: registry, HDPath: "m/44'/118'/0'/0/1"}
+	account, mnemonic, err := custom.Create("alice")
+	assert.NilError(t, err)
+
+	_, _, err = custom.Create("alice")
+	assert.ErrorIs(t, err, cosmosaccount.ErrAccountExists)
+
+	stored, err := registry.GetByName("alice")
+	assert.NilError(t, err)
+	address, err := stored.Address("cosmos")
+	assert.NilError(t, err)
+	created, err := account.Address("cosmos")
+	assert.NilError(t, err)
+	assert.Equal(t, address, created)
+
+	// The same mnemonic at the default path gives another address
+	defaultAccount, err := hdPathRegistry{Registry: registry}.Import("bob", mnemonic, "")
+	assert.NilError(t, err)
+	defaultAddress, err := defaultAccount.Address("cosmos")
+	assert.NilError(t, err)
+	assert.Assert(t, defaultAddress != address)
+
+	// Importing the mnemonic at the custom path in another keyring gives the same address
+	other, err := cosmosaccount.NewInMemory(cosmosaccount.WithBech32Prefix("cosmos"))
+	assert.NilError(t, err)
+	imported, err := hdPathRegistry{Registry: other, HDPath: custom.HDPath}.Import("alice", mnemonic, "")
+	assert.NilError(t, err)
+	importedAddress, err := imported.Address("cosmos")
+	assert.NilError(t, err)
+	assert.Equal(t, importedAddress, address)
+}
+
+func TestChildHDPath(t *testing.T) {
+	tests := []struct {
+		name        string
+		accountPath string
+		index       uint32
+		want        string
+	}{
+		{
+			name:  "default path",
+			index: 3,
+			want:  "m/44'/118'/0'/0/3",
+		},
+		{
+			name:        "custom address index",
+			accountPath: "m/44'/118'/0'/0/1",
+			index:       3,
+			want:        "m/44'/118'/0'/0/3",
+		},
+		{
+			name:        "custom account and coin type",
+			accountPath: "m/44'/60'/2'/0/0",
+			index:       5,
+			want:        "m/44'/60'/2'/0/5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, childHDPath(tt.accountPath, tt.index), tt.want)
+		})
+	}
+}
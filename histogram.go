@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+// latencyWindowSize is the number of latest broadcast latencies the percentiles are computed from
+const latencyWindowSize = 100_000
+
+// latencyPercentiles are the percentiles printed in the latency table
+var latencyPercentiles = []float64{50, 90, 95, 99, 99.9}
+
+// latencyHistogram records the broadcast latencies in a ring buffer, keeping the latest ones
+// along with the count and the maximum of all of them. It is safe for concurrent use.
+type latencyHistogram struct {
+	mu        sync.Mutex
+	latencies []time.Duration
+	next      int
+	full      bool
+	count     uint64
+	max       time.Duration
+}
+
+// newLatencyHistogram creates a histogram keeping the last size latencies
+func newLatencyHistogram(size int) *latencyHistogram {
+	return &latencyHistogram{
+		latencies: make([]time.Duration, size),
+	}
+}
+
+// Record adds the latency of a broadcast
+func (h *latencyHistogram) Record(latency time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.latencies[h.next] = latency
+	h.next = (h.next + 1) % len(h.latencies)
+	if h.next == 0 {
+		h.full = true
+	}
+
+	h.count++
+	h.max = max(h.max, latency)
+}
+
+// Percentiles returns the latencies at the given percentiles, using the nearest-rank method,
+// along with the number of recorded latencies and their maximum
+func (h *latencyHistogram) Percentiles(percentiles []float64) ([]time.Duration, uint64, time.Duration) {
+	h.mu.Lock()
+	window := h.latencies[:h.next]
+	if h.full {
+		window = h.latencies
+	}
+	sorted := slices.Clone(window)
+	count, maxLatency := h.count, h.max
+	h.mu.Unlock()
+
+	if len(sorted) == 0 {
+		return nil, 0, 0
+	}
+	slices.Sort(sorted)
+
+	values := make([]time.Duration, len(percentiles))
+	for i, p := range percentiles {
+		// The epsilon keeps float errors, e.g. 99.9% of 1000 computed as 999.0000000000001, from skipping a rank
+		rank := int(math.Ceil(p/100*float64(len(sorted)) - 1e-9))
+		values[i] = sorted[min(max(rank, 1), len(sorted))-1]
+	}
+
+	return values, count, maxLatency
+}
+
+// Print prints the percentile table of the broadcast latencies
+func (h *latencyHistogram) Print() {
+	values, count, maxLatency := h.Percentiles(latencyPercentiles)
+	if count == 0 {
+		fmt.Println("⏱️ Broadcast latency: no transaction broadcasted")
+		return
+	}
+
+	fmt.Printf("⏱️ Broadcast latency (%d transactions):\n", count)
+	for i, p := range latencyPercentiles {
+		fmt.Printf("%8s %12s\n", fmt.Sprintf("p%g", p), values[i].Round(time.Microsecond))
+	}
+	fmt.Printf("%8s %12s\n", "max", maxLatency.Round(time.Microsecond))
+}
+
+// Run prints the percentile table every interval until the context is cancelled
+func (h *latencyHistogram) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			h.Print()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestLatencyHistogramPercentiles(t *testing.T) {
+	h := newLatencyHistogram(1000)
+
+	values, count, maxLatency := h.Percentiles(latencyPercentiles)
+	assert.Assert(t, values == nil)
+	assert.Equal(t, count, uint64(0))
+	assert.Equal(t, maxLatency, time.Duration(0))
+
+	// Record 1ms to 1000ms in reverse order
+	for i := 1000; i >= 1; i-- {
+		h.Record(time.Duration(i) * time.Millisecond)
+	}
+
+	values, count, maxLatency = h.Percentiles(latencyPercentiles)
+	assert.DeepEqual(t, values, []time.Duration{
+		500 * time.Millisecond,
+		900 * time.Millisecond,
+		950 * time.Millisecond,
+		990 * time.Millisecond,
+		999 * time.Millisecond,
+	})
+	assert.Equal(t, count, uint64(1000))
+	assert.Equal(t, maxLatency, time.Second)
+}
+
+func TestLatencyHistogramWindow(t *testing.T) {
+	h := newLatencyHistogram(4)
+
+	h.Record(time.Hour)
+	for range 4 {
+		h.Record(time.Millisecond)
+	}
+
+	// The percentiles only use the last 4 latencies, the count and maximum use all of them
+	values, count, maxLatency := h.Percentiles([]float64{50, 100})
+	assert.DeepEqual(t, values, []time.Duration{time.Millisecond, time.Millisecond})
+	assert.Equal(t, count, uint64(5))
+	assert.Equal(t, maxLatency, time.Hour)
+}
+
+func TestLatencyHistogramConcurrent(t *testing.T) {
+	h := newLatencyHistogram(latencyWindowSize)
+
+	var wg sync.WaitGroup
+	for i := range 100 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			h.Record(time.Duration(i+1) * time.Millisecond)
+		}()
+	}
+	wg.Wait()
+
+	values, count, maxLatency := h.Percentiles([]float64{50})
+	assert.DeepEqual(t, values, []time.Duration{50 * time.Millisecond})
+	assert.Equal(t, count, uint64(100))
+	assert.Equal(t, maxLatency, 100*time.Millisecond)
+}
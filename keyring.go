@@ -1,12 +1,18 @@
 package main
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/ignite/cli/v29/ignite/pkg/cosmosaccount"
 )
 
@@ -16,30 +22,65 @@ const (
 
 	// DefaultKeyringBackend is the default keyring backend
 	DefaultKeyringBackend = cosmosaccount.KeyringTest
+
+	// keyringBackendFile is the encrypted file keyring backend
+	keyringBackendFile cosmosaccount.KeyringBackend = "file"
 )
 
+// supportedKeyringBackends lists the keyring backends that can be selected with --keyring-backend
+var supportedKeyringBackends = []cosmosaccount.KeyringBackend{
+	cosmosaccount.KeyringTest,
+	cosmosaccount.KeyringOS,
+	keyringBackendFile,
+	keyringBackendVault,
+}
+
+// KeyringOptions holds the options shared by the keyring subcommands
+type KeyringOptions struct {
+	RPC          string
+	Bech32Prefix string
+	Backend      cosmosaccount.KeyringBackend
+}
+
+// offline returns true when the chain registry does not need to be queried
+func (o KeyringOptions) offline() bool {
+	return o.RPC != "" && o.Bech32Prefix != ""
+}
+
 // initializeKeyring creates and configures a cosmos keyring for the specified chain
-func initializeKeyring(chainName string) (cosmosaccount.Registry, string, error) {
+func initializeKeyring(chainName string, opts KeyringOptions) (cosmosaccount.Registry, string, error) {
 	if chainName == "" {
 		return cosmosaccount.Registry{}, "", fmt.Errorf("chain name cannot be empty")
 	}
 
-	// Get chain information to determine bech32 prefix
-	_, bech32Prefix, err := getChainInfo(chainName)
+	// Get chain information to determine bech32 prefix, unless fully provided
+	bech32Prefix := opts.Bech32Prefix
+	if !opts.offline() {
+		var err error
+		_, bech32Prefix, err = getChainInfo(chainName)
+		if err != nil {
+			return cosmosaccount.Registry{}, "", fmt.Errorf("failed to get chain info: %w", err)
+		}
+	}
+
+	backend, err := resolveKeyringBackend(opts.Backend)
 	if err != nil {
-		return cosmosaccount.Registry{}, "", fmt.Errorf("failed to get chain info: %w", err)
+		return cosmosaccount.Registry{}, "", err
+	}
+	if backend == keyringBackendVault {
+		return cosmosaccount.Registry{}, "", errVaultKeyringReadOnly
 	}
 
 	// Create keyring home directory
-	homeDir, err := getKeyringHome()
+	keyringHome, err := getKeyringHome()
 	if err != nil {
 		return cosmosaccount.Registry{}, "", fmt.Errorf("failed to get keyring home: %w", err)
 	}
 
 	// Create the keyring with chain-specific configuration
 	registry, err := cosmosaccount.New(
-		cosmosaccount.WithHome(homeDir),
-		cosmosaccount.WithKeyringBackend(DefaultKeyringBackend),
+		cosmosaccount.WithHome(keyringHome),
+		cosmosaccount.WithKeyringBackend(backend),
 		cosmosaccount.WithKeyringServiceName(DefaultKeyringServiceName),
 		cosmosaccount.WithBech32Prefix(bech32Prefix),
 	)
@@ -50,14 +91,48 @@ func initializeKeyring(chainName string) (cosmosaccount.Registry, string, error)
 	return registry, bech32Prefix, nil
 }
 
+// resolveKeyringBackend validates the keyring backend, defaulting to DefaultKeyringBackend when empty
+func resolveKeyringBackend(backend cosmosaccount.KeyringBackend) (cosmosaccount.KeyringBackend, error) {
+	if backend == "" {
+		return DefaultKeyringBackend, nil
+	}
+
+	if !slices.Contains(supportedKeyringBackends, backend) {
+		supported := make([]string, len(supportedKeyringBackends))
+		for i, b := range supportedKeyringBackends {
+			supported[i] = string(b)
+		}
+		return "", fmt.Errorf("unsupported keyring backend %q, must be one of: %s", backend, strings.Join(supported, ", "))
+	}
+
+	return backend, nil
+}
+
+// homeDir overrides the spamtx home directory when set
+var homeDir string
+
+// getSpamtxHome returns the spamtx home directory, ~/.spamtx unless overridden with --home
+func getSpamtxHome() (string, error) {
+	if homeDir != "" {
+		return homeDir, nil
+	}
+
+	userHome, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
+
+	return filepath.Join(userHome, ".spamtx"), nil
+}
+
 // getKeyringHome returns the home directory for the keyring
 func getKeyringHome() (string, error) {
-	homeDir, err := os.UserHomeDir()
+	spamtxHome, err := getSpamtxHome()
 	if err != nil {
-		return "", fmt.Errorf("failed to get user home directory: %w", err)
+		return "", err
 	}
 
-	keyringHome := filepath.Join(homeDir, ".spamtx", "keyring")
+	keyringHome := filepath.Join(spamtxHome, "keyring")
 
 	// Create directory if it doesn't exist
 	if err := os.MkdirAll(keyringHome, 0755); err != nil {
@@ -68,7 +143,7 @@ func getKeyringHome() (string, error) {
 }
 
 // getOrCreateAccount retrieves an existing account or creates a new one if it doesn't exist
-func getOrCreateAccount(registry cosmosaccount.Registry, accountName string) (cosmosaccount.Account, bool, error) {
+func getOrCreateAccount(registry accountRegistry, accountName string) (cosmosaccount.Account, bool, error) {
 	if err := validateAccountName(accountName); err != nil {
 		return cosmosaccount.Account{}, false, err
 	}
@@ -145,8 +220,75 @@ func listAccounts(registry cosmosaccount.Registry, bech32Prefix string) error {
 	return nil
 }
 
+// accountInfo is the public information of a keyring account
+type accountInfo struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+	PubKey  string `json:"pubkey"`
+	KeyType string `json:"key_type"`
+}
+
+// getAccountInfo returns the address, public key and key type of a single account
+func getAccountInfo(registry cosmosaccount.Registry, name, bech32Prefix string) (accountInfo, error) {
+	if err := validateAccountName(name); err != nil {
+		return accountInfo{}, err
+	}
+
+	account, err := registry.GetByName(name)
+	if err != nil {
+		var accountDoesNotExistError *cosmosaccount.AccountDoesNotExistError
+		if errors.As(err, &accountDoesNotExistError) {
+			return accountInfo{}, fmt.Errorf("account '%s' does not exist", name)
+		}
+		return accountInfo{}, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	address, err := account.Address(bech32Prefix)
+	if err != nil {
+		return accountInfo{}, fmt.Errorf("failed to get account address: %w", err)
+	}
+
+	pubKey, err := account.Record.GetPubKey()
+	if err != nil {
+		return accountInfo{}, fmt.Errorf("failed to get account public key: %w", err)
+	}
+
+	return accountInfo{
+		Name:    account.Name,
+		Address: address,
+		PubKey:  fmt.Sprintf("%X", pubKey.Bytes()),
+		KeyType: pubKey.Type(),
+	}, nil
+}
+
+// showAccount prints a single account as text or JSON
+func showAccount(registry cosmosaccount.Registry, name, bech32Prefix, output string) error {
+	info, err := getAccountInfo(registry, name, bech32Prefix)
+	if err != nil {
+		return err
+	}
+
+	switch output {
+	case "json":
+		bz, err := json.Marshal(info)
+		if err != nil {
+			return fmt.Errorf("failed to encode account: %w", err)
+		}
+		fmt.Println(string(bz))
+	case "text":
+		fmt.Printf("Account '%s':\n", info.Name)
+		fmt.Printf("   Address: %s\n", info.Address)
+		fmt.Printf("   Public key: %s\n", info.PubKey)
+		fmt.Printf("   Key type: %s\n", info.KeyType)
+	default:
+		return fmt.Errorf("unknown output format %q, must be one of: text, json", output)
+	}
+
+	return nil
+}
+
 // importAccount imports an account from a mnemonic or private key
-func importAccount(registry cosmosaccount.Registry, name, secret, passphrase, bech32prefix string) error {
+func importAccount(registry accountRegistry, name, secret, passphrase, bech32prefix string) error {
 	if err := validateAccountName(name); err != nil {
 		return err
 	}
@@ -169,6 +311,28 @@ func importAccount(registry cosmosaccount.Registry, name, secret, passphrase, be
 	return nil
 }
 
+// resolveImportSecret returns the secret to import, either from the positional argument
+// or, when fromEnv is set, from the environment variable it names
+func resolveImportSecret(args []string, fromEnv string) (string, error) {
+	if fromEnv == "" {
+		if len(args) == 0 {
+			return "", fmt.Errorf("mnemonic or private key is required, as argument or with --%s", flagFromEnv)
+		}
+		return args[0], nil
+	}
+
+	if len(args) > 0 {
+		return "", fmt.Errorf("mnemonic argument and --%s are mutually exclusive", flagFromEnv)
+	}
+
+	secret, ok := os.LookupEnv(fromEnv)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", fromEnv)
+	}
+
+	return secret, nil
+}
+
 // deleteAccount removes an account from the keyring
 func deleteAccount(registry cosmosaccount.Registry, name string) error {
 	if err := validateAccountName(name); err != nil {
@@ -193,3 +357,178 @@ func deleteAccount(registry cosmosaccount.Registry, name string) error {
 	fmt.Printf("✅ Successfully deleted account '%s'\n", name)
 	return nil
 }
+
+// bulkCreateAccounts creates count accounts named prefix-0 to prefix-(count-1), e.g. the senders of a multi-sender run.
+// It fails without creating any account when one of the names is invalid or already taken.
+func bulkCreateAccounts(registry cosmosaccount.Registry, prefix string, count int) ([]cosmosaccount.Account, error) {
+	if count <= 0 {
+		return nil, fmt.Errorf("count must be greater than 0")
+	}
+
+	names := make([]string, count)
+	for i := range names {
+		names[i] = fmt.Sprintf("%s-%d", prefix, i)
+		if err := validateAccountName(names[i]); err != nil {
+			return nil, err
+		}
+
+		_, err := registry.GetByName(names[i])
+		if err == nil {
+			return nil, fmt.Errorf("account '%s' already exists", names[i])
+		}
+		var accountDoesNotExistError *cosmosaccount.AccountDoesNotExistError
+		if !errors.As(err, &accountDoesNotExistError) {
+			return nil, fmt.Errorf("failed to check account existence: %w", err)
+		}
+	}
+
+	accounts := make([]cosmosaccount.Account, 0, count)
+	for _, name := range names {
+		account, _, err := registry.Create(name)
+		if err != nil {
+			return accounts, fmt.Errorf("failed to create account '%s': %w", name, err)
+		}
+		accounts = append(accounts, account)
+	}
+
+	return accounts, nil
+}
+
+// printAccountTable prints the names and addresses of the accounts as a table
+func printAccountTable(w io.Writer, accounts []cosmosaccount.Account, bech32Prefix string) error {
+	width := len("NAME")
+	for _, account := range accounts {
+		width = max(width, len(account.Name))
+	}
+
+	fmt.Fprintf(w, "%-*s  %s\n", width, "NAME", "ADDRESS")
+	for _, account := range accounts {
+		address, err := account.Address(bech32Prefix)
+		if err != nil {
+			return fmt.Errorf("failed to get address of account '%s': %w", account.Name, err)
+		}
+		fmt.Fprintf(w, "%-*s  %s\n", width, account.Name, address)
+	}
+
+	return nil
+}
+
+// renameExportPassphrase encrypts the private key exported while renaming an account, which never leaves the process
+const renameExportPassphrase = "spamtx-rename"
+
+// renameRegistry exports, imports and deletes keyring accounts
+type renameRegistry interface {
+	GetByName(name string) (cosmosaccount.Account, error)
+	Export(name, passphrase string) (string, error)
+	Import(name, secret, passphrase string) (cosmosaccount.Account, error)
+	DeleteByName(name string) error
+}
+
+// renameAccount renames an account by exporting its private key, importing it under the new name and deleting the old entry.
+// The old entry is only deleted once the key is imported under the new name, and kept if the deletion fails.
+func renameAccount(registry renameRegistry, oldName, newName string) error {
+	if err := validateAccountName(oldName); err != nil {
+		return err
+	}
+	if err := validateAccountName(newName); err != nil {
+		return err
+	}
+	if oldName == newName {
+		return fmt.Errorf("account '%s' already has this name", oldName)
+	}
+
+	armored, err := registry.Export(oldName, renameExportPassphrase)
+	if err != nil {
+		var accountDoesNotExistError *cosmosaccount.AccountDoesNotExistError
+		if errors.As(err, &accountDoesNotExistError) {
+			return fmt.Errorf("account '%s' does not exist", oldName)
+		}
+		return fmt.Errorf("failed to export account '%s': %w", oldName, err)
+	}
+
+	if _, err := registry.Import(newName, armored, renameExportPassphrase); err != nil {
+		return fmt.Errorf("failed to import account '%s': %w", newName, err)
+	}
+
+	if err := registry.DeleteByName(oldName); err != nil {
+		// Roll back, so that the key is only stored under its old name
+		if rollbackErr := registry.DeleteByName(newName); rollbackErr != nil {
+			return fmt.Errorf("failed to delete account '%s': %w (rollback failed, the key is stored under both names: %v)", oldName, err, rollbackErr)
+		}
+		return fmt.Errorf("failed to delete account '%s': %w", oldName, err)
+	}
+
+	fmt.Printf("✅ Successfully renamed account '%s' to '%s'\n", oldName, newName)
+	return nil
+}
+
+// deriveChildAddress derives the child key at index of the account path (see childHDPath) from a mnemonic
+// and returns its address and public key. The keyring only stores private keys, not the seed,
+// so child keys can only be derived from the mnemonic.
+func deriveChildAddress(mnemonic, accountPath string, index uint32, bech32Prefix string) (string, cryptotypes.PubKey, error) {
+	return deriveKey(mnemonic, childHDPath(accountPath, index), bech32Prefix)
+}
+
+// deriveKey derives the key at path from a mnemonic and returns its address and public key
+func deriveKey(mnemonic, path, bech32Prefix string) (string, cryptotypes.PubKey, error) {
+	derived, err := hd.Secp256k1.Derive()(mnemonic, "", path)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to derive key at %s: %w", path, err)
+	}
+
+	pubKey := hd.Secp256k1.Generate()(derived).PubKey()
+
+	address, err := sdk.Bech32ifyAddressBytes(bech32Prefix, pubKey.Address())
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to encode address: %w", err)
+	}
+
+	return address, pubKey, nil
+}
+
+// deriveAccount prints the child key at the given index of an account derived at accountPath, without storing it.
+// An empty accountPath is the default path, m/44'/118'/0'/0/0.
+func deriveAccount(registry cosmosaccount.Registry, name, mnemonic, accountPath string, index uint32, bech32Prefix string) error {
+	if err := validateAccountName(name); err != nil {
+		return err
+	}
+
+	if mnemonic == "" {
+		return fmt.Errorf("mnemonic cannot be empty")
+	}
+
+	account, err := registry.GetByName(name)
+	if err != nil {
+		return fmt.Errorf("failed to get account: %w", err)
+	}
+
+	// Make sure the mnemonic belongs to the account before deriving from it
+	accountPubKey, err := account.Record.GetPubKey()
+	if err != nil {
+		return fmt.Errorf("failed to get account public key: %w", err)
+	}
+
+	if accountPath == "" {
+		accountPath = hd.CreateHDPath(sdk.CoinType, 0, 0).String()
+	}
+
+	_, derivedPubKey, err := deriveKey(mnemonic, accountPath, bech32Prefix)
+	if err != nil {
+		return err
+	}
+
+	if !derivedPubKey.Equals(accountPubKey) {
+		return fmt.Errorf("mnemonic does not match account '%s' at %s, set --%s to the HD path of the account", name, accountPath, flagWalletHDPath)
+	}
+
+	address, pubKey, err := deriveChildAddress(mnemonic, accountPath, index, bech32Prefix)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🔑 Child key %s of account '%s':\n", childHDPath(accountPath, index), name)
+	fmt.Printf("   Address: %s\n", address)
+	fmt.Printf("   Public key: %X\n", pubKey.Bytes())
+
+	return nil
+}
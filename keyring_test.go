@@ -1,7 +1,13 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/ignite/cli/v29/ignite/pkg/cosmosaccount"
@@ -28,7 +34,7 @@ func TestInitializeKeyring(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, bech32Prefix, err := initializeKeyring(tt.chainName)
+			_, bech32Prefix, err := initializeKeyring(tt.chainName, KeyringOptions{})
 
 			if tt.expectError {
 				assert.Assert(t, err != nil)
@@ -46,6 +52,26 @@ func TestInitializeKeyring(t *testing.T) {
 	}
 }
 
+func TestGetKeyringHomeOverride(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	keyringHome, err := getKeyringHome()
+	assert.NilError(t, err)
+	assert.Equal(t, keyringHome, filepath.Join(os.Getenv("HOME"), ".spamtx", "keyring"))
+
+	override := t.TempDir()
+	homeDir = override
+	t.Cleanup(func() { homeDir = "" })
+
+	keyringHome, err = getKeyringHome()
+	assert.NilError(t, err)
+	assert.Equal(t, keyringHome, filepath.Join(override, "keyring"))
+
+	info, err := os.Stat(keyringHome)
+	assert.NilError(t, err)
+	assert.Assert(t, info.IsDir())
+}
+
 func TestCreateAccountInKeyring(t *testing.T) {
 	// Create an in-memory keyring for testing
 	registry, err := cosmosaccount.NewInMemory(
@@ -176,3 +202,380 @@ func TestAccountNameValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestInitializeKeyringOffline(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// Both --rpc and --bech32-prefix skip the chain registry, even for unknown chains
+	registry, bech32Prefix, err := initializeKeyring("my-private-chain", KeyringOptions{
+		RPC:          "http://localhost:26657",
+		Bech32Prefix: "mychain",
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, bech32Prefix, "mychain")
+
+	account, _, err := getOrCreateAccount(registry, "test-account")
+	assert.NilError(t, err)
+
+	address, err := account.Address(bech32Prefix)
+	assert.NilError(t, err)
+	assert.Assert(t, strings.HasPrefix(address, "mychain1"))
+}
+
+func TestBulkCreateAccounts(t *testing.T) {
+	registry, err := cosmosaccount.NewInMemory(cosmosaccount.WithBech32Prefix("cosmos"))
+	assert.NilError(t, err)
+
+	accounts, err := bulkCreateAccounts(registry, "sender", 3)
+	assert.NilError(t, err)
+	assert.Equal(t, len(accounts), 3)
+
+	addresses := make(map[string]bool)
+	for i, account := range accounts {
+		assert.Equal(t, account.Name, fmt.Sprintf("sender-%d", i))
+
+		stored, err := registry.GetByName(account.Name)
+		assert.NilError(t, err)
+		address, err := stored.Address("cosmos")
+		assert.NilError(t, err)
+		assert.Assert(t, !addresses[address], "address %s shared between accounts", address)
+		addresses[address] = true
+	}
+
+	var out bytes.Buffer
+	assert.NilError(t, printAccountTable(&out, accounts, "cosmos"))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Equal(t, len(lines), 4)
+	assert.Assert(t, strings.HasPrefix(lines[0], "NAME"))
+	assert.Assert(t, strings.HasPrefix(lines[1], "sender-0  cosmos1"))
+
+	// Nothing is created when a name is already taken
+	_, err = bulkCreateAccounts(registry, "sender", 5)
+	assert.ErrorContains(t, err, "account 'sender-0' already exists")
+	_, err = registry.GetByName("sender-3")
+	assert.Assert(t, err != nil)
+
+	_, err = bulkCreateAccounts(registry, "sender", 0)
+	assert.ErrorContains(t, err, "count must be greater than 0")
+
+	_, err = bulkCreateAccounts(registry, "bad prefix", 1)
+	assert.ErrorContains(t, err, "cannot contain spaces")
+}
+
+func TestDeriveChildAddress(t *testing.T) {
+	registry, err := cosmosaccount.NewInMemory(cosmosaccount.WithBech32Prefix("cosmos"))
+	assert.NilError(t, err)
+
+	account, mnemonic, err := registry.Create("alice")
+	assert.NilError(t, err)
+
+	accountAddress, err := account.Address("cosmos")
+	assert.NilError(t, err)
+
+	// Index 0 is the key stored in the keyring
+	address, _, err := deriveChildAddress(mnemonic, "", 0, "cosmos")
+	assert.NilError(t, err)
+	assert.Equal(t, address, accountAddress)
+
+	child, childPubKey, err := deriveChildAddress(mnemonic, "", 1, "cosmos")
+	assert.NilError(t, err)
+	assert.Assert(t, child != accountAddress)
+	assert.Assert(t, strings.HasPrefix(child, "cosmos1"))
+	assert.Equal(t, len(childPubKey.Bytes()), 33)
+
+	// Derivation is deterministic
+	again, _, err := deriveChildAddress(mnemonic, "", 1, "cosmos")
+	assert.NilError(t, err)
+	assert.Equal(t, again, child)
+
+	_, _, err = deriveChildAddress("not a valid mnemonic", "", 1, "cosmos")
+	assert.ErrorContains(t, err, "failed to derive key")
+}
+
+func TestDeriveAccount(t *testing.T) {
+	registry, err := cosmosaccount.NewInMemory(cosmosaccount.WithBech32Prefix("cosmos"))
+	assert.NilError(t, err)
+
+	_, mnemonic, err := registry.Create("alice")
+	assert.NilError(t, err)
+	_, otherMnemonic, err := registry.Create("bob")
+	assert.NilError(t, err)
+	_, customMnemonic, err := hdPathRegistry{Registry: registry, HDPath: "m/44'/118'/0'/0/7"}.Create("carol")
+	assert.NilError(t, err)
+
+	tests := []struct {
+		name     string
+		account  string
+		mnemonic string
+		hdPath   string
+		wantErr  string
+	}{
+		{
+			name:     "matching mnemonic",
+			account:  "alice",
+			mnemonic: mnemonic,
+		},
+		{
+			name:     "mnemonic of another account",
+			account:  "alice",
+			mnemonic: otherMnemonic,
+			wantErr:  "does not match",
+		},
+		{
+			name:     "empty mnemonic",
+			account:  "alice",
+			mnemonic: "",
+			wantErr:  "mnemonic cannot be empty",
+		},
+		{
+			name:     "custom hd path",
+			account:  "carol",
+			mnemonic: customMnemonic,
+			hdPath:   "m/44'/118'/0'/0/7",
+		},
+		{
+			name:     "custom hd path not set",
+			account:  "carol",
+			mnemonic: customMnemonic,
+			wantErr:  "does not match account 'carol' at m/44'/118'/0'/0/0",
+		},
+		{
+			name:     "unknown account",
+			account:  "dave",
+			mnemonic: mnemonic,
+			wantErr:  "failed to get account",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := deriveAccount(registry, tt.account, tt.mnemonic, tt.hdPath, 5, "cosmos")
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NilError(t, err)
+			}
+		})
+	}
+}
+
+func TestGetAccountInfo(t *testing.T) {
+	registry, err := cosmosaccount.NewInMemory(cosmosaccount.WithBech32Prefix("cosmos"))
+	assert.NilError(t, err)
+
+	account, _, err := registry.Create("alice")
+	assert.NilError(t, err)
+
+	address, err := account.Address("cosmos")
+	assert.NilError(t, err)
+
+	info, err := getAccountInfo(registry, "alice", "cosmos")
+	assert.NilError(t, err)
+	assert.Equal(t, info.Name, "alice")
+	assert.Equal(t, info.Address, address)
+	assert.Equal(t, info.KeyType, "secp256k1")
+	assert.Equal(t, len(info.PubKey), 66)
+
+	_, err = getAccountInfo(registry, "bob", "cosmos")
+	assert.ErrorContains(t, err, "does not exist")
+}
+
+func TestShowAccount(t *testing.T) {
+	registry, err := cosmosaccount.NewInMemory(cosmosaccount.WithBech32Prefix("cosmos"))
+	assert.NilError(t, err)
+
+	_, _, err = registry.Create("alice")
+	assert.NilError(t, err)
+
+	tests := []struct {
+		name    string
+		output  string
+		wantErr bool
+	}{
+		{name: "text output", output: "text", wantErr: false},
+		{name: "json output", output: "json", wantErr: false},
+		{name: "unknown output", output: "yaml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := showAccount(registry, "alice", "cosmos", tt.output)
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "unknown output format")
+			} else {
+				assert.NilError(t, err)
+			}
+		})
+	}
+}
+
+func TestResolveKeyringBackend(t *testing.T) {
+	tests := []struct {
+		name     string
+		backend  cosmosaccount.KeyringBackend
+		expected cosmosaccount.KeyringBackend
+		wantErr  bool
+	}{
+		{name: "default backend", backend: "", expected: DefaultKeyringBackend},
+		{name: "test backend", backend: cosmosaccount.KeyringTest, expected: cosmosaccount.KeyringTest},
+		{name: "os backend", backend: cosmosaccount.KeyringOS, expected: cosmosaccount.KeyringOS},
+		{name: "file backend", backend: keyringBackendFile, expected: keyringBackendFile},
+		{name: "memory backend", backend: cosmosaccount.KeyringMemory, wantErr: true},
+		{name: "vault backend", backend: keyringBackendVault, expected: keyringBackendVault},
+		{name: "unknown backend", backend: "kwallet", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend, err := resolveKeyringBackend(tt.backend)
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "must be one of: test, os, file")
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, backend, tt.expected)
+		})
+	}
+}
+
+func TestInitializeKeyringUnsupportedBackend(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	_, _, err := initializeKeyring("my-private-chain", KeyringOptions{
+		RPC:          "http://localhost:26657",
+		Bech32Prefix: "mychain",
+		Backend:      "kwallet",
+	})
+	assert.ErrorContains(t, err, "unsupported keyring backend")
+}
+
+func TestImportAccountFromEnv(t *testing.T) {
+	source, err := cosmosaccount.NewInMemory(cosmosaccount.WithBech32Prefix("cosmos"))
+	assert.NilError(t, err)
+
+	account, mnemonic, err := source.Create("alice")
+	assert.NilError(t, err)
+	expected, err := account.Address("cosmos")
+	assert.NilError(t, err)
+
+	t.Setenv("SPAMTX_TEST_MNEMONIC", mnemonic)
+
+	secret, err := resolveImportSecret(nil, "SPAMTX_TEST_MNEMONIC")
+	assert.NilError(t, err)
+
+	registry, err := cosmosaccount.NewInMemory(cosmosaccount.WithBech32Prefix("cosmos"))
+	assert.NilError(t, err)
+	assert.NilError(t, importAccount(registry, "alice", secret, "", "cosmos"))
+
+	imported, err := registry.GetByName("alice")
+	assert.NilError(t, err)
+	address, err := imported.Address("cosmos")
+	assert.NilError(t, err)
+	assert.Equal(t, address, expected)
+}
+
+func TestResolveImportSecret(t *testing.T) {
+	t.Setenv("SPAMTX_TEST_SECRET", "from env")
+
+	tests := []struct {
+		name     string
+		args     []string
+		fromEnv  string
+		expected string
+		wantErr  string
+	}{
+		{
+			name:     "positional argument",
+			args:     []string{"from arg"},
+			expected: "from arg",
+		},
+		{
+			name:     "environment variable",
+			fromEnv:  "SPAMTX_TEST_SECRET",
+			expected: "from env",
+		},
+		{
+			name:    "missing secret",
+			wantErr: "is required",
+		},
+		{
+			name:    "argument and environment variable",
+			args:    []string{"from arg"},
+			fromEnv: "SPAMTX_TEST_SECRET",
+			wantErr: "mutually exclusive",
+		},
+		{
+			name:    "unset environment variable",
+			fromEnv: "SPAMTX_TEST_UNSET",
+			wantErr: "is not set",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret, err := resolveImportSecret(tt.args, tt.fromEnv)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, secret, tt.expected)
+		})
+	}
+}
+
+// failingImportRegistry is a registry whose imports always fail
+type failingImportRegistry struct {
+	cosmosaccount.Registry
+}
+
+func (failingImportRegistry) Import(string, string, string) (cosmosaccount.Account, error) {
+	return cosmosaccount.Account{}, errors.New("keyring is locked")
+}
+
+func TestRenameAccount(t *testing.T) {
+	registry, err := cosmosaccount.NewInMemory(cosmosaccount.WithBech32Prefix("cosmos"))
+	assert.NilError(t, err)
+
+	account, _, err := registry.Create("alice")
+	assert.NilError(t, err)
+	address, err := account.Address("cosmos")
+	assert.NilError(t, err)
+
+	assert.NilError(t, renameAccount(registry, "alice", "bob"))
+
+	// The key is moved to the new name
+	renamed, err := registry.GetByName("bob")
+	assert.NilError(t, err)
+	renamedAddress, err := renamed.Address("cosmos")
+	assert.NilError(t, err)
+	assert.Equal(t, renamedAddress, address)
+
+	_, err = registry.GetByName("alice")
+	var accountDoesNotExistError *cosmosaccount.AccountDoesNotExistError
+	assert.Assert(t, errors.As(err, &accountDoesNotExistError))
+
+	assert.ErrorContains(t, renameAccount(registry, "alice", "carol"), "account 'alice' does not exist")
+	assert.ErrorContains(t, renameAccount(registry, "bob", "bob"), "already has this name")
+
+	_, _, err = registry.Create("carol")
+	assert.NilError(t, err)
+	assert.ErrorContains(t, renameAccount(registry, "bob", "carol"), "failed to import account 'carol'")
+}
+
+func TestRenameAccountRollback(t *testing.T) {
+	registry, err := cosmosaccount.NewInMemory(cosmosaccount.WithBech32Prefix("cosmos"))
+	assert.NilError(t, err)
+
+	_, _, err = registry.Create("alice")
+	assert.NilError(t, err)
+
+	err = renameAccount(failingImportRegistry{Registry: registry}, "alice", "bob")
+	assert.ErrorContains(t, err, "keyring is locked")
+
+	// The old entry is kept when the import fails
+	_, err = registry.GetByName("alice")
+	assert.NilError(t, err)
+	_, err = registry.GetByName("bob")
+	assert.Assert(t, err != nil)
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+)
+
+// DefaultLogMaxSizeMB is the default size in MB above which the log file is rotated
+const DefaultLogMaxSizeMB = 100
+
+var (
+	// logLevel is the minimum level of the logged records, set by --log-level
+	logLevel = new(slog.LevelVar)
+	// logger is the structured logger of spamtx, writing to stderr
+	logger = newLogger(os.Stderr, logLevel)
+	// logRotator is the log file set by --log-file, nil when the logs are only written to stderr
+	logRotator *LogRotator
+)
+
+// openLogFile writes the logs to the log file at path in addition to stderr, until closeLogFile is called
+func openLogFile(path string, maxSizeMB int) error {
+	rotator, err := NewLogRotator(path, maxSizeMB)
+	if err != nil {
+		return err
+	}
+
+	logRotator = rotator
+	logger = newLogger(io.MultiWriter(os.Stderr, rotator), logLevel)
+	return nil
+}
+
+// closeLogFile switches the logger back to stderr and closes the log file, if any
+func closeLogFile() error {
+	if logRotator == nil {
+		return nil
+	}
+
+	logger = newLogger(os.Stderr, logLevel)
+	err := logRotator.Close()
+	logRotator = nil
+	return err
+}
+
+// newLogger creates a text logger writing the records at or above level to w
+func newLogger(w io.Writer, level slog.Leveler) *slog.Logger {
+	return slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{
+		Level: level,
+	}))
+}
+
+// parseLogLevel parses a log level, one of debug, info, warn or error
+func parseLogLevel(level string) (slog.Level, error) {
+	switch level {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "warn":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return 0, fmt.Errorf("unknown log level %q, must be one of: debug, info, warn, error", level)
+	}
+}
+
+// LogRotator writes to a log file, renaming it to <path>.1 and opening a fresh file once it exceeds its maximum size
+type LogRotator struct {
+	path    string
+	maxSize int64
+
+	// mu guards file and size, so that concurrent records are not interleaved
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// NewLogRotator opens the log file at path for append, creating it if needed
+func NewLogRotator(path string, maxSizeMB int) (*LogRotator, error) {
+	if maxSizeMB <= 0 {
+		return nil, fmt.Errorf("log max size must be greater than 0, got %d", maxSizeMB)
+	}
+
+	r := &LogRotator{
+		path:    path,
+		maxSize: int64(maxSizeMB) << 20,
+	}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Write writes p to the log file, rotating it first when p would make it exceed its maximum size
+func (r *LogRotator) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return 0, errors.New("log file is not open")
+	}
+
+	if r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// Close closes the log file
+func (r *LogRotator) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// open opens the log file for append and records its current size
+func (r *LogRotator) open() error {
+	file, err := os.OpenFile(r.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+
+	r.file = file
+	r.size = info.Size()
+	return nil
+}
+
+// rotate renames the log file to <path>.1, replacing the previous one, and opens a fresh log file
+func (r *LogRotator) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file: %w", err)
+	}
+	r.file = nil
+
+	if err := os.Rename(r.path, r.path+".1"); err != nil {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+
+	return r.open()
+}
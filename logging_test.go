@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestParseLogLevel(t *testing.T) {
+	tests := []struct {
+		level    string
+		expected slog.Level
+		wantErr  bool
+	}{
+		{level: "debug", expected: slog.LevelDebug},
+		{level: "info", expected: slog.LevelInfo},
+		{level: "warn", expected: slog.LevelWarn},
+		{level: "error", expected: slog.LevelError},
+		{level: "trace", wantErr: true},
+		{level: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level, func(t *testing.T) {
+			level, err := parseLogLevel(tt.level)
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "unknown log level")
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, level, tt.expected)
+		})
+	}
+}
+
+func TestNewLoggerFiltersLevel(t *testing.T) {
+	var buf bytes.Buffer
+	level := new(slog.LevelVar)
+	level.Set(slog.LevelInfo)
+	logger := newLogger(&buf, level)
+
+	logger.Debug("🔗 Transaction broadcasted", "sequence", 7)
+	assert.Equal(t, buf.String(), "")
+
+	logger.Info("🚀 Sending transactions", "tps", 10)
+	assert.Assert(t, bytes.Contains(buf.Bytes(), []byte("level=INFO")), buf.String())
+	assert.Assert(t, bytes.Contains(buf.Bytes(), []byte("tps=10")), buf.String())
+
+	buf.Reset()
+	level.Set(slog.LevelDebug)
+	logger.Debug("🔗 Transaction broadcasted", "sequence", 7)
+	assert.Assert(t, bytes.Contains(buf.Bytes(), []byte("sequence=7")), buf.String())
+}
+
+func TestLogRotator(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spamtx.log")
+
+	_, err := NewLogRotator(path, 0)
+	assert.ErrorContains(t, err, "must be greater than 0")
+
+	rotator, err := NewLogRotator(path, 1)
+	assert.NilError(t, err)
+	// Use a small maximum size to rotate without writing megabytes
+	rotator.maxSize = 10
+
+	_, err = rotator.Write([]byte("first\n"))
+	assert.NilError(t, err)
+	_, err = rotator.Write([]byte("second\n"))
+	assert.NilError(t, err)
+	_, err = rotator.Write([]byte("third\n"))
+	assert.NilError(t, err)
+	assert.NilError(t, rotator.Close())
+
+	rotated, err := os.ReadFile(path + ".1")
+	assert.NilError(t, err)
+	assert.Equal(t, string(rotated), "second\n")
+
+	current, err := os.ReadFile(path)
+	assert.NilError(t, err)
+	assert.Equal(t, string(current), "third\n")
+
+	// Writing after close fails instead of reopening the file
+	_, err = rotator.Write([]byte("fourth\n"))
+	assert.ErrorContains(t, err, "not open")
+}
+
+func TestLogRotatorAppends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spamtx.log")
+	assert.NilError(t, os.WriteFile(path, []byte("previous run\n"), 0o644))
+
+	rotator, err := NewLogRotator(path, DefaultLogMaxSizeMB)
+	assert.NilError(t, err)
+	assert.Equal(t, rotator.size, int64(len("previous run\n")))
+
+	var stderr bytes.Buffer
+	logger := newLogger(io.MultiWriter(&stderr, rotator), new(slog.LevelVar))
+	logger.Info("🚀 Sending transactions", "tps", 10)
+	assert.NilError(t, rotator.Close())
+
+	bz, err := os.ReadFile(path)
+	assert.NilError(t, err)
+	assert.Assert(t, bytes.HasPrefix(bz, []byte("previous run\n")), string(bz))
+	assert.Assert(t, bytes.HasSuffix(bz, stderr.Bytes()), string(bz))
+}
+
+func TestCloseLogFile(t *testing.T) {
+	t.Cleanup(func() {
+		logger = newLogger(os.Stderr, logLevel)
+		logRotator = nil
+	})
+
+	path := filepath.Join(t.TempDir(), "spamtx.log")
+	assert.NilError(t, openLogFile(path, 1))
+	logger.Info("before close")
+
+	assert.NilError(t, closeLogFile())
+	assert.Assert(t, logRotator == nil)
+
+	// The logger is back to stderr, later records do not reach the closed file
+	logger.Info("after close")
+	content, err := os.ReadFile(path)
+	assert.NilError(t, err)
+	assert.Assert(t, bytes.Contains(content, []byte("before close")))
+	assert.Assert(t, !bytes.Contains(content, []byte("after close")))
+
+	// Closing again is a no-op
+	assert.NilError(t, closeLogFile())
+}
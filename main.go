@@ -2,14 +2,19 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/charmbracelet/fang"
+	"github.com/julienrbrt/spamtx/chainregistry"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 func main() {
@@ -22,23 +27,74 @@ func main() {
 
 	go func() {
 		<-sigChan
-		log.Println("\n🛑 Received interrupt signal. Shutting down gracefully...")
+		logger.Info("🛑 Received interrupt signal, shutting down gracefully")
 		cancel()
 	}()
 
-	_ = fang.Execute(ctx, rootCmd()) // errors are handled by command
+	// Errors are printed by fang, exit with a non-zero code so that scripts can detect failures
+	if err := execute(ctx); err != nil {
+		cancel()
+		os.Exit(1)
+	}
+}
+
+// execute runs the root command, closing the log file once it is done, even when it failed
+func execute(ctx context.Context) error {
+	defer func() {
+		if err := closeLogFile(); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to close log file: %v\n", err)
+		}
+	}()
+
+	return fang.Execute(ctx, rootCmd())
 }
 
 func rootCmd() *cobra.Command {
+	var (
+		logLevelFlag string
+		logFile      string
+		logMaxSizeMB int
+	)
+
 	cmd := &cobra.Command{
 		Use:   "spamtx",
 		Short: "Spam txs to a Cosmos SDK based blockchain",
 		Long:  "A tool that performs self bank sends with memo fields to spam transactions at a controlled rate",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			level, err := parseLogLevel(logLevelFlag)
+			if err != nil {
+				return err
+			}
+			logLevel.Set(level)
+
+			// Write the logs to the log file in addition to stderr, it is closed once the command is done
+			if logFile != "" {
+				if err := openLogFile(logFile, logMaxSizeMB); err != nil {
+					return err
+				}
+			}
+
+			return chainregistry.ValidateURL(registryURL)
+		},
 	}
 
 	// Add subcommands
 	cmd.AddCommand(spamCmd())
+	cmd.AddCommand(benchmarkCmd())
 	cmd.AddCommand(keyringCmd())
+	cmd.AddCommand(chainCmd())
+	cmd.AddCommand(decodeTxCmd())
+	cmd.AddCommand(checkCmd())
+	cmd.AddCommand(reportCmd())
+
+	cmd.PersistentFlags().StringVar(&homeDir, flagHome, "", "spamtx home directory holding the keyring, profiles and chain registry cache (default: ~/.spamtx)")
+	cmd.PersistentFlags().DurationVar(&registryTTL, flagRegistryTTL, DefaultRegistryTTL, "Maximum age of the cached chain registry")
+	cmd.PersistentFlags().StringVar(&registryURL, flagRegistryURL, chainregistry.DefaultURL, "Base URL of a cosmos.directory compatible chain registry API, e.g. an internal fork or a testnet registry")
+	cmd.PersistentFlags().StringVar(&registryBranch, flagRegistryBranch, "", "Chain registry git branch whose chain.json files are fetched from GitHub instead of the registry API, e.g. to test chains of an unmerged chain registry PR")
+	cmd.PersistentFlags().StringVar(&logLevelFlag, flagLogLevel, "info", "Log level (debug|info|warn|error), debug logs every transaction with its sequence")
+	cmd.PersistentFlags().StringVar(&logFile, flagLogFile, "", "Also write the logs to this file (optional)")
+	cmd.PersistentFlags().IntVar(&logMaxSizeMB, flagLogMaxSizeMB, DefaultLogMaxSizeMB, "Size in MB above which the log file is rotated to <log-file>.1")
+	cmd.PersistentFlags().BoolVar(&registryNoCache, flagNoCache, false, "Always fetch the chain registry instead of using the local cache")
 
 	// Hide the completion command
 	cmd.CompletionOptions.HiddenDefaultCmd = true
@@ -48,14 +104,61 @@ func rootCmd() *cobra.Command {
 
 func spamCmd() *cobra.Command {
 	var config Config
+	var profile, profilePath string
+	var chainInfo bool
 
 	cmd := &cobra.Command{
 		Use:   "spam [chain]",
-		Args:  cobra.ExactArgs(1),
+		Args:  cobra.MaximumNArgs(1),
 		Short: "Start spamming transactions",
-		Long:  "Start spamming self bank send transactions at a controlled rate. Use --rpc to override the RPC endpoint from chain registry.",
+		Long:  "Start spamming self bank send transactions at a controlled rate. Use --rpc to override the RPC endpoint from chain registry, or --grpc-addr to use a gRPC endpoint instead.",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			// Printing the chain info needs none of the required flags of a run
+			if chainInfo {
+				cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+					flag.Annotations = nil
+				})
+				return nil
+			}
+
+			if profile == "" {
+				return nil
+			}
+
+			path := profilePath
+			if path == "" {
+				var err error
+				if path, err = getProfilePath(); err != nil {
+					return err
+				}
+			}
+
+			values, err := LoadProfile(path, profile)
+			if err != nil {
+				return err
+			}
+
+			return applyProfile(cmd.Flags(), values)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			config.Chain = args[0]
+			// --chains overrides the chain argument
+			if len(args) == 0 && (len(config.Chains) == 0 || chainInfo) {
+				return errors.New("requires a chain argument")
+			}
+			if len(args) == 1 {
+				config.Chain = args[0]
+			}
+			if chainInfo {
+				return printChainInfo(cmd.OutOrStdout(), config.Chain)
+			}
+			if len(config.Chains) > 0 {
+				if config.Chain != "" {
+					logger.Warn(fmt.Sprintf("⚠️ --%s is set, ignoring the chain argument", flagChains), "chain", config.Chain)
+				}
+				config.Chain = ""
+			}
+
+			config.SequenceOverride = cmd.Flags().Changed(flagSequence)
 			if err := validateConfig(config); err != nil {
 				return err
 			}
@@ -65,17 +168,238 @@ func spamCmd() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&config.Account, flagFrom, "", "Account name from keyring")
+	cmd.Flags().StringSliceVar(&config.Chains, flagChains, nil, "Comma-separated chain names spammed in parallel with the same account, overriding the chain argument. The TPS is divided equally between the chains")
+	cmd.Flags().StringVar((*string)(&config.KeyringBackend), flagKeyringBackend, string(DefaultKeyringBackend), "Keyring backend (test|os|file|vault)")
+	cmd.Flags().StringVar(&config.VaultPath, flagVaultPath, "", "Path of the Vault secret holding the account mnemonic or private_key, e.g. secret/data/spamtx/alice (vault keyring backend, reads VAULT_ADDR and VAULT_TOKEN)")
+	cmd.Flags().StringVar(&profile, flagProfile, "", "Named profile from the profiles file used as base configuration, overridden by command line flags")
+	cmd.Flags().StringVar(&profilePath, flagProfileFile, "", "Path to the profiles file (default: <home>/profiles.yaml)")
+	cmd.Flags().BoolVar(&chainInfo, flagChainInfo, false, "Print the chain info resolved from the chain registry (chain-id, RPC endpoint, bech32 prefix) as JSON and exit")
 	cmd.Flags().StringVar(&config.Fees, flagFees, "", "Transaction fees")
+	cmd.Flags().Float64Var(&config.FeePct, flagFeePct, 0, "Transaction fees as a percentage of --amount, rounded up (mutually exclusive with --fees)")
+	cmd.Flags().StringVar(&config.FeeDenom, flagFeeDenom, "", "Only pay the fees in this denom, dropping the other coins of the fees (optional)")
+	cmd.Flags().StringVar(&config.FeeGranter, flagFeeGranter, "", "Address of an x/feegrant granter paying the transaction fees (optional)")
+	cmd.Flags().StringVar(&config.AuthzGranter, flagAuthzGranter, "", "Address of an x/authz granter on whose behalf the messages are executed, wrapped in a MsgExec (optional)")
+	cmd.Flags().StringVar(&config.Amount, flagAmount, "", "Amount of the self-transfers (default: the fees)")
+	cmd.Flags().BoolVar(&config.SendAll, flagSendAll, false, "Query the balance before each transaction and send all of it minus the fees, to drain the account (bank-send only)")
 	cmd.Flags().StringVar(&config.Memo, flagMemo, "", "Transaction memo")
+	cmd.Flags().StringVar(&config.MemoTemplate, flagMemoTemplate, "", "Transaction memo as a Go template with {{.TxNum}}, {{.Timestamp}} and {{.Account}}")
+	cmd.Flags().Uint64Var(&config.MemoMaxLen, flagMemoMaxLen, 256, "Maximum memo length in bytes accepted by the chain, checked before sending (0 = no limit)")
+	cmd.Flags().StringVar(&config.TxNote, flagTxNote, "", "Short tag prepended to every memo as \"[<note>] <memo>\", e.g. to filter the spam traffic (max 20 bytes)")
+	cmd.Flags().StringVar(&config.MemoFile, flagMemoFile, "", "File of memos, one per line, cycled through by the transactions (blank lines are empty memos)")
+	cmd.Flags().BoolVar(&config.NoteCounter, flagNoteCounter, false, "Append the transaction number to the memo (memo.tx0, memo.tx1, ...)")
 	cmd.Flags().Uint64Var(&config.TPS, flagTPS, 10, "Transactions per second")
+	cmd.Flags().DurationVar(&config.RampUp, flagRampUp, 0, "Increase the rate linearly from 1 TPS to --tps over this duration (0 = start at --tps)")
+	cmd.Flags().StringVar(&config.RateLimitStrategy, flagRateLimitStrategy, rateLimitTicker, fmt.Sprintf("Rate limiting algorithm (%s|%s), %s is smoother for high TPS targets", rateLimitTicker, rateLimitTokenBucket, rateLimitTokenBucket))
+	cmd.Flags().DurationVar(&config.Cooldown, flagCooldown, 0, "Pause for this duration after every --batch-size transactions, for bursty traffic (0 = continuous)")
+	cmd.Flags().Uint64Var(&config.BatchSize, flagBatchSize, 0, "Number of transactions sent between two cooldowns (default: one second of transactions at the target TPS)")
+	cmd.Flags().DurationVar(&config.StallTimeout, flagStallTimeout, 0, "Warn and restart the rate limiter when no transaction is sent for this duration (0 = disabled)")
+	cmd.Flags().Uint64Var(&config.StallMaxCount, flagStallMaxCount, DefaultStallMaxCount, "Stop the run with an error after N stalls with --stall-timeout (0 = never)")
+	cmd.Flags().BoolVar(&config.BlockThrottle, flagBlockThrottle, false, "Halve the TPS while block production stalls, i.e. no block for twice --block-time-estimate, and restore it once blocks resume")
+	cmd.Flags().DurationVar(&config.BlockTimeEstimate, flagBlockTimeEstimate, DefaultBlockTimeEstimate, "Expected time between two blocks, also the interval between two latest block lookups with --block-throttle and the minimum interval between two ticks with --broadcast-mode block")
+	cmd.Flags().Uint64Var(&config.Concurrent, flagConcurrent, 1, "Transactions sent in parallel per tick, each with its own sequence (effective rate: --tps * --concurrent)")
+	cmd.Flags().Uint64Var(&config.Count, flagCount, 0, "Stop after N successful transactions (0 = run until interrupted)")
+	cmd.Flags().StringVar(&config.MinBalance, flagMinBalance, "", "Minimum account balance required to start, e.g. 1000000uatom (default: no check)")
+	cmd.Flags().Uint64Var(&config.CheckBalanceInterval, flagCheckBalance, 0, "Check the account balance every N successful transactions against --min-balance and --stop-balance (0 = disabled)")
+	cmd.Flags().StringVar(&config.StopBalance, flagStopBalance, "", "Stop the run once the account balance falls below this amount, checked every --check-balance-interval transactions")
+	cmd.Flags().Uint64Var(&config.StopAtHeight, flagStopAtHeight, 0, "Stop once the chain reaches this block height (0 = no stop height)")
+	cmd.Flags().Uint64Var(&config.HeightPollInterval, flagHeightPoll, 100, "Number of transactions between two block height lookups with --stop-at-height")
+	cmd.Flags().Uint64Var(&config.StartAfter, flagStartAfter, 0, "Wait for the chain to reach this block height before sending, to start several instances together (0 = start immediately)")
+	cmd.Flags().BoolVar(&config.WaitSync, flagWaitSync, false, "Wait for the node to be fully synced (not catching up) before sending")
+	cmd.Flags().DurationVar(&config.WaitSyncTimeout, flagWaitSyncTimeout, 5*time.Minute, "Maximum time to wait for the node to sync with --wait-sync")
+	cmd.Flags().StringVar(&config.MinNodeVersion, flagMinNodeVersion, "", "Warn when the node version is below this semantic version, e.g. 0.38.0 (optional)")
+	cmd.Flags().BoolVar(&config.NodeVersionStrict, flagNodeVersionStrict, false, "Fail instead of warning when the node version is below --min-node-version")
 	cmd.Flags().StringVar(&config.RPC, flagRPC, "", "RPC endpoint URL (optional, overrides chain registry)")
+	cmd.Flags().StringVar(&config.RPCPool, flagRPCPool, "", "Comma-separated list of RPC endpoint URLs to round-robin the transactions across (optional, overrides chain registry)")
+	cmd.Flags().StringVar(&config.GRPC, flagGRPCAddr, "", "gRPC endpoint (host:port) used for queries and broadcasts instead of the RPC endpoint (optional, takes precedence over --rpc)")
+	cmd.Flags().BoolVar(&config.InsecureSkipTLS, flagInsecureSkipTLS, false, "Skip TLS certificate verification of the RPC endpoint, for private testnets with self-signed certificates (not allowed on mainnets)")
+	cmd.Flags().StringVar(&config.Bech32Prefix, flagChainPrefix, "", "Bech32 address prefix of the chain, skipping the chain registry for private chains (requires --rpc, --grpc-addr or --rpc-pool)")
+	cmd.Flags().StringVar(&config.ChainID, flagChainID, "", "Chain ID used to sign transactions, must match the node's chain ID (requires --rpc, --grpc-addr or --rpc-pool)")
+	cmd.Flags().Uint64Var(&config.StartSequence, flagSequence, 0, "Starting account sequence (optional, skips fetching it from the chain)")
+	cmd.Flags().StringVar(&config.NonceFile, flagNonceFile, "", "File persisting the last used sequence, reused on restart instead of fetching it from the chain (optional)")
+	cmd.Flags().DurationVar(&config.NonceMaxAge, flagNonceMaxAge, defaultNonceMaxAge, "Maximum age of the sequence persisted in --nonce-file to be reused")
 	cmd.Flags().Uint64Var(&config.GasLimit, flagGasLimit, 0, "Gas limit (optional, default is estimated)")
+	cmd.Flags().Var(gasLimitMapValue{gasLimits: &config.GasLimitMap}, flagGasLimitMap, fmt.Sprintf("Gas limit per transaction type, overriding --gas-limit, e.g. %s=200000,%s=500000", txTypeBankSend, txTypeGovVote))
+	cmd.Flags().BoolVar(&config.GasLimitAuto, flagGasLimitAuto, false, "Simulate the first transaction and use the simulated gas as gas limit for the whole run")
+	cmd.Flags().Float64Var(&config.GasAdjustment, flagGasAdjustment, 1.3, "Multiplier applied to the simulated gas with --gas-limit-auto")
+	cmd.Flags().Uint64Var(&config.GasResimInterval, flagGasResimInterval, 10, "Simulate again after more than N out-of-gas failures with --gas-limit-auto (0 = never)")
+	cmd.Flags().StringVar(&config.SignMode, flagSignMode, signModeDirect, fmt.Sprintf("Transaction sign mode (%s, %s)", signModeDirect, signModeAminoJSON))
+	cmd.Flags().Uint64Var(&config.TimeoutHeightOffset, flagTimeoutOffset, 0, "Expire each transaction this many blocks after the latest block height, queried before each transaction (0 = never expire)")
 	cmd.Flags().BoolVar(&config.Heavy, flagHeavy, false, "Send heavy multi-send transactions to self (multiple outputs)")
+	cmd.Flags().StringVar(&config.AddressPoolFile, flagAddressPoolFile, "", "File with one recipient address per line, cycled through instead of sending to self (bank-send only)")
+	cmd.Flags().BoolVar(&config.RecipientGen, flagRecipientGen, false, "Send to a new recipient every transaction, derived from --recipient-gen-seed and the transaction number (bank-send only)")
+	cmd.Flags().Uint64Var(&config.RecipientGenSeed, flagRecipientGenSeed, 0, "Seed of the recipients of --recipient-gen, the same seed always produces the same recipients")
+	cmd.Flags().BoolVar(&config.Histogram, flagHistogram, false, "Print the broadcast latency percentiles (p50, p90, p95, p99, p99.9, max) at the end of the run")
+	cmd.Flags().DurationVar(&config.HistogramInterval, flagHistogramInterval, 0, "Also print the latency percentiles every interval with --histogram (0 = only at the end)")
+	cmd.Flags().BoolVar(&config.SimulateOnly, flagSimulateOnly, false, "Only simulate the transactions of the configured type through the gRPC simulate endpoint and print the gas they use, without broadcasting")
+	cmd.Flags().IntVar(&config.SimulateCount, flagSimulateCount, DefaultSimulateCount, "Number of simulations averaged with --simulate-only")
+	cmd.Flags().IntVar(&config.MsgsPerTx, flagMsgsPerTx, 1, fmt.Sprintf("Number of identical bank send messages batched in every transaction (max %d)", maxMsgsPerTx))
 	cmd.Flags().Uint64Var(&config.HeavyAddressCount, flagAddressCount, 0, "Number of outputs in heavy mode (default: scales with gas limit, fallback: 10)")
+	cmd.Flags().StringVar(&config.TxType, flagType, txTypeBankSend, fmt.Sprintf("Transaction type (%s)", strings.Join(txTypes, ", ")))
+	cmd.Flags().Var(weightMapValue{weights: &config.WeightMap}, flagWeightMap, fmt.Sprintf("Send a random mix of transaction types in proportion to their weight, e.g. %s=70,%s=30 (replaces --type)", txTypeBankSend, txTypeGovVote))
+	cmd.Flags().Int64Var(&config.RandomSeed, flagRandomSeed, 0, "Seed of the random transaction type selection of --weight-map, for reproducible runs (default: random, logged at startup)")
+	cmd.Flags().StringVar(&config.ExtraMsg, flagExtraMsg, "", `Additional proto-JSON sdk.Msg with its @type appended to every transaction, e.g. '{"@type":"/cosmos.bank.v1beta1.MsgSend",...}'`)
+	cmd.Flags().Uint64Var(&config.GroupID, flagGroupID, 0, "Group ID to submit proposals to (group-submit-proposal)")
+	cmd.Flags().StringVar(&config.GroupMetadata, flagGroupMetadata, "", "Metadata of the submitted group proposals (group-submit-proposal)")
+	cmd.Flags().StringVar(&config.GroupMessageJSON, flagGroupMessageJSON, "", "Path to a JSON file containing the proposal sdk.Msg with its @type (group-submit-proposal)")
+	cmd.Flags().StringVar(&config.WithdrawAddress, flagWithdrawAddress, "", "Withdraw address for distribution-set-withdraw-address (default: cycle through addresses derived from the account)")
+	cmd.Flags().Uint64Var(&config.ProposalID, flagProposalID, 0, "Governance proposal ID to vote on (gov-vote)")
+	cmd.Flags().StringVar(&config.VoteOption, flagVoteOption, "yes", "Vote option: yes, no, abstain or no-with-veto (gov-vote)")
+	cmd.Flags().StringVar(&config.Validator, flagValidator, "", "Validator operator address to undelegate from (staking-undelegate, with --amount)")
+	cmd.Flags().StringVar(&config.ValidatorSrc, flagValidatorSrc, "", "Validator operator address to redelegate from (staking-redelegate, with --amount)")
+	cmd.Flags().StringVar(&config.ValidatorDst, flagValidatorDst, "", "Validator operator address to redelegate to (staking-redelegate, with --amount)")
+	cmd.MarkFlagsRequiredTogether(flagValidatorSrc, flagValidatorDst)
+	cmd.Flags().StringVar(&config.Grantee, flagGrantee, "", "Address granted the authorization (authz-grant)")
+	cmd.Flags().StringVar(&config.AuthzMsgType, flagAuthzMsgType, "", "Message type URL of the granted generic authorization, e.g. /cosmos.bank.v1beta1.MsgSend (authz-grant)")
+	cmd.Flags().DurationVar(&config.GrantExpiry, flagGrantExpiry, 24*time.Hour, "Duration after which the grants expire, from the time they are sent (authz-grant)")
+	cmd.Flags().BoolVar(&config.MockMode, flagMockMode, false, "Run against an in-process fake node, without any network access (for testing)")
+	cmd.Flags().BoolVar(&config.WatchBlock, flagWatchBlock, false, "Wait for each transaction to be included in a block before sending the next one")
+	cmd.Flags().BoolVar(&config.WatchMempool, flagWatchMempool, false, "Check that each broadcasted transaction enters the node mempool and report the mempool hit rate")
+	cmd.Flags().BoolVar(&config.EventLog, flagEventLog, false, "Subscribe to the node WebSocket and log every transaction of the account as it is included in a block")
+	cmd.Flags().BoolVar(&config.DryRun, flagDryRun, false, "Build and sign transactions without broadcasting them")
+	cmd.Flags().StringVar(&config.OTELEndpoint, flagOTELEndpoint, "", "OpenTelemetry collector gRPC endpoint to export per-transaction traces to (host:port or URL)")
+	cmd.Flags().StringVar(&config.MetricsAddr, flagMetricsAddr, "", "Address serving Prometheus metrics on /metrics during the run (e.g. :9090)")
+	cmd.Flags().StringVar(&config.ErrorFile, flagErrorFile, "", "File the failed transactions are appended to, one JSON record per line (optional)")
+	cmd.Flags().StringVar(&config.PprofAddr, flagPprofAddr, "", "Address serving CPU and memory profiles on /debug/pprof/ during the run (e.g. :6060)")
+	cmd.Flags().Uint64Var(&config.MaxErrors, flagMaxErrors, 0, "Stop after N consecutive failed transactions (0 = unlimited)")
+	cmd.Flags().BoolVar(&config.RequireZeroErrors, flagRequireZeroErrors, false, "Exit with an error at the end of the run if any transaction failed, e.g. to fail a CI job")
+	cmd.Flags().Uint64Var(&config.Retry, flagRetry, 0, "Number of retries of a broadcast failing with a network error (0 = no retry)")
+	cmd.Flags().DurationVar(&config.RetryDelay, flagRetryDelay, defaultRetryDelay, "Delay before the first retry, doubled on each subsequent retry")
+	cmd.Flags().DurationVar(&config.TxTimeout, flagTxTimeout, defaultTxTimeout, "Maximum duration of a single broadcast attempt (at least 1s)")
+	cmd.Flags().StringVar(&config.BroadcastMode, flagBroadcastMode, broadcastModeAsync, fmt.Sprintf("Broadcast mode (%s|%s|%s), %s waits for the CheckTx result of every transaction before the next tick and logs it, %s waits for their block inclusion", broadcastModeAsync, broadcastModeSync, broadcastModeBlock, broadcastModeSync, broadcastModeBlock))
+	cmd.Flags().Uint64Var(&config.LogInterval, flagLogInterval, 100, "Print progress every N transactions")
+	cmd.Flags().BoolVar(&config.ConsensusCheck, flagConsensusCheck, false, "Verify that the block gas limit can fit the configured TPS before spamming")
+
+	_ = cmd.MarkFlagRequired(flagFrom)
+	cmd.MarkFlagsOneRequired(flagFees, flagFeePct)
+	cmd.MarkFlagsMutuallyExclusive(flagFees, flagFeePct)
+	cmd.MarkFlagsOneRequired(flagMemo, flagMemoTemplate, flagMemoFile)
+	cmd.MarkFlagsMutuallyExclusive(flagMemo, flagMemoTemplate, flagMemoFile)
+	cmd.MarkFlagsMutuallyExclusive(flagNoteCounter, flagMemoTemplate)
+	cmd.MarkFlagsMutuallyExclusive(flagGasLimit, flagGasLimitAuto)
+	cmd.MarkFlagsMutuallyExclusive(flagType, flagWeightMap)
+
+	return cmd
+}
+
+func benchmarkCmd() *cobra.Command {
+	var config Config
+	var maxTPS int
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "benchmark [chain]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Measure the maximum transaction rate accepted by a node",
+		Long:  "Send self bank send transactions starting at 1 TPS, doubling the rate at each stage, until more than 10% of the transactions of a stage fail. Prints the error rate of each stage and the last stable rate.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config.Chain = args[0]
+			config.TPS = 1
+			config.MsgsPerTx = 1
+			if err := validateConfig(config); err != nil {
+				return err
+			}
+			if maxTPS < 1 {
+				return errors.New("max tps must be greater than 0")
+			}
+			if config.StageDuration <= 0 {
+				return errors.New("stage duration must be greater than 0")
+			}
+			if output != "text" && output != "json" {
+				return fmt.Errorf("unknown output format %q, must be one of: text, json", output)
+			}
+
+			return runBenchmark(cmd.Context(), config, maxTPS, output)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.Account, flagFrom, "", "Account name from keyring")
+	cmd.Flags().StringVar((*string)(&config.KeyringBackend), flagKeyringBackend, string(DefaultKeyringBackend), "Keyring backend (test|os|file)")
+	cmd.Flags().StringVar(&config.Fees, flagFees, "", "Transaction fees")
+	cmd.Flags().StringVar(&config.Amount, flagAmount, "", "Amount of the self-transfers (default: the fees)")
+	cmd.Flags().StringVar(&config.Memo, flagMemo, "", "Transaction memo")
+	cmd.Flags().StringVar(&config.RPC, flagRPC, "", "RPC endpoint URL (optional, overrides chain registry)")
+	cmd.Flags().StringVar(&config.GRPC, flagGRPCAddr, "", "gRPC endpoint (host:port) used for queries and broadcasts instead of the RPC endpoint (optional, takes precedence over --rpc)")
+	cmd.Flags().StringVar(&config.ChainID, flagChainID, "", "Chain ID used to sign transactions, must match the node's chain ID (requires --rpc or --grpc-addr)")
+	cmd.Flags().Uint64Var(&config.GasLimit, flagGasLimit, 0, "Gas limit (optional, default is estimated)")
+	cmd.Flags().BoolVar(&config.MockMode, flagMockMode, false, "Run against an in-process fake node, without any network access (for testing)")
+	cmd.Flags().IntVar(&maxTPS, flagMaxTPS, 1024, "Rate of the last stage, the benchmark stops there even if it is stable")
+	cmd.Flags().DurationVar(&config.StageDuration, flagStageDuration, 10*time.Second, "Duration of each stage")
+	cmd.Flags().StringVar(&output, "output", "text", "Output format (text|json)")
+	_ = cmd.MarkFlagRequired(flagFrom)
+
+	return cmd
+}
+
+func checkCmd() *cobra.Command {
+	var config Config
 
+	cmd := &cobra.Command{
+		Use:   "check [chain]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Check that the chain and account are ready to spam, without sending any transaction",
+		Long:  "Run the pre-flight checks of spam: fetch the chain info, connect to the node, look up the keyring account, verify it exists on chain, query its balance and sequence. Exits with a non-zero code if any check fails, e.g. to gate a spam run in CI.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config.Chain = args[0]
+			if _, err := resolveKeyringBackend(config.KeyringBackend); err != nil {
+				return err
+			}
+			if err := validateEndpointConfig(config); err != nil {
+				return err
+			}
+			if config.KeyringBackend == keyringBackendVault && config.VaultPath == "" {
+				return errors.New("vault keyring backend requires a vault path")
+			}
+			if config.MinBalance != "" {
+				if _, err := parseAmount(config.MinBalance); err != nil {
+					return fmt.Errorf("invalid minimum balance: %w", err)
+				}
+			}
+
+			checks := runPreflightChecks(cmd.Context(), config)
+			return printValidationReport(fmt.Sprintf("Pre-flight checks for '%s'", config.Chain), checks)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.Account, flagFrom, "", "Account name from keyring")
+	cmd.Flags().StringVar((*string)(&config.KeyringBackend), flagKeyringBackend, string(DefaultKeyringBackend), "Keyring backend (test|os|file|vault)")
+	cmd.Flags().StringVar(&config.VaultPath, flagVaultPath, "", "Path of the Vault secret holding the account mnemonic or private_key, e.g. secret/data/spamtx/alice (vault keyring backend, reads VAULT_ADDR and VAULT_TOKEN)")
+	cmd.Flags().StringVar(&config.RPC, flagRPC, "", "RPC endpoint URL (optional, overrides chain registry)")
+	cmd.Flags().StringVar(&config.GRPC, flagGRPCAddr, "", "gRPC endpoint (host:port) used for queries instead of the RPC endpoint (optional, takes precedence over --rpc)")
+	cmd.Flags().StringVar(&config.RPCPool, flagRPCPool, "", "Comma-separated list of RPC endpoint URLs of spam, the first one is checked (mutually exclusive with --rpc and --grpc-addr)")
+	cmd.Flags().StringVar(&config.Bech32Prefix, flagChainPrefix, "", "Bech32 address prefix of the chain, skipping the chain registry for private chains (requires --rpc, --grpc-addr or --rpc-pool)")
+	cmd.Flags().StringVar(&config.ChainID, flagChainID, "", "Chain ID expected from the node (requires --rpc, --grpc-addr or --rpc-pool)")
+	cmd.Flags().BoolVar(&config.InsecureSkipTLS, flagInsecureSkipTLS, false, "Skip TLS certificate verification of the RPC endpoint, for private testnets with self-signed certificates (not allowed on mainnets)")
+	cmd.Flags().StringVar(&config.MinBalance, flagMinBalance, "", "Minimum account balance required, e.g. 1000000uatom (default: any non-empty balance)")
 	_ = cmd.MarkFlagRequired(flagFrom)
-	_ = cmd.MarkFlagRequired(flagFees)
-	_ = cmd.MarkFlagRequired(flagMemo)
+
+	return cmd
+}
+
+func reportCmd() *cobra.Command {
+	var errorFile, output string
+
+	cmd := &cobra.Command{
+		Use:   "report",
+		Args:  cobra.NoArgs,
+		Short: "Summarize the failed transactions recorded with --error-file",
+		Long:  "Read the error file written by spam --error-file, group the failed transactions by error message and print their frequency, along with the error rate and the time of the first and last error.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "text" && output != "json" {
+				return fmt.Errorf("unknown output format %q, must be one of: text, json", output)
+			}
+
+			records, err := ParseErrorLog(errorFile)
+			if err != nil {
+				return err
+			}
+
+			return printErrorReport(newErrorReport(records), output)
+		},
+	}
+
+	cmd.Flags().StringVar(&errorFile, flagErrorFile, "", "Error file written by spam --error-file, one JSON record per line")
+	cmd.Flags().StringVar(&output, "output", "text", "Output format (text|json)")
+	_ = cmd.MarkFlagRequired(flagErrorFile)
 
 	return cmd
 }
@@ -84,19 +408,33 @@ func keyringCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "keyring",
 		Short: "Manage keyring accounts",
-		Long:  "Create, list, import, and delete accounts in the keyring",
+		Long:  "Create, bulk-create, list, show, import, derive, back up, restore and delete accounts in the keyring. Provide both --rpc and --bech32-prefix to skip the chain registry entirely.",
 	}
 
-	cmd.AddCommand(keyringCreateCmd())
-	cmd.AddCommand(keyringListCmd())
-	cmd.AddCommand(keyringImportCmd())
-	cmd.AddCommand(keyringDeleteCmd())
+	opts := &KeyringOptions{}
+	cmd.PersistentFlags().StringVar(&opts.RPC, flagRPC, "", "RPC endpoint URL (optional, requires --bech32-prefix for offline use)")
+	cmd.PersistentFlags().StringVar(&opts.Bech32Prefix, flagBech32Prefix, "", "Bech32 address prefix (optional, requires --rpc for offline use)")
+	cmd.PersistentFlags().StringVar((*string)(&opts.Backend), flagKeyringBackend, string(DefaultKeyringBackend), "Keyring backend (test|os|file)")
+	cmd.MarkFlagsRequiredTogether(flagRPC, flagBech32Prefix)
+
+	cmd.AddCommand(keyringCreateCmd(opts))
+	cmd.AddCommand(keyringGenerateManyCmd(opts))
+	cmd.AddCommand(keyringListCmd(opts))
+	cmd.AddCommand(keyringShowCmd(opts))
+	cmd.AddCommand(keyringImportCmd(opts))
+	cmd.AddCommand(keyringDeleteCmd(opts))
+	cmd.AddCommand(keyringRenameCmd(opts))
+	cmd.AddCommand(keyringDeriveCmd(opts))
+	cmd.AddCommand(keyringBackupCmd(opts))
+	cmd.AddCommand(keyringRestoreCmd(opts))
 
 	return cmd
 }
 
-func keyringCreateCmd() *cobra.Command {
-	return &cobra.Command{
+func keyringCreateCmd(opts *KeyringOptions) *cobra.Command {
+	var hdPath string
+
+	cmd := &cobra.Command{
 		Use:   "create [chain] [account-name]",
 		Args:  cobra.ExactArgs(2),
 		Short: "Create a new account in the keyring",
@@ -104,18 +442,58 @@ func keyringCreateCmd() *cobra.Command {
 			chainName := args[0]
 			accountName := args[1]
 
-			registry, _, err := initializeKeyring(chainName)
+			if hdPath != "" {
+				if err := validateHDPath(hdPath); err != nil {
+					return err
+				}
+			}
+
+			registry, _, err := initializeKeyring(chainName, *opts)
 			if err != nil {
 				return fmt.Errorf("failed to initialize keyring: %w", err)
 			}
 
-			_, _, err = getOrCreateAccount(registry, accountName)
+			_, _, err = getOrCreateAccount(hdPathRegistry{Registry: registry, HDPath: hdPath}, accountName)
 			return err
 		},
 	}
+
+	cmd.Flags().StringVar(&hdPath, flagWalletHDPath, "", "HD derivation path of the account, e.g. m/44'/118'/0'/0/1 (default: m/44'/118'/0'/0/0)")
+
+	return cmd
+}
+
+func keyringGenerateManyCmd(opts *KeyringOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "generate-many [chain] [prefix] [count]",
+		Args:  cobra.ExactArgs(3),
+		Short: "Create many test accounts at once",
+		Long:  "Create [count] accounts named [prefix]-0 to [prefix]-([count]-1), e.g. to prepare the senders of a multi-sender run, and print their names and addresses. Nothing is created if one of the names is already taken.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			chainName := args[0]
+			prefix := args[1]
+
+			count, err := strconv.Atoi(args[2])
+			if err != nil {
+				return fmt.Errorf("invalid count %q: %w", args[2], err)
+			}
+
+			registry, bech32Prefix, err := initializeKeyring(chainName, *opts)
+			if err != nil {
+				return fmt.Errorf("failed to initialize keyring: %w", err)
+			}
+
+			accounts, err := bulkCreateAccounts(registry, prefix, count)
+			if err != nil {
+				return err
+			}
+
+			return printAccountTable(cmd.OutOrStdout(), accounts, bech32Prefix)
+		},
+	}
 }
 
-func keyringListCmd() *cobra.Command {
+func keyringListCmd(opts *KeyringOptions) *cobra.Command {
 	return &cobra.Command{
 		Use:   "list [chain]",
 		Args:  cobra.ExactArgs(1),
@@ -123,7 +501,7 @@ func keyringListCmd() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			chainName := args[0]
 
-			registry, bech32Prefix, err := initializeKeyring(chainName)
+			registry, bech32Prefix, err := initializeKeyring(chainName, *opts)
 			if err != nil {
 				return fmt.Errorf("failed to initialize keyring: %w", err)
 			}
@@ -133,33 +511,71 @@ func keyringListCmd() *cobra.Command {
 	}
 }
 
-func keyringImportCmd() *cobra.Command {
-	var passphrase string
+func keyringShowCmd(opts *KeyringOptions) *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "show [chain] [account-name]",
+		Args:  cobra.ExactArgs(2),
+		Short: "Show the address and public key of an account",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			chainName := args[0]
+			accountName := args[1]
+
+			registry, bech32Prefix, err := initializeKeyring(chainName, *opts)
+			if err != nil {
+				return fmt.Errorf("failed to initialize keyring: %w", err)
+			}
+
+			return showAccount(registry, accountName, bech32Prefix, output)
+		},
+	}
+
+	cmd.Flags().StringVar(&output, "output", "text", "Output format (text|json)")
+
+	return cmd
+}
+
+func keyringImportCmd(opts *KeyringOptions) *cobra.Command {
+	var passphrase, fromEnv, hdPath string
 
 	cmd := &cobra.Command{
 		Use:   "import [chain] [account-name] [mnemonic-or-key]",
-		Args:  cobra.ExactArgs(3),
+		Args:  cobra.RangeArgs(2, 3),
 		Short: "Import an account from mnemonic or private key",
+		Long:  "Import an account from mnemonic or private key, given as argument or read from an environment variable with --from-env to keep it out of the shell history",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			chainName := args[0]
 			accountName := args[1]
-			secret := args[2]
 
-			registry, bech32Prefix, err := initializeKeyring(chainName)
+			secret, err := resolveImportSecret(args[2:], fromEnv)
+			if err != nil {
+				return err
+			}
+
+			if hdPath != "" {
+				if err := validateHDPath(hdPath); err != nil {
+					return err
+				}
+			}
+
+			registry, bech32Prefix, err := initializeKeyring(chainName, *opts)
 			if err != nil {
 				return fmt.Errorf("failed to initialize keyring: %w", err)
 			}
 
-			return importAccount(registry, accountName, secret, passphrase, bech32Prefix)
+			return importAccount(hdPathRegistry{Registry: registry, HDPath: hdPath}, accountName, secret, passphrase, bech32Prefix)
 		},
 	}
 
 	cmd.Flags().StringVar(&passphrase, "passphrase", "", "Passphrase for encrypted private key")
+	cmd.Flags().StringVar(&fromEnv, flagFromEnv, "", "Environment variable holding the mnemonic or private key, e.g. SPAMTX_MNEMONIC (replaces the argument)")
+	cmd.Flags().StringVar(&hdPath, flagWalletHDPath, "", "HD derivation path of a mnemonic, e.g. m/44'/118'/0'/0/1 (default: m/44'/118'/0'/0/0)")
 
 	return cmd
 }
 
-func keyringDeleteCmd() *cobra.Command {
+func keyringDeleteCmd(opts *KeyringOptions) *cobra.Command {
 	return &cobra.Command{
 		Use:   "delete [chain] [account-name]",
 		Args:  cobra.ExactArgs(2),
@@ -168,7 +584,7 @@ func keyringDeleteCmd() *cobra.Command {
 			chainName := args[0]
 			accountName := args[1]
 
-			registry, _, err := initializeKeyring(chainName)
+			registry, _, err := initializeKeyring(chainName, *opts)
 			if err != nil {
 				return fmt.Errorf("failed to initialize keyring: %w", err)
 			}
@@ -177,3 +593,174 @@ func keyringDeleteCmd() *cobra.Command {
 		},
 	}
 }
+
+func keyringRenameCmd(opts *KeyringOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "rename [chain] [old-name] [new-name]",
+		Args:  cobra.ExactArgs(3),
+		Short: "Rename an account in the keyring",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			chainName := args[0]
+
+			registry, _, err := initializeKeyring(chainName, *opts)
+			if err != nil {
+				return fmt.Errorf("failed to initialize keyring: %w", err)
+			}
+
+			return renameAccount(registry, args[1], args[2])
+		},
+	}
+}
+
+func keyringDeriveCmd(opts *KeyringOptions) *cobra.Command {
+	var fromEnv, hdPath string
+
+	cmd := &cobra.Command{
+		Use:   "derive [chain] [account-name] [index]",
+		Args:  cobra.ExactArgs(3),
+		Short: "Show the child key of an account at a given HD index",
+		Long:  "Derive the child key at m/44'/118'/0'/0/[index] (or at [index] of --wallet-hd-path) from the account mnemonic, read from the environment variable named by --from-env, and print its address and public key. The child key is not stored.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			chainName := args[0]
+			accountName := args[1]
+
+			index, err := strconv.ParseUint(args[2], 10, 32)
+			if err != nil {
+				return fmt.Errorf("invalid index %q: %w", args[2], err)
+			}
+
+			if hdPath != "" {
+				if err := validateHDPath(hdPath); err != nil {
+					return err
+				}
+			}
+
+			mnemonic, err := resolveImportSecret(nil, fromEnv)
+			if err != nil {
+				return err
+			}
+
+			registry, bech32Prefix, err := initializeKeyring(chainName, *opts)
+			if err != nil {
+				return fmt.Errorf("failed to initialize keyring: %w", err)
+			}
+
+			return deriveAccount(registry, accountName, mnemonic, hdPath, uint32(index), bech32Prefix)
+		},
+	}
+
+	cmd.Flags().StringVar(&fromEnv, flagFromEnv, "", "Environment variable holding the mnemonic of the account, e.g. SPAMTX_MNEMONIC (the keyring does not store it)")
+	cmd.Flags().StringVar(&hdPath, flagWalletHDPath, "", "HD derivation path the account was created or imported at, e.g. m/44'/118'/0'/0/1 (default: m/44'/118'/0'/0/0)")
+	_ = cmd.MarkFlagRequired(flagFromEnv)
+
+	return cmd
+}
+
+func keyringBackupCmd(opts *KeyringOptions) *cobra.Command {
+	var output, passphrase, fromEnv string
+
+	cmd := &cobra.Command{
+		Use:   "backup [chain]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Export all accounts of the keyring into a tar.gz archive",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			passphrase, err := resolveBackupPassphrase(passphrase, fromEnv)
+			if err != nil {
+				return err
+			}
+
+			registry, _, err := initializeKeyring(args[0], *opts)
+			if err != nil {
+				return fmt.Errorf("failed to initialize keyring: %w", err)
+			}
+
+			return BackupKeyring(registry, output, passphrase)
+		},
+	}
+
+	cmd.Flags().StringVar(&output, "output", "", "Path of the backup archive to create, e.g. backup.tar.gz")
+	cmd.Flags().StringVar(&passphrase, "passphrase", "", "Passphrase encrypting the private keys of the backup")
+	cmd.Flags().StringVar(&fromEnv, flagFromEnv, "", "Environment variable holding the backup passphrase, e.g. SPAMTX_BACKUP_PASSPHRASE (replaces --passphrase)")
+	_ = cmd.MarkFlagRequired("output")
+
+	return cmd
+}
+
+func keyringRestoreCmd(opts *KeyringOptions) *cobra.Command {
+	var input, passphrase, fromEnv string
+
+	cmd := &cobra.Command{
+		Use:   "restore [chain]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Import all accounts of a tar.gz archive created by keyring backup",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			passphrase, err := resolveBackupPassphrase(passphrase, fromEnv)
+			if err != nil {
+				return err
+			}
+
+			registry, _, err := initializeKeyring(args[0], *opts)
+			if err != nil {
+				return fmt.Errorf("failed to initialize keyring: %w", err)
+			}
+
+			return RestoreKeyring(registry, input, passphrase)
+		},
+	}
+
+	cmd.Flags().StringVar(&input, "input", "", "Path of the backup archive to restore, e.g. backup.tar.gz")
+	cmd.Flags().StringVar(&passphrase, "passphrase", "", "Passphrase the backup was created with")
+	cmd.Flags().StringVar(&fromEnv, flagFromEnv, "", "Environment variable holding the backup passphrase, e.g. SPAMTX_BACKUP_PASSPHRASE (replaces --passphrase)")
+	_ = cmd.MarkFlagRequired("input")
+
+	return cmd
+}
+
+func decodeTxCmd() *cobra.Command {
+	var rpc string
+
+	cmd := &cobra.Command{
+		Use:   "decode-tx [chain] [base64-tx-or-hash]",
+		Args:  cobra.ExactArgs(2),
+		Short: "Decode a raw transaction and print it as JSON",
+		Long:  "Decode a base64-encoded protobuf transaction, unpacking its messages, and print it as JSON. When given a transaction hash, the transaction is fetched from the node first.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDecodeTx(cmd.Context(), args[0], rpc, args[1])
+		},
+	}
+
+	cmd.Flags().StringVar(&rpc, flagRPC, "", "RPC endpoint URL (optional, overrides chain registry)")
+
+	return cmd
+}
+
+func chainCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "chain",
+		Short: "Inspect chain registry data",
+		Long:  "Inspect and validate the chain registry entries used to connect to a chain",
+	}
+
+	cmd.AddCommand(chainValidateCmd())
+
+	return cmd
+}
+
+func chainValidateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate [chain]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Check the chain registry data of a chain for consistency",
+		Long:  "Check that the RPC and gRPC endpoints are reachable, the bech32 prefix is consistent, the fee denom exists on chain, and minimum gas prices are set",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			chainName := args[0]
+
+			checks, err := validateChainRegistry(cmd.Context(), chainName)
+			if err != nil {
+				return err
+			}
+
+			return printValidationReport(fmt.Sprintf("Chain registry validation for '%s'", chainName), checks)
+		},
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// maxTxNoteLen is the maximum length in bytes of the note prepended to the memos
+const maxTxNoteLen = 20
+
+// MemoData holds the variables available to a memo template
+type MemoData struct {
+	TxNum     uint64
+	Timestamp time.Time
+	Account   string
+}
+
+// parseMemoTemplate parses a memo template using Go text/template syntax
+func parseMemoTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("memo").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse memo template: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+// renderMemo renders the memo template for a single transaction
+func renderMemo(tmpl *template.Template, data MemoData) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render memo template: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// loadMemoFile reads the memos of a file, one per line. Blank lines are valid, empty memos.
+func loadMemoFile(path string) ([]string, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read memo file: %w", err)
+	}
+
+	content := strings.TrimSuffix(strings.ReplaceAll(string(bz), "\r\n", "\n"), "\n")
+	if content == "" {
+		return nil, fmt.Errorf("memo file %s is empty", path)
+	}
+
+	return strings.Split(content, "\n"), nil
+}
+
+// checkMemoLength returns an error when the memo is longer than maxLen bytes, a maxLen of 0 disabling the check
+func checkMemoLength(memo string, maxLen uint64) error {
+	if maxLen > 0 && uint64(len(memo)) > maxLen {
+		return fmt.Errorf("memo is %d bytes long, above the maximum memo length of %d bytes", len(memo), maxLen)
+	}
+
+	return nil
+}
+
+// appendMemoCounter appends the transaction number to the memo, e.g. memo.tx42.
+// It is a cheaper alternative to a memo template for unique memos.
+func appendMemoCounter(memo string, txNum uint64) string {
+	return memo + ".tx" + strconv.FormatUint(txNum, 10)
+}
+
+// decorateMemo prepends the note to the memo as "[<note>] <memo>", an empty note leaving the memo unchanged
+func decorateMemo(note, memo string) string {
+	if note == "" {
+		return memo
+	}
+
+	return "[" + note + "] " + memo
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestRenderMemo(t *testing.T) {
+	data := MemoData{
+		TxNum:     42,
+		Timestamp: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		Account:   "cosmos1abc123",
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "static text",
+			template: "spam test",
+			expected: "spam test",
+		},
+		{
+			name:     "tx number",
+			template: "run-1 tx {{.TxNum}}",
+			expected: "run-1 tx 42",
+		},
+		{
+			name:     "timestamp",
+			template: "{{.Timestamp.Unix}}",
+			expected: "1735787045",
+		},
+		{
+			name:     "all variables",
+			template: "{{.Account}}/{{.TxNum}}/{{.Timestamp.Format \"2006-01-02\"}}",
+			expected: "cosmos1abc123/42/2025-01-02",
+		},
+		{
+			name:     "unknown field",
+			template: "{{.Unknown}}",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := parseMemoTemplate(tt.template)
+			assert.NilError(t, err)
+
+			memo, err := renderMemo(tmpl, data)
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.Equal(t, memo, tt.expected)
+		})
+	}
+}
+
+func TestParseMemoTemplateInvalid(t *testing.T) {
+	_, err := parseMemoTemplate("{{.TxNum")
+	assert.Assert(t, err != nil)
+}
+
+func TestAppendMemoCounter(t *testing.T) {
+	assert.Equal(t, appendMemoCounter("testmemo", 0), "testmemo.tx0")
+	assert.Equal(t, appendMemoCounter("testmemo", 1), "testmemo.tx1")
+	assert.Equal(t, appendMemoCounter("testmemo", 123456), "testmemo.tx123456")
+}
+
+func TestDecorateMemo(t *testing.T) {
+	tests := []struct {
+		name     string
+		note     string
+		memo     string
+		expected string
+	}{
+		{
+			name:     "empty note",
+			memo:     "testmemo",
+			expected: "testmemo",
+		},
+		{
+			name:     "note",
+			note:     "loadtest",
+			memo:     "testmemo",
+			expected: "[loadtest] testmemo",
+		},
+		{
+			name:     "note at max length",
+			note:     strings.Repeat("n", maxTxNoteLen),
+			memo:     "testmemo",
+			expected: "[" + strings.Repeat("n", maxTxNoteLen) + "] testmemo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, decorateMemo(tt.note, tt.memo), tt.expected)
+		})
+	}
+}
+
+func TestLoadMemoFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []string
+		wantErr  bool
+	}{
+		{
+			name:     "one memo per line",
+			content:  "first\nsecond\nthird\n",
+			expected: []string{"first", "second", "third"},
+		},
+		{
+			name:     "no trailing newline",
+			content:  "first\nsecond",
+			expected: []string{"first", "second"},
+		},
+		{
+			name:     "blank lines are empty memos",
+			content:  "first\n\nthird\n",
+			expected: []string{"first", "", "third"},
+		},
+		{
+			name:     "windows line endings",
+			content:  "first\r\nsecond\r\n",
+			expected: []string{"first", "second"},
+		},
+		{
+			name:    "empty file",
+			content: "",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "memos.txt")
+			assert.NilError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+
+			memos, err := loadMemoFile(path)
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "is empty")
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, memos, tt.expected)
+		})
+	}
+}
+
+func TestLoadMemoFileMissing(t *testing.T) {
+	_, err := loadMemoFile(filepath.Join(t.TempDir(), "missing.txt"))
+	assert.ErrorContains(t, err, "failed to read memo file")
+}
+
+func TestCheckMemoLength(t *testing.T) {
+	tests := []struct {
+		name    string
+		memo    string
+		maxLen  uint64
+		wantErr bool
+	}{
+		{
+			name:   "below the limit",
+			memo:   "spam test",
+			maxLen: 256,
+		},
+		{
+			name:   "at the limit",
+			memo:   strings.Repeat("a", 256),
+			maxLen: 256,
+		},
+		{
+			name:    "above the limit",
+			memo:    strings.Repeat("a", 257),
+			maxLen:  256,
+			wantErr: true,
+		},
+		{
+			name:    "multi-byte characters count as bytes",
+			memo:    strings.Repeat("🚀", 65),
+			maxLen:  256,
+			wantErr: true,
+		},
+		{
+			name:   "no limit",
+			memo:   strings.Repeat("a", 1024),
+			maxLen: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkMemoLength(tt.memo, tt.maxLen)
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "above the maximum memo length of 256 bytes")
+				return
+			}
+			assert.NilError(t, err)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/ignite/cli/v29/ignite/pkg/cosmosclient"
+)
+
+// mempoolQueryLimit is the number of unconfirmed transactions fetched per mempool lookup, the maximum accepted by CometBFT
+const mempoolQueryLimit = 100
+
+// mempoolStatus is the outcome of looking up a broadcasted transaction in the node mempool
+type mempoolStatus int
+
+const (
+	// mempoolSeen means the transaction is in the mempool or already included in a block
+	mempoolSeen mempoolStatus = iota
+	// mempoolDropped means the transaction is neither in the mempool nor in a block
+	mempoolDropped
+	// mempoolUnknown means the transaction is not in a block and the mempool holds more transactions than could be looked up
+	mempoolUnknown
+)
+
+// mempoolChecker tracks how many of the broadcasted transactions actually enter the node mempool
+type mempoolChecker struct {
+	seen    atomic.Uint64
+	dropped atomic.Uint64
+	unknown atomic.Uint64
+}
+
+// Check looks up whether the transaction is visible in the node mempool, or already included in a block.
+// Only the first mempoolQueryLimit unconfirmed transactions are looked up, so a transaction not found
+// while the mempool holds more than that is reported as unknown rather than dropped.
+func (m *mempoolChecker) Check(ctx context.Context, client cosmosclient.Client, hash string) (mempoolStatus, error) {
+	bz, err := hex.DecodeString(hash)
+	if err != nil {
+		return mempoolUnknown, fmt.Errorf("invalid transaction hash %s: %w", hash, err)
+	}
+
+	limit := mempoolQueryLimit
+	resp, err := client.RPC.UnconfirmedTxs(ctx, &limit)
+	if err != nil {
+		return mempoolUnknown, fmt.Errorf("failed to fetch unconfirmed transactions: %w", err)
+	}
+
+	for _, tx := range resp.Txs {
+		if bytes.Equal(tx.Hash(), bz) {
+			return mempoolSeen, nil
+		}
+	}
+
+	// The transaction may have left the mempool by being included in a block
+	if _, err := client.RPC.Tx(ctx, bz, false); err != nil {
+		if !strings.Contains(err.Error(), "not found") {
+			return mempoolUnknown, fmt.Errorf("failed to fetch transaction %s: %w", hash, err)
+		}
+		// The transaction may be queued behind the unconfirmed transactions that were looked up
+		if resp.Total > len(resp.Txs) {
+			return mempoolUnknown, nil
+		}
+		return mempoolDropped, nil
+	}
+
+	return mempoolSeen, nil
+}
+
+// Record checks whether the transaction entered the mempool and counts it as seen, dropped or unknown
+func (m *mempoolChecker) Record(ctx context.Context, client cosmosclient.Client, hash string) {
+	status, err := m.Check(ctx, client, hash)
+	if err != nil {
+		logger.Warn("⚠️ Failed to check mempool", "hash", hash, "error", err)
+		return
+	}
+
+	switch status {
+	case mempoolSeen:
+		m.seen.Add(1)
+	case mempoolDropped:
+		m.dropped.Add(1)
+		logger.Debug("🕳️ Transaction dropped from mempool", "hash", hash)
+	default:
+		m.unknown.Add(1)
+	}
+}
+
+// HitRate returns the percentage of the resolved transactions seen in the mempool, leaving out the unknown ones
+func (m *mempoolChecker) HitRate() float64 {
+	seen, dropped := m.seen.Load(), m.dropped.Load()
+	if seen+dropped == 0 {
+		return 0
+	}
+
+	return float64(seen) / float64(seen+dropped) * 100
+}
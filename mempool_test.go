@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/ignite/cli/v29/ignite/pkg/cosmosclient"
+	"gotest.tools/v3/assert"
+)
+
+// mempoolRPC serves a fixed mempool and a fixed set of committed transactions.
+// The mempool holds extra transactions beyond the ones served.
+type mempoolRPC struct {
+	rpcclient.Client
+	mempool   []cmttypes.Tx
+	extra     int
+	committed []cmttypes.Tx
+	err       error
+}
+
+func (r mempoolRPC) UnconfirmedTxs(context.Context, *int) (*ctypes.ResultUnconfirmedTxs, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	return &ctypes.ResultUnconfirmedTxs{Count: len(r.mempool), Total: len(r.mempool) + r.extra, Txs: r.mempool}, nil
+}
+
+func (r mempoolRPC) Tx(_ context.Context, hash []byte, _ bool) (*ctypes.ResultTx, error) {
+	for _, tx := range r.committed {
+		if string(tx.Hash()) == string(hash) {
+			return &ctypes.ResultTx{Hash: hash, Height: 1}, nil
+		}
+	}
+	return nil, errors.New("tx not found")
+}
+
+// txHash returns the hex encoded hash of a transaction, as returned by a broadcast
+func txHash(tx cmttypes.Tx) string {
+	return strings.ToUpper(hex.EncodeToString(tx.Hash()))
+}
+
+func TestMempoolCheckerCheck(t *testing.T) {
+	pendingTx, committedTx, droppedTx := cmttypes.Tx("pending"), cmttypes.Tx("committed"), cmttypes.Tx("dropped")
+	rpc := mempoolRPC{
+		mempool:   []cmttypes.Tx{cmttypes.Tx("other"), pendingTx},
+		committed: []cmttypes.Tx{committedTx},
+	}
+
+	fullRPC := rpc
+	fullRPC.extra = mempoolQueryLimit
+
+	tests := []struct {
+		name       string
+		rpc        mempoolRPC
+		hash       string
+		wantStatus mempoolStatus
+		wantErr    string
+	}{
+		{
+			name:       "in mempool",
+			rpc:        rpc,
+			hash:       txHash(pendingTx),
+			wantStatus: mempoolSeen,
+		},
+		{
+			name:       "already included in a block",
+			rpc:        rpc,
+			hash:       txHash(committedTx),
+			wantStatus: mempoolSeen,
+		},
+		{
+			name:       "dropped",
+			rpc:        rpc,
+			hash:       txHash(droppedTx),
+			wantStatus: mempoolDropped,
+		},
+		{
+			name:       "beyond the unconfirmed transactions looked up",
+			rpc:        fullRPC,
+			hash:       txHash(droppedTx),
+			wantStatus: mempoolUnknown,
+		},
+		{
+			name:       "in a block while the mempool is full",
+			rpc:        fullRPC,
+			hash:       txHash(committedTx),
+			wantStatus: mempoolSeen,
+		},
+		{
+			name:    "invalid hash",
+			rpc:     rpc,
+			hash:    "not hex",
+			wantErr: "invalid transaction hash",
+		},
+		{
+			name:    "rpc error",
+			rpc:     mempoolRPC{err: errors.New("connection refused")},
+			hash:    txHash(pendingTx),
+			wantErr: "connection refused",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, err := (&mempoolChecker{}).Check(context.Background(), cosmosclient.Client{RPC: tt.rpc}, tt.hash)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, status, tt.wantStatus)
+		})
+	}
+}
+
+func TestMempoolCheckerHitRate(t *testing.T) {
+	pendingTx := cmttypes.Tx("pending")
+	client := cosmosclient.Client{RPC: mempoolRPC{mempool: []cmttypes.Tx{pendingTx}}}
+
+	var checker mempoolChecker
+	assert.Equal(t, checker.HitRate(), 0.0)
+
+	for range 3 {
+		checker.Record(context.Background(), client, txHash(pendingTx))
+	}
+	checker.Record(context.Background(), client, txHash(cmttypes.Tx("dropped")))
+	// Failed lookups are not counted
+	checker.Record(context.Background(), client, "not hex")
+	// Transactions possibly queued beyond the looked up ones are left out of the hit rate
+	fullClient := cosmosclient.Client{RPC: mempoolRPC{mempool: []cmttypes.Tx{pendingTx}, extra: mempoolQueryLimit}}
+	for range 4 {
+		checker.Record(context.Background(), fullClient, txHash(cmttypes.Tx("queued")))
+	}
+
+	assert.Equal(t, checker.seen.Load(), uint64(3))
+	assert.Equal(t, checker.dropped.Load(), uint64(1))
+	assert.Equal(t, checker.unknown.Load(), uint64(4))
+	assert.Equal(t, checker.HitRate(), 75.0)
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// spamMetrics holds the Prometheus metrics of a spam run.
+// A nil *spamMetrics is valid and records nothing.
+type spamMetrics struct {
+	registry *prometheus.Registry
+	sent     prometheus.Counter
+	failed   prometheus.Counter
+	tps      prometheus.Gauge
+}
+
+// newSpamMetrics creates and registers the spam metrics
+func newSpamMetrics() *spamMetrics {
+	m := &spamMetrics{
+		registry: prometheus.NewRegistry(),
+		sent: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "spamtx_transactions_sent_total",
+			Help: "Number of transactions successfully broadcasted.",
+		}),
+		failed: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "spamtx_transactions_failed_total",
+			Help: "Number of transactions that failed to be created or broadcasted.",
+		}),
+		tps: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "spamtx_actual_tps",
+			Help: "Actual transactions per second over the recent window.",
+		}),
+	}
+	m.registry.MustRegister(m.sent, m.failed, m.tps)
+
+	return m
+}
+
+// recordSent records a successful transaction and the current actual TPS
+func (m *spamMetrics) recordSent(tps float64) {
+	if m == nil {
+		return
+	}
+
+	m.sent.Inc()
+	m.tps.Set(tps)
+}
+
+// recordFailed records a failed transaction
+func (m *spamMetrics) recordFailed() {
+	if m == nil {
+		return
+	}
+
+	m.failed.Inc()
+}
+
+// startMetricsServer serves the metrics on /metrics until the context is cancelled and returns the listening address
+func startMetricsServer(ctx context.Context, addr string, m *spamMetrics) (string, error) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
+
+	return startHTTPServer(ctx, "metrics", addr, mux)
+}
+
+// startHTTPServer serves handler on addr until the context is cancelled and returns the listening address.
+// name identifies the server in errors and logs.
+func startHTTPServer(ctx context.Context, name, addr string, handler http.Handler) (string, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return "", fmt.Errorf("failed to listen on %s address %s: %w", name, addr, err)
+	}
+
+	server := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Warn("⚠️ HTTP server stopped", "server", name, "error", err)
+		}
+	}()
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			logger.Warn("⚠️ Failed to shut down HTTP server", "server", name, "error", err)
+		}
+	}()
+
+	return listener.Addr().String(), nil
+}
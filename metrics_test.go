@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestMetricsServer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	metrics := newSpamMetrics()
+	addr, err := startMetricsServer(ctx, "127.0.0.1:0", metrics)
+	assert.NilError(t, err)
+
+	metrics.recordSent(4.5)
+	metrics.recordSent(5)
+	metrics.recordFailed()
+
+	resp, err := http.Get("http://" + addr + "/metrics")
+	assert.NilError(t, err)
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	assert.Equal(t, resp.StatusCode, http.StatusOK)
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NilError(t, err)
+
+	for _, want := range []string{
+		"spamtx_transactions_sent_total 2",
+		"spamtx_transactions_failed_total 1",
+		"spamtx_actual_tps 5",
+	} {
+		assert.Assert(t, strings.Contains(string(body), want), "missing %q in:\n%s", want, body)
+	}
+
+	// The server shuts down with the context
+	cancel()
+	assert.Assert(t, waitFor(func() bool {
+		_, err := http.Get("http://" + addr + "/metrics")
+		return err != nil
+	}))
+}
+
+func TestNilMetrics(t *testing.T) {
+	var metrics *spamMetrics
+	metrics.recordSent(1)
+	metrics.recordFailed()
+}
+
+// waitFor polls cond for up to a second
+func waitFor(cond func() bool) bool {
+	for range 100 {
+		if cond() {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return false
+}
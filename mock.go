@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+	"github.com/cometbft/cometbft/p2p"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// mockRPCEndpoint is the RPC endpoint reported in chain mock mode
+	mockRPCEndpoint = "http://localhost:26657"
+	// mockBech32Prefix is the bech32 prefix used in chain mock mode
+	mockBech32Prefix = "cosmos"
+	// mockChainID is the chain ID reported by the mock node
+	mockChainID = "spamtx-mock"
+)
+
+// errMockQueryUnsupported is returned for queries the mock node cannot answer
+var errMockQueryUnsupported = errors.New("query not supported in chain mock mode")
+
+// mockRPC is an offline RPC client that accepts every transaction.
+// It answers the calls made by the spam loop and rejects state queries.
+type mockRPC struct {
+	dryRunRPC
+}
+
+// newMockRPC creates an offline RPC client
+func newMockRPC() mockRPC {
+	return mockRPC{}
+}
+
+// Status reports a node of the mock chain
+func (mockRPC) Status(context.Context) (*ctypes.ResultStatus, error) {
+	return &ctypes.ResultStatus{
+		NodeInfo: p2p.DefaultNodeInfo{
+			Network: mockChainID,
+		},
+	}, nil
+}
+
+// ConsensusParams reports an unlimited block gas
+func (mockRPC) ConsensusParams(context.Context, *int64) (*ctypes.ResultConsensusParams, error) {
+	params := cmttypes.DefaultConsensusParams()
+	params.Block.MaxGas = -1
+
+	return &ctypes.ResultConsensusParams{
+		ConsensusParams: *params,
+	}, nil
+}
+
+// Tx reports every transaction as included at height 1
+func (mockRPC) Tx(_ context.Context, hash []byte, _ bool) (*ctypes.ResultTx, error) {
+	return &ctypes.ResultTx{
+		Hash:   hash,
+		Height: 1,
+	}, nil
+}
+
+// ABCIQuery rejects state queries
+func (mockRPC) ABCIQuery(context.Context, string, cmtbytes.HexBytes) (*ctypes.ResultABCIQuery, error) {
+	return nil, errMockQueryUnsupported
+}
+
+// ABCIQueryWithOptions rejects state queries
+func (mockRPC) ABCIQueryWithOptions(context.Context, string, cmtbytes.HexBytes, rpcclient.ABCIQueryOptions) (*ctypes.ResultABCIQuery, error) {
+	return nil, errMockQueryUnsupported
+}
+
+// mockAccountRetriever reports every account as existing with account number and sequence 0
+type mockAccountRetriever struct {
+	client.AccountRetriever
+}
+
+// EnsureExists never fails
+func (mockAccountRetriever) EnsureExists(client.Context, sdk.AccAddress) error {
+	return nil
+}
+
+// GetAccountNumberSequence always returns zero values
+func (mockAccountRetriever) GetAccountNumberSequence(client.Context, sdk.AccAddress) (uint64, uint64, error) {
+	return 0, 0, nil
+}
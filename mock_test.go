@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestMockRPC(t *testing.T) {
+	rpc := newMockRPC()
+
+	status, err := rpc.Status(context.Background())
+	assert.NilError(t, err)
+	assert.Equal(t, status.NodeInfo.Network, mockChainID)
+
+	params, err := rpc.ConsensusParams(context.Background(), nil)
+	assert.NilError(t, err)
+	assert.Equal(t, params.ConsensusParams.Block.MaxGas, int64(-1))
+
+	_, err = rpc.ABCIQuery(context.Background(), "/store/acc/key", nil)
+	assert.ErrorIs(t, err, errMockQueryUnsupported)
+}
+
+func TestSpamTransactionsMockMode(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// Create the account in the keyring without touching the chain registry
+	registry, _, err := initializeKeyring("mock", KeyringOptions{
+		RPC:          mockRPCEndpoint,
+		Bech32Prefix: mockBech32Prefix,
+	})
+	assert.NilError(t, err)
+	_, _, err = getOrCreateAccount(registry, "alice")
+	assert.NilError(t, err)
+
+	for _, txType := range []string{txTypeBankSend, txTypeSetWithdrawAddress} {
+		t.Run(txType, func(t *testing.T) {
+			config := Config{
+				Chain:          "mock",
+				Account:        "alice",
+				Fees:           "1000uatom",
+				Memo:           "mock test",
+				TPS:            50,
+				MsgsPerTx:      1,
+				TxType:         txType,
+				MockMode:       true,
+				ConsensusCheck: true,
+			}
+			assert.NilError(t, validateConfig(config))
+
+			ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+			defer cancel()
+
+			err := spamTransactions(ctx, config)
+			assert.NilError(t, err)
+		})
+	}
+}
+
+func TestSpamTransactionsMockModeSequenceOverride(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	registry, _, err := initializeKeyring("mock", KeyringOptions{
+		RPC:          mockRPCEndpoint,
+		Bech32Prefix: mockBech32Prefix,
+	})
+	assert.NilError(t, err)
+	_, _, err = getOrCreateAccount(registry, "alice")
+	assert.NilError(t, err)
+
+	config := Config{
+		Chain:            "mock",
+		Account:          "alice",
+		Fees:             "1000uatom",
+		Memo:             "mock test",
+		TPS:              50,
+		MsgsPerTx:        1,
+		MockMode:         true,
+		StartSequence:    1234,
+		SequenceOverride: true,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	err = spamTransactions(ctx, config)
+	assert.NilError(t, err)
+}
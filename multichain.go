@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// ChainRunner sends the transactions of one chain of a multi-chain run, with its share of the TPS budget
+type ChainRunner struct {
+	// Chain is the chain registry name of the chain
+	Chain   string
+	config  Config
+	session *spamSession
+
+	sent   atomic.Uint64
+	failed atomic.Uint64
+}
+
+// newChainRunners creates a runner per chain of config.Chains, splitting the TPS and count equally between them.
+// The first chains get one more when they are not a multiple of the number of chains.
+func newChainRunners(config Config) []*ChainRunner {
+	runners := make([]*ChainRunner, len(config.Chains))
+	for i, chain := range config.Chains {
+		chainConfig := config
+		chainConfig.Chain = chain
+		chainConfig.Chains = nil
+		chainConfig.TPS = chainShare(config.TPS, len(config.Chains), i)
+		chainConfig.Count = chainShare(config.Count, len(config.Chains), i)
+
+		runners[i] = &ChainRunner{Chain: chain, config: chainConfig}
+	}
+
+	return runners
+}
+
+// chainShare returns the share of a budget, such as the TPS, of the ith of n chains
+func chainShare(budget uint64, n, i int) uint64 {
+	share := budget / uint64(n)
+	if uint64(i) < budget%uint64(n) {
+		share++
+	}
+
+	return share
+}
+
+// Prepare connects to the chain. The account is looked up by name in the keyring, with the bech32 prefix of the chain.
+func (r *ChainRunner) Prepare(ctx context.Context) error {
+	session, err := prepareSpam(ctx, r.config)
+	if err != nil {
+		return err
+	}
+	r.session = session
+
+	return nil
+}
+
+// Run sends transactions until the context is cancelled or the run stops, once prepared
+func (r *ChainRunner) Run(ctx context.Context) error {
+	logger.Info("🚀 Sending transactions", "chain", r.Chain, "tps", r.config.TPS, "concurrent", concurrency(r.config), "target_tps", targetTPS(r.config))
+
+	sent, err := spamLoop(ctx, r.config, r.session.pool, r.countFailures(r.session.send), nil)
+	r.sent.Store(sent)
+
+	return err
+}
+
+// Close closes the connection to the chain, if prepared
+func (r *ChainRunner) Close() {
+	if r.session != nil {
+		r.session.Close()
+	}
+}
+
+// countFailures wraps send to count the failed transactions of the chain
+func (r *ChainRunner) countFailures(send sendFunc) sendFunc {
+	return countErrors(&r.failed, send)
+}
+
+// spamChains prepares a ChainRunner per chain of config.Chains, runs them in parallel and prints the aggregated stats.
+// The run stops on every chain as soon as one of them fails.
+func spamChains(ctx context.Context, config Config) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	runners := newChainRunners(config)
+	defer func() {
+		for _, runner := range runners {
+			runner.Close()
+		}
+	}()
+
+	// Connect to the chains one after another: cosmosclient.New reads the bech32 prefixes of the global
+	// SDK config without the lock the other clients hold while setting them
+	for _, runner := range runners {
+		if err := runner.Prepare(ctx); err != nil {
+			return fmt.Errorf("chain %s: %w", runner.Chain, err)
+		}
+	}
+
+	errs := make([]error, len(runners))
+
+	var wg sync.WaitGroup
+	for i, runner := range runners {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if err := runner.Run(ctx); err != nil {
+				errs[i] = fmt.Errorf("chain %s: %w", runner.Chain, err)
+				cancel()
+			}
+		}()
+	}
+	wg.Wait()
+
+	var sent, failed uint64
+	for _, runner := range runners {
+		fmt.Printf("⛓️ %s: sent %d transactions (%d failed)\n", runner.Chain, runner.sent.Load(), runner.failed.Load())
+		sent += runner.sent.Load()
+		failed += runner.failed.Load()
+	}
+	fmt.Printf("Sent %d transactions total across %d chains (%d failed).\n", sent, len(runners), failed)
+
+	if config.RequireZeroErrors && failed > 0 {
+		errs = append(errs, fmt.Errorf("%d transactions failed (--%s)", failed, flagRequireZeroErrors))
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestChainShare(t *testing.T) {
+	tests := []struct {
+		name   string
+		budget uint64
+		n      int
+		want   []uint64
+	}{
+		{
+			name:   "single chain",
+			budget: 10,
+			n:      1,
+			want:   []uint64{10},
+		},
+		{
+			name:   "even split",
+			budget: 10,
+			n:      2,
+			want:   []uint64{5, 5},
+		},
+		{
+			name:   "remainder to the first chains",
+			budget: 11,
+			n:      3,
+			want:   []uint64{4, 4, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make([]uint64, tt.n)
+			for i := range got {
+				got[i] = chainShare(tt.budget, tt.n, i)
+			}
+			assert.DeepEqual(t, got, tt.want)
+		})
+	}
+}
+
+func TestNewChainRunners(t *testing.T) {
+	config := Config{Chains: []string{"cosmoshub", "osmosis"}, Account: "alice", TPS: 5, Count: 7}
+
+	runners := newChainRunners(config)
+	assert.Equal(t, len(runners), 2)
+	for i, chain := range config.Chains {
+		assert.Equal(t, runners[i].Chain, chain)
+		assert.Equal(t, runners[i].config.Chain, chain)
+		assert.Equal(t, runners[i].config.Account, "alice")
+		assert.Equal(t, len(runners[i].config.Chains), 0)
+	}
+	assert.Equal(t, runners[0].config.TPS, uint64(3))
+	assert.Equal(t, runners[1].config.TPS, uint64(2))
+	assert.Equal(t, runners[0].config.Count, uint64(4))
+	assert.Equal(t, runners[1].config.Count, uint64(3))
+}
+
+func TestChainRunnerCountFailures(t *testing.T) {
+	runner := &ChainRunner{Chain: "cosmoshub"}
+	send := runner.countFailures(func(ctx context.Context, txNum, sequence uint64) error {
+		if txNum%2 == 1 {
+			return errors.New("broadcast failed")
+		}
+		return nil
+	})
+
+	for txNum := range uint64(5) {
+		_ = send(context.Background(), txNum, txNum)
+	}
+	assert.Equal(t, runner.failed.Load(), uint64(2))
+}
+
+func TestSpamChainsMockMode(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	registry, _, err := initializeKeyring("mock", KeyringOptions{
+		RPC:          mockRPCEndpoint,
+		Bech32Prefix: mockBech32Prefix,
+	})
+	assert.NilError(t, err)
+	_, _, err = getOrCreateAccount(registry, "alice")
+	assert.NilError(t, err)
+
+	config := Config{
+		Chains:    []string{"mock-a", "mock-b"},
+		Account:   "alice",
+		Fees:      "1000uatom",
+		Memo:      "multi-chain test",
+		TPS:       50,
+		Count:     5,
+		MsgsPerTx: 1,
+		MockMode:  true,
+	}
+	assert.NilError(t, validateConfig(config))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	assert.NilError(t, spamTransactions(ctx, config))
+	assert.Assert(t, ctx.Err() == nil, "every chain should stop after its count")
+}
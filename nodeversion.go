@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ignite/cli/v29/ignite/pkg/cosmosclient"
+	"golang.org/x/mod/semver"
+)
+
+// compareNodeVersion compares two semantic versions, with or without the v prefix.
+// It returns -1 when nodeVer is below minVer, 0 when they are equal and +1 when nodeVer is above minVer.
+func compareNodeVersion(nodeVer, minVer string) (int, error) {
+	node, err := canonicalVersion(nodeVer)
+	if err != nil {
+		return 0, err
+	}
+
+	minimum, err := canonicalVersion(minVer)
+	if err != nil {
+		return 0, err
+	}
+
+	return semver.Compare(node, minimum), nil
+}
+
+// canonicalVersion adds the v prefix expected by semver to version and checks that it is a valid semantic version
+func canonicalVersion(version string) (string, error) {
+	v := strings.TrimSpace(version)
+	if !strings.HasPrefix(v, "v") {
+		v = "v" + v
+	}
+
+	if !semver.IsValid(v) {
+		return "", fmt.Errorf("invalid semantic version %q", version)
+	}
+
+	return v, nil
+}
+
+// checkNodeVersion warns when the version reported by the node status is below minVersion.
+// With strict set, it returns an error instead.
+func checkNodeVersion(ctx context.Context, client cosmosclient.Client, minVersion string, strict bool) error {
+	status, err := client.RPC.Status(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch node status: %w", err)
+	}
+
+	nodeVersion := status.NodeInfo.Version
+	cmp, err := compareNodeVersion(nodeVersion, minVersion)
+	if err != nil {
+		if strict {
+			return fmt.Errorf("failed to check node version: %w", err)
+		}
+		logger.Warn("⚠️ Failed to check node version", "version", nodeVersion, "error", err)
+		return nil
+	}
+
+	if cmp < 0 {
+		if strict {
+			return fmt.Errorf("node version %s is below the minimum version %s", nodeVersion, minVersion)
+		}
+		logger.Warn("⚠️ Node version is below the minimum version", "version", nodeVersion, "min_version", minVersion)
+		return nil
+	}
+
+	logger.Info("🏷️ Node version", "version", nodeVersion, "min_version", minVersion)
+	return nil
+}
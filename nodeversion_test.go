@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cometbft/cometbft/p2p"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/ignite/cli/v29/ignite/pkg/cosmosclient"
+	"gotest.tools/v3/assert"
+)
+
+func TestCompareNodeVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		nodeVer string
+		minVer  string
+		want    int
+		wantErr bool
+	}{
+		{name: "equal", nodeVer: "0.38.17", minVer: "0.38.17", want: 0},
+		{name: "below", nodeVer: "0.37.4", minVer: "0.38.0", want: -1},
+		{name: "above", nodeVer: "0.38.17", minVer: "0.38.0", want: 1},
+		{name: "v prefix", nodeVer: "v0.47.0", minVer: "0.47.0", want: 0},
+		{name: "numeric ordering", nodeVer: "0.38.10", minVer: "0.38.9", want: 1},
+		{name: "pre-release below release", nodeVer: "1.0.0-rc1", minVer: "1.0.0", want: -1},
+		{name: "invalid node version", nodeVer: "unknown", minVer: "0.38.0", wantErr: true},
+		{name: "empty node version", nodeVer: "", minVer: "0.38.0", wantErr: true},
+		{name: "invalid min version", nodeVer: "0.38.0", minVer: "latest", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := compareNodeVersion(tt.nodeVer, tt.minVer)
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "invalid semantic version")
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, got, tt.want)
+		})
+	}
+}
+
+// versionRPC reports a node with the given version
+type versionRPC struct {
+	rpcclient.Client
+	version string
+}
+
+func (r versionRPC) Status(context.Context) (*ctypes.ResultStatus, error) {
+	return &ctypes.ResultStatus{NodeInfo: p2p.DefaultNodeInfo{Version: r.version}}, nil
+}
+
+func TestCheckNodeVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		strict  bool
+		wantErr string
+	}{
+		{name: "above minimum", version: "0.38.17"},
+		{name: "above minimum strict", version: "0.38.17", strict: true},
+		{name: "below minimum warns", version: "0.37.4"},
+		{name: "below minimum strict", version: "0.37.4", strict: true, wantErr: "below the minimum version 0.38.0"},
+		{name: "invalid version warns", version: "dev"},
+		{name: "invalid version strict", version: "dev", strict: true, wantErr: "invalid semantic version"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := cosmosclient.Client{RPC: versionRPC{version: tt.version}}
+
+			err := checkNodeVersion(context.Background(), client, "0.38.0", tt.strict)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+		})
+	}
+}
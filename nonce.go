@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// defaultNonceMaxAge is the default maximum age of a persisted sequence to be reused on startup
+const defaultNonceMaxAge = 10 * time.Second
+
+// nonceRecord is the content of the nonce file
+type nonceRecord struct {
+	LastSeq   uint64    `json:"last_seq"`
+	Timestamp time.Time `json:"ts"`
+}
+
+// NonceStore persists the last used account sequence to a file, so that a restarted run
+// does not depend on a possibly lagging node for its starting sequence
+type NonceStore struct {
+	path string
+
+	// mu guards lastSeq and serializes the writes of the nonce file
+	mu      sync.Mutex
+	lastSeq uint64
+	saved   bool
+}
+
+// NewNonceStore creates a store persisting the sequence to the file at path
+func NewNonceStore(path string) *NonceStore {
+	return &NonceStore{path: path}
+}
+
+// Load returns the persisted sequence when the nonce file exists and was written less than maxAge ago
+func (s *NonceStore) Load(maxAge time.Duration) (uint64, bool, error) {
+	bz, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, false, nil
+		}
+		return 0, false, fmt.Errorf("failed to read nonce file: %w", err)
+	}
+
+	var record nonceRecord
+	if err := json.Unmarshal(bz, &record); err != nil {
+		return 0, false, fmt.Errorf("failed to unmarshal nonce file: %w", err)
+	}
+
+	if time.Since(record.Timestamp) > maxAge {
+		return 0, false, nil
+	}
+
+	return record.LastSeq, true, nil
+}
+
+// Save persists seq when it is above the sequences saved so far. The file is replaced atomically,
+// so that a crash never leaves a partially written nonce file behind.
+func (s *NonceStore) Save(seq uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Concurrent transactions may complete out of order
+	if s.saved && seq <= s.lastSeq {
+		return nil
+	}
+
+	bz, err := json.Marshal(nonceRecord{LastSeq: seq, Timestamp: time.Now().UTC()})
+	if err != nil {
+		return fmt.Errorf("failed to marshal nonce file: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write nonce file: %w", err)
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if _, err := tmp.Write(bz); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write nonce file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write nonce file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to write nonce file: %w", err)
+	}
+
+	s.lastSeq = seq
+	s.saved = true
+	return nil
+}
+
+// persistNonce wraps send to save the sequence of each successful transaction to the nonce store
+func persistNonce(store *NonceStore, send sendFunc) sendFunc {
+	return func(ctx context.Context, txNum, sequence uint64) error {
+		if err := send(ctx, txNum, sequence); err != nil {
+			return err
+		}
+
+		if err := store.Save(sequence); err != nil {
+			logger.Warn("⚠️ Failed to persist sequence", "sequence", sequence, "error", err)
+		}
+
+		return nil
+	}
+}
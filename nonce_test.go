@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestNonceStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nonce.json")
+	store := NewNonceStore(path)
+
+	// A missing file is not an error
+	_, ok, err := store.Load(time.Minute)
+	assert.NilError(t, err)
+	assert.Assert(t, !ok)
+
+	assert.NilError(t, store.Save(42))
+	// Lower sequences completing out of order are not persisted
+	assert.NilError(t, store.Save(41))
+
+	seq, ok, err := NewNonceStore(path).Load(time.Minute)
+	assert.NilError(t, err)
+	assert.Assert(t, ok)
+	assert.Equal(t, seq, uint64(42))
+
+	// No temporary file is left behind
+	entries, err := os.ReadDir(filepath.Dir(path))
+	assert.NilError(t, err)
+	assert.Equal(t, len(entries), 1)
+}
+
+func TestNonceStoreLoadExpired(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nonce.json")
+
+	bz, err := json.Marshal(nonceRecord{LastSeq: 42, Timestamp: time.Now().Add(-time.Minute)})
+	assert.NilError(t, err)
+	assert.NilError(t, os.WriteFile(path, bz, 0o644))
+
+	_, ok, err := NewNonceStore(path).Load(10 * time.Second)
+	assert.NilError(t, err)
+	assert.Assert(t, !ok)
+
+	seq, ok, err := NewNonceStore(path).Load(time.Hour)
+	assert.NilError(t, err)
+	assert.Assert(t, ok)
+	assert.Equal(t, seq, uint64(42))
+}
+
+func TestNonceStoreLoadInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nonce.json")
+	assert.NilError(t, os.WriteFile(path, []byte("not json"), 0o644))
+
+	_, _, err := NewNonceStore(path).Load(time.Minute)
+	assert.ErrorContains(t, err, "failed to unmarshal nonce file")
+}
+
+func TestPersistNonce(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nonce.json")
+	store := NewNonceStore(path)
+
+	send := persistNonce(store, func(_ context.Context, txNum, _ uint64) error {
+		if txNum == 9 {
+			return errors.New("account sequence mismatch")
+		}
+		return nil
+	})
+
+	var wg sync.WaitGroup
+	for i := range uint64(10) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = send(context.Background(), i, 100+i)
+		}()
+	}
+	wg.Wait()
+
+	// The failed transaction is not persisted
+	seq, ok, err := store.Load(time.Minute)
+	assert.NilError(t, err)
+	assert.Assert(t, ok)
+	assert.Equal(t, seq, uint64(108))
+}
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/pprof"
+)
+
+// startPprofServer serves the runtime profiles on /debug/pprof/ until the context is cancelled and returns the listening address
+func startPprofServer(ctx context.Context, addr string) (string, error) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	return startHTTPServer(ctx, "pprof", addr, mux)
+}
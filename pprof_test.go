@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestPprofServer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	addr, err := startPprofServer(ctx, "127.0.0.1:0")
+	assert.NilError(t, err)
+
+	resp, err := http.Get("http://" + addr + "/debug/pprof/goroutine")
+	assert.NilError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, resp.StatusCode, http.StatusOK)
+
+	// The server shuts down with the context
+	cancel()
+	assert.Assert(t, waitFor(func() bool {
+		_, err := http.Get("http://" + addr + "/debug/pprof/goroutine")
+		return err != nil
+	}))
+}
+
+func TestPprofServerInvalidAddress(t *testing.T) {
+	_, err := startPprofServer(context.Background(), "invalid:address:0")
+	assert.ErrorContains(t, err, "failed to listen on pprof address")
+}
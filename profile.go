@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+// ProfiledConfig is a YAML file of named spam profiles.
+// Each profile maps flag names to their value, e.g. `profiles: {light: {tps: 5, fees: "100uatom"}}`.
+type ProfiledConfig struct {
+	Profiles map[string]map[string]any `yaml:"profiles"`
+}
+
+// getProfilePath returns the default path of the profiles file
+func getProfilePath() (string, error) {
+	spamtxHome, err := getSpamtxHome()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(spamtxHome, "profiles.yaml"), nil
+}
+
+// LoadProfile reads the named profile from the profiles file and returns its values keyed by flag name
+func LoadProfile(path, name string) (map[string]string, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profiles file: %w", err)
+	}
+
+	var config ProfiledConfig
+	if err := yaml.Unmarshal(bz, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse profiles file %s: %w", path, err)
+	}
+
+	profile, exists := config.Profiles[name]
+	if !exists {
+		available := slices.Sorted(maps.Keys(config.Profiles))
+		return nil, fmt.Errorf("profile '%s' not found in %s (available: %s)", name, path, strings.Join(available, ", "))
+	}
+
+	values := make(map[string]string, len(profile))
+	for flag, value := range profile {
+		values[flag] = fmt.Sprint(value)
+	}
+
+	return values, nil
+}
+
+// applyProfile sets the profile values of the flags that were not set on the command line
+func applyProfile(flags *pflag.FlagSet, values map[string]string) error {
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		flag := flags.Lookup(name)
+		if flag == nil {
+			return fmt.Errorf("unknown flag '%s' in profile", name)
+		}
+
+		// Command line flags take precedence over the profile
+		if flag.Changed {
+			continue
+		}
+
+		if err := flags.Set(name, values[name]); err != nil {
+			return fmt.Errorf("invalid value for '%s' in profile: %w", name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"gotest.tools/v3/assert"
+)
+
+const testProfiles = `
+profiles:
+  light:
+    tps: 5
+    fees: "100uatom"
+  heavy:
+    tps: 50
+    fees: "5000uatom"
+    heavy: true
+`
+
+func TestLoadProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profiles.yaml")
+	assert.NilError(t, os.WriteFile(path, []byte(testProfiles), 0644))
+
+	tests := []struct {
+		name     string
+		path     string
+		profile  string
+		expected map[string]string
+		wantErr  string
+	}{
+		{
+			name:     "existing profile",
+			path:     path,
+			profile:  "heavy",
+			expected: map[string]string{"tps": "50", "fees": "5000uatom", "heavy": "true"},
+		},
+		{
+			name:    "unknown profile",
+			path:    path,
+			profile: "medium",
+			wantErr: "profile 'medium' not found",
+		},
+		{
+			name:    "missing file",
+			path:    filepath.Join(t.TempDir(), "missing.yaml"),
+			profile: "light",
+			wantErr: "failed to read profiles file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := LoadProfile(tt.path, tt.profile)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, values, tt.expected)
+		})
+	}
+}
+
+func TestLoadProfileInvalidYAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profiles.yaml")
+	assert.NilError(t, os.WriteFile(path, []byte("profiles: [light"), 0644))
+
+	_, err := LoadProfile(path, "light")
+	assert.ErrorContains(t, err, "failed to parse profiles file")
+}
+
+func TestApplyProfile(t *testing.T) {
+	newFlags := func() (*pflag.FlagSet, *Config) {
+		var config Config
+		flags := pflag.NewFlagSet("spam", pflag.ContinueOnError)
+		flags.Uint64Var(&config.TPS, flagTPS, 10, "")
+		flags.StringVar(&config.Fees, flagFees, "", "")
+		flags.BoolVar(&config.Heavy, flagHeavy, false, "")
+		return flags, &config
+	}
+
+	t.Run("profile values are applied", func(t *testing.T) {
+		flags, config := newFlags()
+		assert.NilError(t, applyProfile(flags, map[string]string{"tps": "50", "fees": "5000uatom", "heavy": "true"}))
+		assert.Equal(t, config.TPS, uint64(50))
+		assert.Equal(t, config.Fees, "5000uatom")
+		assert.Equal(t, config.Heavy, true)
+	})
+
+	t.Run("command line flags take precedence", func(t *testing.T) {
+		flags, config := newFlags()
+		assert.NilError(t, flags.Parse([]string{"--tps", "20"}))
+		assert.NilError(t, applyProfile(flags, map[string]string{"tps": "50", "fees": "5000uatom"}))
+		assert.Equal(t, config.TPS, uint64(20))
+		assert.Equal(t, config.Fees, "5000uatom")
+	})
+
+	t.Run("unknown flag", func(t *testing.T) {
+		flags, _ := newFlags()
+		err := applyProfile(flags, map[string]string{"speed": "50"})
+		assert.ErrorContains(t, err, "unknown flag 'speed'")
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		flags, _ := newFlags()
+		err := applyProfile(flags, map[string]string{"tps": "fast"})
+		assert.ErrorContains(t, err, "invalid value for 'tps'")
+	})
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"time"
+)
+
+// rampLogInterval is the interval between two logs of the current rate during ramp-up
+const rampLogInterval = time.Second
+
+// rampTPS returns the rate after elapsed, increasing linearly from startTPS to targetTPS over rampDuration
+func rampTPS(startTPS, targetTPS int, rampDuration, elapsed time.Duration) float64 {
+	if elapsed >= rampDuration {
+		return float64(targetTPS)
+	}
+
+	progress := float64(elapsed) / float64(rampDuration)
+	return float64(startTPS) + float64(targetTPS-startTPS)*progress
+}
+
+// rampTicker delivers ticks at a rate increasing linearly from startTPS to targetTPS over rampDuration,
+// then at targetTPS with a steady ticker until the context is cancelled.
+// Like time.Ticker, ticks are dropped when the receiver is too slow.
+func rampTicker(ctx context.Context, startTPS, targetTPS int, rampDuration time.Duration) <-chan time.Time {
+	ticks := make(chan time.Time, 1)
+
+	tick := func(now time.Time) {
+		select {
+		case ticks <- now:
+		default:
+		}
+	}
+
+	go func() {
+		start := time.Now()
+		lastLog := start
+
+		for {
+			elapsed := time.Since(start)
+			if elapsed >= rampDuration {
+				break
+			}
+
+			tps := rampTPS(startTPS, targetTPS, rampDuration, elapsed)
+			timer := time.NewTimer(time.Duration(float64(time.Second) / tps))
+			select {
+			case now := <-timer.C:
+				tick(now)
+				if now.Sub(lastLog) >= rampLogInterval {
+					logger.Info("📈 Ramping up", "tps", int(rampTPS(startTPS, targetTPS, rampDuration, now.Sub(start))), "target_tps", targetTPS)
+					lastLog = now
+				}
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			}
+		}
+
+		logger.Info("📈 Ramp-up done", "tps", targetTPS)
+
+		ticker := time.NewTicker(time.Second / time.Duration(targetTPS))
+		defer ticker.Stop()
+
+		for {
+			select {
+			case now := <-ticker.C:
+				tick(now)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ticks
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestRampTPS(t *testing.T) {
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		expect  float64
+	}{
+		{name: "start", elapsed: 0, expect: 1},
+		{name: "quarter", elapsed: 15 * time.Second, expect: 26},
+		{name: "half", elapsed: 30 * time.Second, expect: 51},
+		{name: "end", elapsed: time.Minute, expect: 101},
+		{name: "after ramp-up", elapsed: 2 * time.Minute, expect: 101},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, rampTPS(1, 101, time.Minute, tt.elapsed), tt.expect)
+		})
+	}
+}
+
+func TestRampTicker(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Ramp from 50 to 1000 TPS over 200ms, then tick at 1000 TPS
+	ticks := rampTicker(ctx, 50, 1000, 200*time.Millisecond)
+
+	count := func(d time.Duration) int {
+		n := 0
+		timeout := time.After(d)
+		for {
+			select {
+			case <-ticks:
+				n++
+			case <-timeout:
+				return n
+			}
+		}
+	}
+
+	// The rate during ramp-up is below the steady-state rate
+	rampTicks := count(200 * time.Millisecond)
+	steadyTicks := count(200 * time.Millisecond)
+	assert.Assert(t, rampTicks > 0)
+	assert.Assert(t, steadyTicks > rampTicks, "ramp-up: %d ticks, steady state: %d ticks", rampTicks, steadyTicks)
+
+	// No more ticks are delivered once the context is cancelled
+	cancel()
+	time.Sleep(10 * time.Millisecond)
+	for len(ticks) > 0 {
+		<-ticks
+	}
+	assert.Equal(t, count(50*time.Millisecond), 0)
+}
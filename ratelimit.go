@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	rateLimitTicker      = "ticker"
+	rateLimitTokenBucket = "token-bucket"
+)
+
+// TokenBucket is a token bucket rate limiter, refilled continuously at rate tokens per second up to burst tokens.
+// Unlike time.Ticker, whose period is rounded to 1s/TPS and which drops the ticks missed by a slow receiver,
+// waiters are scheduled from the tokens accounted so far, so the average rate stays at the target.
+type TokenBucket struct {
+	rate  float64
+	burst float64
+
+	// mu guards tokens and last
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewTokenBucket creates a full token bucket refilled at rate tokens per second, holding up to burst tokens
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	return &TokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a token is available, or returns the context error when the context is cancelled first
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	delay := b.reserve(time.Now())
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// Give back the token that was not used
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// reserve takes a token at now and returns how long to wait until it is available.
+// The token count goes negative when waiting, so that concurrent waiters are spaced out.
+func (b *TokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = min(b.burst, b.tokens+elapsed.Seconds()*b.rate)
+		b.last = now
+	}
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// tokenBucketTicker delivers ticks at tps using a token bucket until the context is cancelled.
+// Like time.Ticker, ticks are dropped when the receiver is too slow.
+func tokenBucketTicker(ctx context.Context, tps uint64) <-chan time.Time {
+	ticks := make(chan time.Time, 1)
+	bucket := NewTokenBucket(float64(tps), 1)
+
+	go func() {
+		for {
+			if err := bucket.Wait(ctx); err != nil {
+				return
+			}
+
+			select {
+			case ticks <- time.Now():
+			default:
+			}
+		}
+	}()
+
+	return ticks
+}
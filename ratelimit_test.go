@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestTokenBucketReserve(t *testing.T) {
+	bucket := NewTokenBucket(10, 2)
+	now := bucket.last
+
+	// The burst is available right away
+	assert.Equal(t, bucket.reserve(now), time.Duration(0))
+	assert.Equal(t, bucket.reserve(now), time.Duration(0))
+
+	// Then the waiters are spaced out by 1s/rate
+	assert.Equal(t, bucket.reserve(now), 100*time.Millisecond)
+	assert.Equal(t, bucket.reserve(now), 200*time.Millisecond)
+
+	// Refilling never exceeds the burst
+	assert.Equal(t, bucket.reserve(now.Add(time.Hour)), time.Duration(0))
+	assert.Equal(t, bucket.tokens, float64(1))
+}
+
+func TestTokenBucketWaitCancelled(t *testing.T) {
+	bucket := NewTokenBucket(1, 1)
+	assert.NilError(t, bucket.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, bucket.Wait(ctx), context.DeadlineExceeded)
+
+	// The token of the cancelled wait is given back
+	assert.Assert(t, bucket.tokens > -1, "tokens: %f", bucket.tokens)
+}
+
+func TestTokenBucketTicker(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const tps = 200
+	ticks := tokenBucketTicker(ctx, tps)
+
+	start := time.Now()
+	for range 20 {
+		<-ticks
+	}
+	elapsed := time.Since(start)
+
+	// 20 ticks at 200 TPS take ~95ms, the first token being available right away
+	assert.Assert(t, elapsed >= 80*time.Millisecond, "elapsed: %s", elapsed)
+	assert.Assert(t, elapsed < time.Second, "elapsed: %s", elapsed)
+}
+
+// tickJitter returns the standard deviation of the intervals between n ticks, in nanoseconds
+func tickJitter(ticks <-chan time.Time, n int) float64 {
+	intervals := make([]float64, 0, n)
+	last := <-ticks
+	for range n {
+		now := <-ticks
+		intervals = append(intervals, float64(now.Sub(last)))
+		last = now
+	}
+
+	var mean float64
+	for _, interval := range intervals {
+		mean += interval
+	}
+	mean /= float64(len(intervals))
+
+	var variance float64
+	for _, interval := range intervals {
+		variance += (interval - mean) * (interval - mean)
+	}
+
+	return math.Sqrt(variance / float64(len(intervals)))
+}
+
+// BenchmarkRateLimitJitter compares the jitter of the tick intervals of the rate limit strategies, run with:
+// go test -run '^$' -bench RateLimitJitter
+func BenchmarkRateLimitJitter(b *testing.B) {
+	const tps = 1000
+
+	strategies := map[string]func(ctx context.Context) <-chan time.Time{
+		rateLimitTicker: func(ctx context.Context) <-chan time.Time {
+			ticker := time.NewTicker(time.Second / tps)
+			context.AfterFunc(ctx, ticker.Stop)
+			return ticker.C
+		},
+		rateLimitTokenBucket: func(ctx context.Context) <-chan time.Time {
+			return tokenBucketTicker(ctx, tps)
+		},
+	}
+
+	for name, newTicks := range strategies {
+		b.Run(name, func(b *testing.B) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			ticks := newTicks(ctx)
+			b.ResetTimer()
+			b.ReportMetric(tickJitter(ticks, b.N), "jitter-ns")
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// recipientGenerator returns a function deriving the recipient address of the nth transaction
+// from the first 20 bytes of sha256(seed || n), both big-endian encoded.
+// The same seed always produces the same sequence of distinct recipients.
+func recipientGenerator(seed uint64, prefix string) func(n uint64) string {
+	return func(n uint64) string {
+		var preimage [16]byte
+		binary.BigEndian.PutUint64(preimage[:8], seed)
+		binary.BigEndian.PutUint64(preimage[8:], n)
+		hash := sha256.Sum256(preimage[:])
+
+		return sdk.MustBech32ifyAddressBytes(prefix, sdk.AccAddress(hash[:20]))
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"gotest.tools/v3/assert"
+)
+
+func TestRecipientGenerator(t *testing.T) {
+	generate := func(seed uint64, count int) []string {
+		next := recipientGenerator(seed, "cosmos")
+		addresses := make([]string, count)
+		for i := range addresses {
+			addresses[i] = next(uint64(i))
+		}
+		return addresses
+	}
+
+	// Two runs with the same seed produce the same recipients
+	first := generate(42, 100)
+	assert.DeepEqual(t, first, generate(42, 100))
+
+	// The recipients are distinct valid account addresses
+	seen := make(map[string]bool)
+	for _, address := range first {
+		accAddr, err := sdk.GetFromBech32(address, "cosmos")
+		assert.NilError(t, err)
+		assert.NilError(t, sdk.VerifyAddressFormat(accAddr))
+		assert.Assert(t, !seen[address], "duplicate recipient %s", address)
+		seen[address] = true
+	}
+
+	// Another seed produces other recipients
+	other := generate(43, 100)
+	for _, address := range other {
+		assert.Assert(t, !seen[address], "recipient %s shared between seeds", address)
+	}
+}
@@ -1,138 +1,72 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
-	"strings"
+	"os"
+	"path/filepath"
 	"time"
 
-	"github.com/ignite/cli/v29/ignite/pkg/chainregistry"
+	"github.com/julienrbrt/spamtx/chainregistry"
 )
 
 const (
-	repoURL               = "https://github.com/cosmos/chain-registry"
-	cosmosDirectoryAPIURL = "https://chains.cosmos.directory"
-)
-
-type ChainRegistry struct {
-	Chains map[string]chainregistry.Chain
-	Assets map[string]chainregistry.Asset
-}
+	repoURL = "https://github.com/cosmos/chain-registry"
 
-func NewChainRegistry() *ChainRegistry {
-	return &ChainRegistry{
-		Chains: make(map[string]chainregistry.Chain),
-		Assets: make(map[string]chainregistry.Asset),
-	}
-}
-
-// FetchChains fetches the list of chains from the cosmos.directory API
-// Note, the output chainregistry.Chain doesn't contain the full list of fields
-func (r *ChainRegistry) FetchChains() error {
-	client := &http.Client{
-		Timeout: 5 * time.Second,
-	}
-
-	req, err := http.NewRequest(http.MethodGet, cosmosDirectoryAPIURL, nil)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
+	// DefaultRegistryTTL is the default duration for which the cached chain list is used
+	DefaultRegistryTTL = time.Hour
+)
 
-	resp, err := client.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to fetch chains: %w", err)
-	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
+var (
+	// registryTTL is the maximum age of the cached chain list
+	registryTTL = DefaultRegistryTTL
+	// registryNoCache bypasses the chain list cache when set
+	registryNoCache bool
+	// registryURL is the base URL of the cosmos.directory compatible chain registry API
+	registryURL = chainregistry.DefaultURL
+	// registryBranch is the chain registry git branch whose chain.json files are fetched from GitHub, empty for the registry API
+	registryBranch string
+)
 
-	body, err := io.ReadAll(resp.Body)
+// getRegistryCachePath returns the path of the chain registry cache file
+func getRegistryCachePath() (string, error) {
+	spamtxHome, err := getSpamtxHome()
 	if err != nil {
-		return fmt.Errorf("failed to read response: %w", err)
-	}
-
-	var cdOutput map[string]json.RawMessage
-	if err := json.Unmarshal(body, &cdOutput); err != nil {
-		return fmt.Errorf("failed to unmarshal cosmos.directory API response: %w", err)
-	}
-
-	rawChains, ok := cdOutput["chains"]
-	if !ok {
-		return fmt.Errorf("failed to get chains from response: cosmos.directory API may have changed")
+		return "", err
 	}
 
-	var chains []chainregistry.Chain
-	if err := json.Unmarshal(rawChains, &chains); err != nil {
-		return fmt.Errorf("failed to unmarshal chains: %w", err)
+	if err := os.MkdirAll(spamtxHome, 0755); err != nil {
+		return "", fmt.Errorf("failed to create spamtx directory: %w", err)
 	}
 
-	for _, c := range chains {
-		r.Chains[c.ChainName] = c
-	}
-
-	return nil
+	return filepath.Join(spamtxHome, "chain-registry-cache.json"), nil
 }
 
-// EnrichChain fetches the full chain information from the cosmos.directory API
-func EnrichChain(chain *chainregistry.Chain) error {
-	baseURL := fmt.Sprintf("%s/%s", cosmosDirectoryAPIURL, chain.ChainName)
-
-	client := &http.Client{
-		Timeout: 5 * time.Second,
-	}
-
-	req, err := http.NewRequest(http.MethodGet, baseURL, nil)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to fetch chains: %w", err)
-	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
+// loadChainRegistry returns the chain list, from the local cache when it is fresh enough
+func loadChainRegistry() (*chainregistry.Registry, error) {
+	registry := chainregistry.New(registryURL)
+	registry.Branch = registryBranch
 
-	body, err := io.ReadAll(resp.Body)
+	cachePath, err := getRegistryCachePath()
 	if err != nil {
-		return fmt.Errorf("failed to read response: %w", err)
+		return nil, err
 	}
 
-	apiResponseType := struct {
-		Chain *chainregistry.Chain `json:"chain"`
-	}{
-		Chain: chain,
+	if !registryNoCache {
+		loaded, err := registry.LoadCached(cachePath, registryTTL)
+		if err != nil {
+			logger.Warn("⚠️ Ignoring chain registry cache", "error", err)
+		} else if loaded {
+			return registry, nil
+		}
 	}
 
-	if err := json.Unmarshal(body, &apiResponseType); err != nil {
-		return fmt.Errorf("failed to unmarshal cosmos.directory API response: %w", err)
+	if err := registry.Fetch(); err != nil {
+		return nil, err
 	}
 
-	chain.APIs.Grpc = cleanGRPCEntries(chain.APIs.Grpc)
-
-	return nil
-}
-
-func cleanGRPCEntries(entries []chainregistry.APIProvider) []chainregistry.APIProvider {
-	cleanEntries := make([]chainregistry.APIProvider, 0)
-	for _, api := range entries {
-		// clean-up the http(s):// prefix
-		if idx := strings.Index(api.Address, "://"); idx != -1 {
-			api.Address = api.Address[idx+3:]
-		}
-		// remove trailing slashes
-		api.Address = strings.TrimSuffix(api.Address, "/")
-
-		// remove addresses without a port
-		if !strings.Contains(api.Address, ":") {
-			continue
-		}
-
-		cleanEntries = append(cleanEntries, api)
+	if err := registry.SaveCache(cachePath); err != nil {
+		logger.Warn("⚠️ Failed to cache chain registry", "error", err)
 	}
 
-	return cleanEntries
+	return registry, nil
 }
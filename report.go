@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+)
+
+// ErrorGroup is the number of failed transactions with the same error message
+type ErrorGroup struct {
+	Error string `json:"error"`
+	Count int    `json:"count"`
+}
+
+// ErrorReport summarizes the failed transactions of an error file.
+// Errors counts every failed attempt, while FailedTxs counts a transaction retried with the same tx_num once.
+type ErrorReport struct {
+	// TotalTxs is the number of transactions up to the last failed one, from its tx_num
+	TotalTxs        uint64       `json:"total_txs"`
+	FailedTxs       int          `json:"failed_txs"`
+	Errors          int          `json:"errors"`
+	ErrorRate       float64      `json:"error_rate"`
+	FirstError      time.Time    `json:"first_error"`
+	LastError       time.Time    `json:"last_error"`
+	MostCommonError string       `json:"most_common_error"`
+	Groups          []ErrorGroup `json:"groups"`
+}
+
+// ParseErrorLog reads the records of an error file written with --error-file, skipping blank lines
+func ParseErrorLog(path string) ([]ErrorRecord, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open error file: %w", err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	var records []ErrorRecord
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var record ErrorRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("invalid record on line %d of error file %s: %w", line, path, err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read error file: %w", err)
+	}
+
+	return records, nil
+}
+
+// newErrorReport groups the records by error message, the most frequent first
+func newErrorReport(records []ErrorRecord) ErrorReport {
+	report := ErrorReport{
+		Errors: len(records),
+		Groups: []ErrorGroup{},
+	}
+	if len(records) == 0 {
+		return report
+	}
+
+	counts := make(map[string]int)
+	failed := make(map[uint64]bool)
+	report.FirstError, report.LastError = records[0].Timestamp, records[0].Timestamp
+	for _, record := range records {
+		counts[record.Error]++
+		failed[record.TxNum] = true
+		report.TotalTxs = max(report.TotalTxs, record.TxNum+1)
+		if record.Timestamp.Before(report.FirstError) {
+			report.FirstError = record.Timestamp
+		}
+		if record.Timestamp.After(report.LastError) {
+			report.LastError = record.Timestamp
+		}
+	}
+	report.FailedTxs = len(failed)
+	report.ErrorRate = float64(report.FailedTxs) / float64(report.TotalTxs)
+
+	for message, count := range counts {
+		report.Groups = append(report.Groups, ErrorGroup{Error: message, Count: count})
+	}
+	slices.SortFunc(report.Groups, func(a, b ErrorGroup) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Error, b.Error))
+	})
+	report.MostCommonError = report.Groups[0].Error
+
+	return report
+}
+
+// printErrorReport prints the error frequency table with the summary of the run, or the report as JSON
+func printErrorReport(report ErrorReport, output string) error {
+	switch output {
+	case "json":
+		bz, err := json.Marshal(report)
+		if err != nil {
+			return fmt.Errorf("failed to encode error report: %w", err)
+		}
+		fmt.Println(string(bz))
+	case "text":
+		if report.Errors == 0 {
+			fmt.Println("No failed transaction recorded.")
+			return nil
+		}
+
+		fmt.Printf("%8s %7s  %s\n", "COUNT", "SHARE", "ERROR")
+		for _, group := range report.Groups {
+			fmt.Printf("%8d %6.1f%%  %s\n", group.Count, float64(group.Count)/float64(report.Errors)*100, group.Error)
+		}
+
+		fmt.Println()
+		fmt.Printf("Transactions:      %d (up to the last failed one)\n", report.TotalTxs)
+		fmt.Printf("Failed txs:        %d (%.1f%%)\n", report.FailedTxs, report.ErrorRate*100)
+		fmt.Printf("Errors:            %d (including retried attempts)\n", report.Errors)
+		fmt.Printf("First error:       %s\n", report.FirstError.Format(time.RFC3339))
+		fmt.Printf("Last error:        %s\n", report.LastError.Format(time.RFC3339))
+		fmt.Printf("Most common error: %s\n", report.MostCommonError)
+	default:
+		return fmt.Errorf("unknown output format %q, must be one of: text, json", output)
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestParseErrorLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.jsonl")
+
+	var errorLog ErrorLog
+	assert.NilError(t, errorLog.Open(path))
+	timestamp := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	assert.NilError(t, errorLog.Write(ErrorRecord{TxNum: 1, Seq: 11, Error: "insufficient fees", Timestamp: timestamp}))
+	assert.NilError(t, errorLog.Write(ErrorRecord{TxNum: 4, Seq: 14, Error: "out of gas", Timestamp: timestamp}))
+	assert.NilError(t, errorLog.Close())
+
+	records, err := ParseErrorLog(path)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, records, []ErrorRecord{
+		{TxNum: 1, Seq: 11, Error: "insufficient fees", Timestamp: timestamp},
+		{TxNum: 4, Seq: 14, Error: "out of gas", Timestamp: timestamp},
+	})
+
+	// Invalid records are reported with their line
+	assert.NilError(t, os.WriteFile(path, []byte("{\"tx_num\":1}\n\n{\"tx_num\":\n"), 0o644))
+	_, err = ParseErrorLog(path)
+	assert.ErrorContains(t, err, "line 3")
+
+	_, err = ParseErrorLog(filepath.Join(t.TempDir(), "missing.jsonl"))
+	assert.ErrorContains(t, err, "failed to open error file")
+}
+
+func TestNewErrorReport(t *testing.T) {
+	start := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	report := newErrorReport([]ErrorRecord{
+		{TxNum: 3, Error: "out of gas", Timestamp: start.Add(time.Second)},
+		{TxNum: 1, Error: "insufficient fees", Timestamp: start},
+		{TxNum: 19, Error: "out of gas", Timestamp: start.Add(3 * time.Second)},
+		{TxNum: 7, Error: "account sequence mismatch", Timestamp: start.Add(2 * time.Second)},
+		{TxNum: 9, Error: "out of gas", Timestamp: start.Add(2 * time.Second)},
+	})
+
+	assert.DeepEqual(t, report, ErrorReport{
+		TotalTxs:        20,
+		FailedTxs:       5,
+		Errors:          5,
+		ErrorRate:       0.25,
+		FirstError:      start,
+		LastError:       start.Add(3 * time.Second),
+		MostCommonError: "out of gas",
+		Groups: []ErrorGroup{
+			{Error: "out of gas", Count: 3},
+			{Error: "account sequence mismatch", Count: 1},
+			{Error: "insufficient fees", Count: 1},
+		},
+	})
+}
+
+func TestNewErrorReportRetriedTransactions(t *testing.T) {
+	start := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	// Transaction 0 failed three times with the same tx_num, its sequence being released and retried
+	report := newErrorReport([]ErrorRecord{
+		{TxNum: 0, Error: "account sequence mismatch", Timestamp: start},
+		{TxNum: 0, Error: "account sequence mismatch", Timestamp: start.Add(time.Second)},
+		{TxNum: 0, Error: "account sequence mismatch", Timestamp: start.Add(2 * time.Second)},
+		{TxNum: 3, Error: "out of gas", Timestamp: start.Add(3 * time.Second)},
+	})
+
+	assert.Equal(t, report.TotalTxs, uint64(4))
+	assert.Equal(t, report.FailedTxs, 2)
+	assert.Equal(t, report.Errors, 4)
+	assert.Equal(t, report.ErrorRate, 0.5)
+}
+
+func TestNewErrorReportEmpty(t *testing.T) {
+	report := newErrorReport(nil)
+	assert.Equal(t, report.Errors, 0)
+	assert.Equal(t, report.ErrorRate, 0.0)
+	assert.Equal(t, len(report.Groups), 0)
+
+	assert.ErrorContains(t, printErrorReport(report, "yaml"), "unknown output format")
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/ignite/cli/v29/ignite/pkg/cosmosclient"
+)
+
+const (
+	// defaultTxTimeout is the default maximum duration of a single broadcast attempt
+	defaultTxTimeout = 30 * time.Second
+	// defaultRetryDelay is the delay before the first retry of a failed broadcast
+	defaultRetryDelay = 500 * time.Millisecond
+)
+
+const (
+	// broadcastModeAsync dispatches the transactions of a tick without waiting for their CheckTx result
+	broadcastModeAsync = "async"
+	// broadcastModeSync waits for the CheckTx result of the transactions of a tick before the next tick
+	broadcastModeSync = "sync"
+	// broadcastModeBlock waits for the transactions of a tick to be included in a block before the next tick
+	broadcastModeBlock = "block"
+)
+
+// txBroadcaster broadcasts a signed transaction, as done by cosmosclient.TxService
+type txBroadcaster interface {
+	BroadcastAsync(ctx context.Context, opts ...cosmosclient.BroadcastOption) (cosmosclient.Response, error)
+}
+
+// broadcastWithRetry broadcasts the transaction, retrying transient failures up to retries times with exponential backoff.
+// Each attempt is cancelled after timeout.
+func broadcastWithRetry(ctx context.Context, txService txBroadcaster, sequence, retries uint64, baseDelay, timeout time.Duration) (cosmosclient.Response, error) {
+	delay := baseDelay
+	for attempt := uint64(0); ; attempt++ {
+		attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+		response, err := txService.BroadcastAsync(attemptCtx, cosmosclient.WithSequence(sequence))
+		cancel()
+
+		if err == nil || attempt >= retries || ctx.Err() != nil || !isRetryableError(err) {
+			return response, err
+		}
+
+		logger.Warn("🔁 Retrying broadcast", "sequence", sequence, "attempt", attempt+1, "retries", retries, "delay", delay, "error", err)
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return response, err
+		}
+		delay *= 2
+	}
+}
+
+// isRetryableError reports whether a broadcast error is a transient network failure.
+// Errors returned by the node, such as sequence mismatches or insufficient fees, are not retried.
+func isRetryableError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ignite/cli/v29/ignite/pkg/cosmosclient"
+	"gotest.tools/v3/assert"
+)
+
+// failingBroadcaster fails with the given errors before succeeding
+type failingBroadcaster struct {
+	errs  []error
+	calls int
+}
+
+func (b *failingBroadcaster) BroadcastAsync(context.Context, ...cosmosclient.BroadcastOption) (cosmosclient.Response, error) {
+	b.calls++
+	if b.calls <= len(b.errs) {
+		return cosmosclient.Response{}, b.errs[b.calls-1]
+	}
+	return cosmosclient.Response{}, nil
+}
+
+func TestBroadcastWithRetry(t *testing.T) {
+	timeoutErr := &net.OpError{Op: "dial", Net: "tcp", Err: context.DeadlineExceeded}
+	sequenceErr := errors.New("error code: '32' msg: 'account sequence mismatch, expected 5, got 4'")
+
+	tests := []struct {
+		name      string
+		errs      []error
+		retries   uint64
+		wantErr   bool
+		wantCalls int
+	}{
+		{
+			name:      "success without retry",
+			retries:   3,
+			wantErr:   false,
+			wantCalls: 1,
+		},
+		{
+			name:      "no retry configured",
+			errs:      []error{timeoutErr},
+			retries:   0,
+			wantErr:   true,
+			wantCalls: 1,
+		},
+		{
+			name:      "transient error retried until success",
+			errs:      []error{timeoutErr, timeoutErr},
+			retries:   3,
+			wantErr:   false,
+			wantCalls: 3,
+		},
+		{
+			name:      "transient error exhausts retries",
+			errs:      []error{timeoutErr, timeoutErr, timeoutErr},
+			retries:   2,
+			wantErr:   true,
+			wantCalls: 3,
+		},
+		{
+			name:      "sequence mismatch not retried",
+			errs:      []error{sequenceErr},
+			retries:   3,
+			wantErr:   true,
+			wantCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			broadcaster := &failingBroadcaster{errs: tt.errs}
+
+			_, err := broadcastWithRetry(context.Background(), broadcaster, 1, tt.retries, time.Millisecond, defaultTxTimeout)
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+			} else {
+				assert.NilError(t, err)
+			}
+			assert.Equal(t, broadcaster.calls, tt.wantCalls)
+		})
+	}
+}
+
+func TestBroadcastWithRetryStopsOnContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	broadcaster := &failingBroadcaster{errs: []error{context.DeadlineExceeded}}
+	_, err := broadcastWithRetry(ctx, broadcaster, 1, 3, time.Hour, defaultTxTimeout)
+	assert.Assert(t, err != nil)
+	assert.Equal(t, broadcaster.calls, 1)
+}
+
+// blockingBroadcaster blocks until the broadcast context is done
+type blockingBroadcaster struct{}
+
+func (blockingBroadcaster) BroadcastAsync(ctx context.Context, _ ...cosmosclient.BroadcastOption) (cosmosclient.Response, error) {
+	<-ctx.Done()
+	return cosmosclient.Response{}, ctx.Err()
+}
+
+func TestBroadcastWithRetryTimeout(t *testing.T) {
+	start := time.Now()
+	_, err := broadcastWithRetry(context.Background(), blockingBroadcaster{}, 1, 0, time.Millisecond, 20*time.Millisecond)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Assert(t, time.Since(start) < defaultTxTimeout)
+}
+
+func TestIsRetryableError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "deadline exceeded", err: context.DeadlineExceeded, want: true},
+		{name: "network error", err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, want: true},
+		{name: "insufficient fee", err: errors.New("error code: '13' msg: 'insufficient fee'"), want: false},
+		{name: "sequence mismatch", err: errors.New("error code: '32' msg: 'account sequence mismatch'"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, isRetryableError(tt.err), tt.want)
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// rpcPoolCheckInterval is the interval between two health checks of the RPC pool endpoints
+	rpcPoolCheckInterval = 10 * time.Second
+	// rpcPoolRecheckDelay is the duration an unhealthy endpoint is left out of the RPC pool
+	rpcPoolRecheckDelay = 30 * time.Second
+)
+
+// healthChecker checks that an RPC endpoint is healthy
+type healthChecker func(ctx context.Context, endpoint string) error
+
+// RPCPool round-robins across the healthy endpoints of a set of RPC endpoints.
+// Endpoints failing their health check are removed from the rotation and re-added after rpcPoolRecheckDelay.
+type RPCPool struct {
+	endpoints []string
+	check     healthChecker
+
+	// mu guards next and unhealthy
+	mu   sync.Mutex
+	next int
+	// unhealthy maps the removed endpoints to the time they were removed
+	unhealthy map[string]time.Time
+}
+
+// NewRPCPool creates a pool of the given endpoints, all considered healthy
+func NewRPCPool(endpoints []string, check healthChecker) *RPCPool {
+	return &RPCPool{
+		endpoints: endpoints,
+		check:     check,
+		unhealthy: make(map[string]time.Time),
+	}
+}
+
+// Next returns the next healthy endpoint. When all endpoints are unhealthy, it round-robins across all of them.
+func (p *RPCPool) Next() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for range p.endpoints {
+		endpoint := p.endpoints[p.next]
+		p.next = (p.next + 1) % len(p.endpoints)
+
+		if _, unhealthy := p.unhealthy[endpoint]; !unhealthy {
+			return endpoint
+		}
+	}
+
+	endpoint := p.endpoints[p.next]
+	p.next = (p.next + 1) % len(p.endpoints)
+	return endpoint
+}
+
+// Run checks the health of the endpoints every interval until the context is cancelled
+func (p *RPCPool) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.checkAll(ctx, time.Now())
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// checkAll re-adds the endpoints removed for longer than rpcPoolRecheckDelay,
+// then removes the healthy endpoints failing their health check
+func (p *RPCPool) checkAll(ctx context.Context, now time.Time) {
+	for _, endpoint := range p.endpoints {
+		p.mu.Lock()
+		removedAt, unhealthy := p.unhealthy[endpoint]
+		if unhealthy && now.Sub(removedAt) >= rpcPoolRecheckDelay {
+			delete(p.unhealthy, endpoint)
+			logger.Info("🔌 RPC endpoint re-added to the pool", "endpoint", endpoint)
+		}
+		p.mu.Unlock()
+
+		if unhealthy {
+			continue
+		}
+
+		if err := p.check(ctx, endpoint); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			p.mu.Lock()
+			p.unhealthy[endpoint] = now
+			p.mu.Unlock()
+			logger.Warn("⚠️ RPC endpoint removed from the pool", "endpoint", endpoint, "for", rpcPoolRecheckDelay, "error", err)
+		}
+	}
+}
+
+// parseRPCPool parses a comma-separated list of RPC endpoints
+func parseRPCPool(pool string) ([]string, error) {
+	var endpoints []string
+	for endpoint := range strings.SplitSeq(pool, ",") {
+		endpoint = strings.TrimSpace(endpoint)
+		if endpoint == "" {
+			return nil, errors.New("rpc pool contains an empty endpoint")
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+
+	return endpoints, nil
+}
+
+// newHealthClient creates the HTTP client of the health checks, skipping the TLS certificate verification when insecureSkipTLS is set
+func newHealthClient(insecureSkipTLS bool) *http.Client {
+	httpClient := &http.Client{
+		Timeout: 5 * time.Second,
+	}
+	if insecureSkipTLS {
+		httpClient.Transport = &http.Transport{
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: true,
+			},
+		}
+	}
+
+	return httpClient
+}
+
+// checkRPCHealth queries the /health route of an RPC endpoint, which returns 200 when the node is up
+func checkRPCHealth(httpClient *http.Client) healthChecker {
+	return func(ctx context.Context, endpoint string) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(endpoint, "/")+"/health", nil)
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("health check returned status %d", resp.StatusCode)
+		}
+
+		return nil
+	}
+}
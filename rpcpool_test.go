@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestRPCPoolNext(t *testing.T) {
+	tests := []struct {
+		name      string
+		unhealthy []string
+		expect    []string
+	}{
+		{
+			name:   "all healthy",
+			expect: []string{"a", "b", "c", "a", "b"},
+		},
+		{
+			name:      "skips unhealthy endpoints",
+			unhealthy: []string{"b"},
+			expect:    []string{"a", "c", "a", "c"},
+		},
+		{
+			name:      "all unhealthy",
+			unhealthy: []string{"a", "b", "c"},
+			expect:    []string{"a", "b", "c", "a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := NewRPCPool([]string{"a", "b", "c"}, nil)
+			for _, endpoint := range tt.unhealthy {
+				pool.unhealthy[endpoint] = time.Now()
+			}
+
+			for _, endpoint := range tt.expect {
+				assert.Equal(t, pool.Next(), endpoint)
+			}
+		})
+	}
+}
+
+func TestRPCPoolCheckAll(t *testing.T) {
+	down := map[string]bool{"b": true}
+	var checked []string
+	check := func(_ context.Context, endpoint string) error {
+		checked = append(checked, endpoint)
+		if down[endpoint] {
+			return errors.New("health check returned status 503")
+		}
+		return nil
+	}
+
+	pool := NewRPCPool([]string{"a", "b"}, check)
+	now := time.Now()
+
+	// Failing endpoints are removed from the rotation
+	pool.checkAll(context.Background(), now)
+	assert.DeepEqual(t, checked, []string{"a", "b"})
+	assert.Equal(t, pool.Next(), "a")
+	assert.Equal(t, pool.Next(), "a")
+
+	// Removed endpoints are not checked before the recheck delay
+	checked = nil
+	delete(down, "b")
+	pool.checkAll(context.Background(), now.Add(rpcPoolRecheckDelay/2))
+	assert.DeepEqual(t, checked, []string{"a"})
+	assert.Equal(t, pool.Next(), "a")
+
+	// Removed endpoints are re-added after the recheck delay, and checked again on the next round
+	checked = nil
+	pool.checkAll(context.Background(), now.Add(rpcPoolRecheckDelay))
+	assert.DeepEqual(t, checked, []string{"a"})
+	assert.Equal(t, pool.Next(), "b")
+	assert.Equal(t, pool.Next(), "a")
+
+	checked = nil
+	pool.checkAll(context.Background(), now.Add(rpcPoolRecheckDelay+rpcPoolCheckInterval))
+	assert.DeepEqual(t, checked, []string{"a", "b"})
+}
+
+func TestParseRPCPool(t *testing.T) {
+	tests := []struct {
+		name    string
+		pool    string
+		expect  []string
+		wantErr bool
+	}{
+		{
+			name:   "single endpoint",
+			pool:   "http://node1:26657",
+			expect: []string{"http://node1:26657"},
+		},
+		{
+			name:   "multiple endpoints with spaces",
+			pool:   "http://node1:26657, http://node2:26657",
+			expect: []string{"http://node1:26657", "http://node2:26657"},
+		},
+		{
+			name:    "empty endpoint",
+			pool:    "http://node1:26657,,http://node2:26657",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoints, err := parseRPCPool(tt.pool)
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, endpoints, tt.expect)
+		})
+	}
+}
+
+func TestCheckRPCHealth(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{
+			name:   "healthy",
+			status: http.StatusOK,
+		},
+		{
+			name:    "unhealthy",
+			status:  http.StatusServiceUnavailable,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, r.URL.Path, "/health")
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			err := checkRPCHealth(newHealthClient(false))(context.Background(), server.URL+"/")
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "status 503")
+				return
+			}
+			assert.NilError(t, err)
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+const (
+	// sequencePoolSize is the number of sequences pre-allocated by the sequence pool
+	sequencePoolSize = 1000
+	// sequencePoolThreshold is the number of pre-allocated sequences below which the pool is refilled
+	sequencePoolThreshold = 250
+)
+
+// sequenceFetcher returns the current sequence of the account on chain
+type sequenceFetcher func(ctx context.Context) (uint64, error)
+
+// SequencePool hands out account sequences from a buffer of pre-allocated sequences.
+// A background goroutine refills the buffer when it runs low and resyncs it with the
+// on-chain sequence when the chain is ahead, e.g. when the account is used elsewhere.
+type SequencePool struct {
+	start     uint64
+	seqs      chan uint64
+	refill    chan struct{}
+	threshold int
+	fetch     sequenceFetcher
+
+	// mu guards next and released
+	mu       sync.Mutex
+	next     uint64
+	released []uint64
+}
+
+// NewSequencePool creates a pool handing out sequences from start.
+// fetch may be nil, in which case the pool never resyncs with the chain.
+func NewSequencePool(start uint64, size, threshold int, fetch sequenceFetcher) *SequencePool {
+	p := &SequencePool{
+		start:     start,
+		seqs:      make(chan uint64, size),
+		refill:    make(chan struct{}, 1),
+		threshold: threshold,
+		fetch:     fetch,
+		next:      start,
+	}
+	p.fill()
+
+	return p
+}
+
+// Acquire returns the next sequence to use, preferring released sequences
+func (p *SequencePool) Acquire() uint64 {
+	p.mu.Lock()
+	if len(p.released) > 0 {
+		seq := p.released[0]
+		p.released = p.released[1:]
+		p.mu.Unlock()
+		return seq
+	}
+	p.mu.Unlock()
+
+	select {
+	case seq := <-p.seqs:
+		if len(p.seqs) < p.threshold {
+			select {
+			case p.refill <- struct{}{}:
+			default:
+			}
+		}
+		return seq
+	default:
+		// The pool ran dry before being refilled, allocate the sequence directly
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		seq := p.next
+		p.next++
+		return seq
+	}
+}
+
+// Release returns the sequence of a transaction that failed, so it is handed out again.
+// It triggers a resync, as the transaction may have been included on chain anyway, e.g. after a client-side timeout.
+func (p *SequencePool) Release(seq uint64) {
+	p.mu.Lock()
+	i, found := slices.BinarySearch(p.released, seq)
+	if !found {
+		p.released = slices.Insert(p.released, i, seq)
+	}
+	p.mu.Unlock()
+
+	select {
+	case p.refill <- struct{}{}:
+	default:
+	}
+}
+
+// Run refills the pool when it runs low and resyncs it when a sequence is released, until the context is cancelled
+func (p *SequencePool) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.refill:
+		}
+
+		p.fill()
+
+		if p.fetch == nil {
+			continue
+		}
+
+		chainSeq, err := p.fetch(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.Warn("⚠️ Failed to fetch account sequence to resync the sequence pool", "error", err)
+			}
+			continue
+		}
+
+		if p.resync(chainSeq) {
+			logger.Debug("🔄 Resynced sequence pool to on-chain sequence", "sequence", chainSeq)
+			p.fill()
+		}
+	}
+}
+
+// fill pre-allocates sequences until the pool is full
+func (p *SequencePool) fill() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for len(p.seqs) < cap(p.seqs) {
+		p.seqs <- p.next
+		p.next++
+	}
+}
+
+// resync drops the released and pre-allocated sequences already used on chain.
+// It returns true when the chain was ahead of a sequence the pool would have handed out.
+func (p *SequencePool) resync(chainSeq uint64) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	released := len(p.released)
+	p.released = slices.DeleteFunc(p.released, func(seq uint64) bool {
+		return seq < chainSeq
+	})
+	resynced := len(p.released) < released
+
+	if chainSeq > p.next {
+		p.next = chainSeq
+		resynced = true
+	}
+
+	// Rotate the pre-allocated sequences, keeping their order, to drop those below the chain sequence
+	for range len(p.seqs) {
+		select {
+		case seq := <-p.seqs:
+			if seq < chainSeq {
+				resynced = true
+				continue
+			}
+			p.seqs <- seq
+		default:
+		}
+	}
+
+	return resynced
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestSequencePoolRefillTrigger(t *testing.T) {
+	tests := []struct {
+		name          string
+		acquire       int
+		expectRefill  bool
+		expectPending int
+	}{
+		{
+			name:          "above threshold",
+			acquire:       6,
+			expectRefill:  false,
+			expectPending: 4,
+		},
+		{
+			name:          "at threshold",
+			acquire:       7,
+			expectRefill:  false,
+			expectPending: 3,
+		},
+		{
+			name:          "below threshold",
+			acquire:       8,
+			expectRefill:  true,
+			expectPending: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := NewSequencePool(5, 10, 3, nil)
+			assert.Equal(t, len(pool.seqs), 10)
+
+			for i := range tt.acquire {
+				assert.Equal(t, pool.Acquire(), uint64(5+i))
+			}
+
+			assert.Equal(t, len(pool.seqs), tt.expectPending)
+			assert.Equal(t, len(pool.refill) == 1, tt.expectRefill)
+		})
+	}
+}
+
+func TestSequencePoolRefill(t *testing.T) {
+	pool := NewSequencePool(0, 4, 2, nil)
+	for range 3 {
+		pool.Acquire()
+	}
+	assert.Equal(t, len(pool.refill), 1)
+
+	pool.fill()
+	assert.Equal(t, len(pool.seqs), 4)
+
+	// Refilled sequences follow the pre-allocated ones
+	for i := range 5 {
+		assert.Equal(t, pool.Acquire(), uint64(3+i))
+	}
+}
+
+func TestSequencePoolDrained(t *testing.T) {
+	pool := NewSequencePool(0, 2, 1, nil)
+
+	// Sequences are allocated directly once the pool is empty
+	for i := range 4 {
+		assert.Equal(t, pool.Acquire(), uint64(i))
+	}
+
+	pool.fill()
+	assert.Equal(t, pool.Acquire(), uint64(4))
+}
+
+func TestSequencePoolRelease(t *testing.T) {
+	pool := NewSequencePool(0, 10, 3, nil)
+	for range 4 {
+		pool.Acquire()
+	}
+
+	pool.Release(2)
+	pool.Release(1)
+	pool.Release(2)
+
+	// Released sequences are handed out first, lowest first
+	assert.Equal(t, pool.Acquire(), uint64(1))
+	assert.Equal(t, pool.Acquire(), uint64(2))
+	assert.Equal(t, pool.Acquire(), uint64(4))
+}
+
+func TestSequencePoolResync(t *testing.T) {
+	tests := []struct {
+		name         string
+		chainSeq     uint64
+		expectResync bool
+		expectNext   []uint64
+	}{
+		{
+			name:         "chain behind the pool",
+			chainSeq:     1,
+			expectResync: false,
+			expectNext:   []uint64{1, 4, 5},
+		},
+		{
+			name:         "chain ahead of a released sequence",
+			chainSeq:     2,
+			expectResync: true,
+			expectNext:   []uint64{4, 5, 6},
+		},
+		{
+			name:         "chain ahead of a pre-allocated sequence",
+			chainSeq:     5,
+			expectResync: true,
+			expectNext:   []uint64{5, 6, 7},
+		},
+		{
+			name:         "chain ahead of the pool",
+			chainSeq:     20,
+			expectResync: true,
+			expectNext:   []uint64{20, 21, 22},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := NewSequencePool(0, 5, 2, nil)
+			for range 4 {
+				pool.Acquire()
+			}
+			pool.Release(1)
+
+			assert.Equal(t, pool.resync(tt.chainSeq), tt.expectResync)
+			pool.fill()
+
+			for _, seq := range tt.expectNext {
+				assert.Equal(t, pool.Acquire(), seq)
+			}
+		})
+	}
+}
+
+func TestSequencePoolRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fetched := make(chan struct{}, 1)
+	fetch := func(context.Context) (uint64, error) {
+		defer func() { fetched <- struct{}{} }()
+		return 0, errors.New("account not found")
+	}
+
+	pool := NewSequencePool(0, 4, 2, fetch)
+	go pool.Run(ctx)
+
+	for range 3 {
+		pool.Acquire()
+	}
+
+	// A failed fetch keeps the pool going with its own sequences
+	<-fetched
+	for i := range 4 {
+		assert.Equal(t, pool.Acquire(), uint64(3+i))
+	}
+}
+
+func TestSequencePoolReleaseResyncs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The transaction of sequence 5 failed client-side but was included on chain
+	fetch := func(context.Context) (uint64, error) {
+		return 6, nil
+	}
+
+	pool := NewSequencePool(5, 4, 1, fetch)
+	go pool.Run(ctx)
+
+	seq := pool.Acquire()
+	assert.Equal(t, seq, uint64(5))
+	for i := 0; seq < 6 && i < 100; i++ {
+		pool.Release(seq)
+		time.Sleep(time.Millisecond)
+		seq = pool.Acquire()
+	}
+	assert.Equal(t, seq, uint64(6))
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+)
+
+const (
+	signModeDirect    = "direct"
+	signModeAminoJSON = "amino-json"
+)
+
+// parseSignMode converts a --sign-mode value to its signing mode, defaulting to direct when empty
+func parseSignMode(mode string) (signing.SignMode, error) {
+	switch mode {
+	case "", signModeDirect:
+		return signing.SignMode_SIGN_MODE_DIRECT, nil
+	case signModeAminoJSON:
+		return signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON, nil
+	default:
+		return signing.SignMode_SIGN_MODE_UNSPECIFIED, fmt.Errorf("unknown sign mode %q, must be one of: %s, %s", mode, signModeDirect, signModeAminoJSON)
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	clienttx "github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	"github.com/ignite/cli/v29/ignite/pkg/cosmosclient"
+	"gotest.tools/v3/assert"
+)
+
+func TestParseSignMode(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     string
+		expected signing.SignMode
+		wantErr  bool
+	}{
+		{name: "default", mode: "", expected: signing.SignMode_SIGN_MODE_DIRECT},
+		{name: "direct", mode: signModeDirect, expected: signing.SignMode_SIGN_MODE_DIRECT},
+		{name: "amino-json", mode: signModeAminoJSON, expected: signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON},
+		{name: "textual is not supported", mode: "textual", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mode, err := parseSignMode(tt.mode)
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "unknown sign mode")
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, mode, tt.expected)
+		})
+	}
+}
+
+// recordingSigner signs transactions like the default signer and records the sign mode and signed tx
+type recordingSigner struct {
+	modes *[]signing.SignMode
+	txs   *[]sdk.Tx
+}
+
+func (s recordingSigner) Sign(ctx context.Context, txf clienttx.Factory, name string, txBuilder client.TxBuilder, overwriteSig bool) error {
+	if err := clienttx.Sign(ctx, txf, name, txBuilder, overwriteSig); err != nil {
+		return err
+	}
+
+	*s.modes = append(*s.modes, txf.SignMode())
+	*s.txs = append(*s.txs, txBuilder.GetTx())
+	return nil
+}
+
+func TestSignModeChangesSignedTx(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	var modes []signing.SignMode
+	var txs []sdk.Tx
+
+	client, err := cosmosclient.New(context.Background(),
+		cosmosclient.WithRPCClient(newMockRPC()),
+		cosmosclient.WithAccountRetriever(mockAccountRetriever{}),
+		cosmosclient.WithSigner(recordingSigner{modes: &modes, txs: &txs}),
+		cosmosclient.WithBech32Prefix(mockBech32Prefix),
+		cosmosclient.WithKeyringDir(t.TempDir()),
+		cosmosclient.WithKeyringBackend(DefaultKeyringBackend),
+	)
+	assert.NilError(t, err)
+
+	account, _, err := client.AccountRegistry.Create("alice")
+	assert.NilError(t, err)
+
+	config := Config{Fees: "1000uatom", GasLimit: 200000, MsgsPerTx: 1}
+	amount, err := parseAmount(config.Fees)
+	assert.NilError(t, err)
+
+	for _, mode := range []string{signModeDirect, signModeAminoJSON} {
+		signMode, err := parseSignMode(mode)
+		assert.NilError(t, err)
+		client.TxFactory = client.TxFactory.WithSignMode(signMode)
+
+		err = sendTransaction(context.Background(), client, account, config, amount, "", 0, mockBech32Prefix, "sign mode test", 1)
+		assert.NilError(t, err)
+	}
+
+	assert.DeepEqual(t, modes, []signing.SignMode{
+		signing.SignMode_SIGN_MODE_DIRECT,
+		signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON,
+	})
+
+	encoder := client.Context().TxConfig.TxEncoder()
+	directBytes, err := encoder(txs[0])
+	assert.NilError(t, err)
+	aminoBytes, err := encoder(txs[1])
+	assert.NilError(t, err)
+	assert.Assert(t, string(directBytes) != string(aminoBytes))
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ignite/cli/v29/ignite/pkg/cosmosaccount"
+	"github.com/ignite/cli/v29/ignite/pkg/cosmosclient"
+)
+
+// DefaultSimulateCount is the default number of simulations of a --simulate-only run
+const DefaultSimulateCount = 10
+
+// SimulationResult is the gas used by repeated simulations of a transaction
+type SimulationResult struct {
+	Count  int
+	Mean   float64
+	StdDev float64
+	Min    uint64
+	Max    uint64
+}
+
+// newSimulationResult computes the mean and the standard deviation of the simulated gas
+func newSimulationResult(gas []uint64) SimulationResult {
+	if len(gas) == 0 {
+		return SimulationResult{}
+	}
+
+	result := SimulationResult{
+		Count: len(gas),
+		Min:   gas[0],
+		Max:   gas[0],
+	}
+
+	var sum float64
+	for _, g := range gas {
+		sum += float64(g)
+		result.Min = min(result.Min, g)
+		result.Max = max(result.Max, g)
+	}
+	result.Mean = sum / float64(len(gas))
+
+	var variance float64
+	for _, g := range gas {
+		variance += math.Pow(float64(g)-result.Mean, 2)
+	}
+	result.StdDev = math.Sqrt(variance / float64(len(gas)))
+
+	return result
+}
+
+// Print prints the simulated gas of the transaction type
+func (r SimulationResult) Print(txType string) {
+	fmt.Printf("⛽ Simulated gas for %s over %d simulations: %.0f ± %.0f (min: %d, max: %d)\n", txType, r.Count, r.Mean, r.StdDev, r.Min, r.Max)
+}
+
+// runSimulationBenchmark simulates the messages n times through the gRPC simulate endpoint, without broadcasting them
+func runSimulationBenchmark(ctx context.Context, client cosmosclient.Client, account cosmosaccount.Account, config Config, memo string, sequence uint64, msgs []sdk.Msg, n int) (SimulationResult, error) {
+	txBytes, err := buildSimTx(client, account, config, memo, sequence, msgs...)
+	if err != nil {
+		return SimulationResult{}, err
+	}
+
+	gas := make([]uint64, 0, n)
+	for i := range n {
+		gasUsed, err := simulateGas(ctx, client, txBytes)
+		if err != nil {
+			return SimulationResult{}, fmt.Errorf("simulation %d: %w", i+1, err)
+		}
+		gas = append(gas, gasUsed)
+	}
+
+	return newSimulationResult(gas), nil
+}
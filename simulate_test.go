@@ -0,0 +1,41 @@
+package main
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestNewSimulationResult(t *testing.T) {
+	tests := []struct {
+		name string
+		gas  []uint64
+		want SimulationResult
+	}{
+		{
+			name: "no simulation",
+			want: SimulationResult{},
+		},
+		{
+			name: "single simulation",
+			gas:  []uint64{80000},
+			want: SimulationResult{Count: 1, Mean: 80000, Min: 80000, Max: 80000},
+		},
+		{
+			name: "constant gas",
+			gas:  []uint64{80000, 80000, 80000},
+			want: SimulationResult{Count: 3, Mean: 80000, Min: 80000, Max: 80000},
+		},
+		{
+			name: "varying gas",
+			gas:  []uint64{2, 4, 4, 4, 5, 5, 7, 9},
+			want: SimulationResult{Count: 8, Mean: 5, StdDev: 2, Min: 2, Max: 9},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.DeepEqual(t, newSimulationResult(tt.gas), tt.want)
+		})
+	}
+}
@@ -2,219 +2,1485 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"text/template"
 	"time"
 
 	"cosmossdk.io/math"
 
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	authztypes "github.com/cosmos/cosmos-sdk/x/authz"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	distributiontypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	grouptypes "github.com/cosmos/cosmos-sdk/x/group"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/ignite/cli/v29/ignite/pkg/cosmosaccount"
 	"github.com/ignite/cli/v29/ignite/pkg/cosmosclient"
+	"github.com/julienrbrt/spamtx/chainregistry"
 )
 
-// getChainInfo fetches chain information from the registry
+// ChainInfo is the chain information resolved from the registry
+type ChainInfo struct {
+	Chain        string `json:"chain"`
+	ChainID      string `json:"chain_id"`
+	RPC          string `json:"rpc"`
+	Bech32Prefix string `json:"bech32_prefix"`
+}
+
+// getChainInfo fetches the RPC endpoint and the bech32 prefix of a chain from the registry
 func getChainInfo(chainName string) (string, string, error) {
-	registry := NewChainRegistry()
-	if err := registry.FetchChains(); err != nil {
-		return "", "", fmt.Errorf("failed to fetch chains: %w", err)
+	info, err := resolveChainInfo(chainName)
+	if err != nil {
+		return "", "", err
+	}
+
+	return info.RPC, info.Bech32Prefix, nil
+}
+
+// resolveChainInfo fetches chain information from the registry
+func resolveChainInfo(chainName string) (ChainInfo, error) {
+	registry, err := loadChainRegistry()
+	if err != nil {
+		return ChainInfo{}, fmt.Errorf("failed to fetch chains: %w", err)
+	}
+
+	chain, err := registry.Get(chainName)
+	if err != nil {
+		return ChainInfo{}, err
+	}
+
+	// Enrich the chain to get full details
+	if err := registry.Enrich(&chain); err != nil {
+		return ChainInfo{}, fmt.Errorf("failed to enrich chain '%s': %w", chainName, err)
+	}
+
+	// Get RPC endpoint
+	if len(chain.APIs.RPC) == 0 {
+		return ChainInfo{}, fmt.Errorf("no RPC endpoints found for chain '%s'", chainName)
+	}
+
+	// Get bech32 prefix
+	if chain.Bech32Prefix == "" {
+		return ChainInfo{}, fmt.Errorf("no bech32 prefix found for chain '%s'", chainName)
+	}
+
+	return ChainInfo{
+		Chain:        chainName,
+		ChainID:      chain.ChainID,
+		RPC:          chain.APIs.RPC[0].Address,
+		Bech32Prefix: chain.Bech32Prefix,
+	}, nil
+}
+
+// printChainInfo prints the chain information resolved from the registry as JSON
+func printChainInfo(w io.Writer, chainName string) error {
+	info, err := resolveChainInfo(chainName)
+	if err != nil {
+		return err
+	}
+
+	bz, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode chain info: %w", err)
+	}
+
+	_, err = fmt.Fprintln(w, string(bz))
+	return err
+}
+
+// chainBech32Prefix returns the bech32 prefix set with --chain-prefix, or the one of the chain registry.
+// It is only used with a custom endpoint, as the chain registry is otherwise needed for the RPC endpoint.
+func chainBech32Prefix(config Config) (string, error) {
+	if config.Bech32Prefix != "" {
+		logger.Debug("🏷️ Using custom bech32 prefix, skipping the chain registry", "prefix", config.Bech32Prefix)
+		return config.Bech32Prefix, nil
+	}
+
+	_, bech32Prefix, err := getChainInfo(config.Chain)
+	if err != nil {
+		if errors.Is(err, chainregistry.ErrChainNotFound) {
+			return "", fmt.Errorf("%w, set --%s for chains missing from the chain registry", err, flagChainPrefix)
+		}
+		return "", fmt.Errorf("failed to get chain info for bech32 prefix: %w", err)
+	}
+
+	return bech32Prefix, nil
+}
+
+// spamTransactions starts the transaction spamming process
+func spamTransactions(ctx context.Context, config Config) error {
+	if len(config.Chains) > 0 {
+		return spamChains(ctx, config)
+	}
+
+	session, err := prepareSpam(ctx, config)
+	if err != nil {
+		return err
+	}
+	defer session.Close()
+
+	// Only simulate a transaction of the configured type, without broadcasting it
+	if config.SimulateOnly {
+		logger.Info("⛽ Simulating transactions without broadcasting", "type", config.TxType, "count", config.SimulateCount)
+		return session.send(ctx, 0, session.pool.Acquire())
+	}
+
+	// Expose Prometheus metrics for the duration of the run
+	var metrics *spamMetrics
+	if config.MetricsAddr != "" {
+		metrics = newSpamMetrics()
+
+		addr, err := startMetricsServer(ctx, config.MetricsAddr, metrics)
+		if err != nil {
+			return err
+		}
+		logger.Info("📈 Serving Prometheus metrics", "url", fmt.Sprintf("http://%s/metrics", addr))
+	}
+
+	// Expose the runtime profiles for the duration of the run
+	if config.PprofAddr != "" {
+		addr, err := startPprofServer(ctx, config.PprofAddr)
+		if err != nil {
+			return err
+		}
+		logger.Info("🩺 Serving pprof profiles", "url", fmt.Sprintf("http://%s/debug/pprof/", addr))
+	}
+
+	// Record the failed transactions for post-mortem analysis
+	send := session.send
+	if config.ErrorFile != "" {
+		var errorLog ErrorLog
+		if err := errorLog.Open(config.ErrorFile); err != nil {
+			return err
+		}
+		defer func() {
+			if err := errorLog.Close(); err != nil {
+				logger.Warn("⚠️ Failed to close error file", "error", err)
+			}
+		}()
+		send = logErrors(&errorLog, send)
+		logger.Info("📝 Recording failed transactions", "file", config.ErrorFile)
+	}
+
+	// Count the failed transactions to fail the run if any
+	var failed atomic.Uint64
+	if config.RequireZeroErrors {
+		send = countErrors(&failed, send)
+	}
+
+	// Persist the last used sequence for the next run
+	if config.NonceFile != "" {
+		send = persistNonce(NewNonceStore(config.NonceFile), send)
+		logger.Info("💾 Persisting the last used sequence", "file", config.NonceFile)
+	}
+
+	// Stop once the chain reaches the stop height
+	if config.StopAtHeight > 0 {
+		reached, latest, err := heightReached(ctx, session.client, config.StopAtHeight)
+		if err != nil {
+			return fmt.Errorf("failed to fetch node status: %w", err)
+		}
+		if reached {
+			return fmt.Errorf("stop height %d already reached (current height: %d)", config.StopAtHeight, latest)
+		}
+
+		var stop context.CancelFunc
+		ctx, stop = context.WithCancel(ctx)
+		defer stop()
+		send = stopAtHeight(session.client, config.StopAtHeight, config.HeightPollInterval, send, stop)
+		logger.Info("🛑 Stopping at block height", "height", config.StopAtHeight, "current", latest)
+	}
+
+	// Watch the account balance during the run
+	var balanceCtx context.Context
+	if config.CheckBalanceInterval > 0 {
+		var minBalance, stopBalance sdk.Coins
+		if config.MinBalance != "" {
+			if minBalance, err = parseAmount(config.MinBalance); err != nil {
+				return fmt.Errorf("failed to parse minimum balance: %w", err)
+			}
+		}
+		if config.StopBalance != "" {
+			if stopBalance, err = parseAmount(config.StopBalance); err != nil {
+				return fmt.Errorf("failed to parse stop balance: %w", err)
+			}
+		}
+
+		var stop context.CancelCauseFunc
+		ctx, stop = context.WithCancelCause(ctx)
+		defer stop(nil)
+		balanceCtx = ctx
+
+		monitor := newBalanceMonitor(bankBalanceFetcher(session.client, session.accountAddr))
+		go monitor.Run(ctx)
+		send = checkBalanceEvery(monitor, config.CheckBalanceInterval, minBalance, stopBalance, send, stop)
+		logger.Info("💰 Checking the account balance", "every", config.CheckBalanceInterval, "min_balance", minBalance, "stop_balance", stopBalance)
+	}
+
+	config.mempoolChecker = session.mempoolChecker
+	config.latencyHistogram = session.latencyHistogram
+
+	// Throttle the rate when block production stalls
+	if config.BlockThrottle {
+		config.blockMonitor = newBlockTimeMonitor(latestBlockTimeFetcher(session.client), config.BlockTimeEstimate)
+		go config.blockMonitor.Run(ctx)
+		logger.Info("⏱️ Watching the block production", "block_time_estimate", config.BlockTimeEstimate)
+	}
+
+	// Log the inclusion of the transactions of the account as the node reports them
+	if config.EventLog {
+		eventCtx, stopEvents := context.WithCancel(ctx)
+		defer stopEvents()
+
+		wsURL := eventWebsocketURL(session.rpcEndpoint)
+		events, err := NewEventSubscriber().Subscribe(eventCtx, wsURL, senderTxQuery(session.accountAddr))
+		if err != nil {
+			return err
+		}
+		go logEvents(events)
+		logger.Info("📨 Subscribed to the transaction events of the account", "url", wsURL)
+	}
+
+	// Print the latency percentiles during the run
+	if config.latencyHistogram != nil && config.HistogramInterval > 0 {
+		go config.latencyHistogram.Run(ctx, config.HistogramInterval)
+	}
+
+	logger.Info("🚀 Sending transactions", "tps", config.TPS, "concurrent", concurrency(config), "target_tps", targetTPS(config))
+
+	if err := runSpamLoop(ctx, config, session.pool, send, metrics); err != nil {
+		return err
+	}
+
+	// Report the run stopped by the balance check as failed
+	if balanceCtx != nil {
+		if cause := context.Cause(balanceCtx); errors.Is(cause, errStopBalance) {
+			return cause
+		}
+	}
+
+	if n := failed.Load(); n > 0 {
+		return fmt.Errorf("%d transactions failed (--%s)", n, flagRequireZeroErrors)
+	}
+
+	return nil
+}
+
+// spamSession is a connection to the chain sending transactions of the configured type
+type spamSession struct {
+	client cosmosclient.Client
+	// rpcEndpoint is the RPC endpoint of the client, the first one of the RPC pool
+	rpcEndpoint string
+	// accountAddr is the address of the sending account
+	accountAddr string
+	// send sends a transaction of the configured type
+	send sendFunc
+	// pool hands out the account sequences to use
+	pool *SequencePool
+	// mempoolChecker counts the transactions entering the mempool, nil unless WatchMempool is set
+	mempoolChecker *mempoolChecker
+	// latencyHistogram records the broadcast latencies, nil unless Histogram is set
+	latencyHistogram *latencyHistogram
+	// cleanups release the connections, in reverse order
+	cleanups []func()
+}
+
+// Close releases the connections of the session
+func (s *spamSession) Close() {
+	for i := len(s.cleanups) - 1; i >= 0; i-- {
+		s.cleanups[i]()
+	}
+}
+
+// prepareSpam connects to the chain and prepares a session sending transactions of the configured type.
+// The session must be closed once done sending.
+func prepareSpam(ctx context.Context, config Config) (_ *spamSession, err error) {
+	session := &spamSession{}
+	defer func() {
+		if err != nil {
+			session.Close()
+		}
+	}()
+
+	rpcEndpoint, bech32Prefix, rpcEndpoints, err := resolveChainEndpoint(config)
+	if err != nil {
+		return nil, err
+	}
+
+	// Export a span per transaction to an OpenTelemetry collector
+	if config.OTELEndpoint != "" {
+		tp, err := initTracer(ctx, config.OTELEndpoint)
+		if err != nil {
+			return nil, err
+		}
+		session.cleanups = append(session.cleanups, func() {
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := tp.Shutdown(shutdownCtx); err != nil {
+				logger.Warn("⚠️ Failed to flush OpenTelemetry traces", "error", err)
+			}
+		})
+		logger.Info("📡 Exporting traces to OpenTelemetry collector", "endpoint", config.OTELEndpoint)
+	}
+
+	// Compute the fees from the transfer amount when a fee percentage is set
+	if config.FeePct > 0 {
+		amount, err := parseAmount(config.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse amount: %w", err)
+		}
+
+		if config.Fees, err = computeFeesFromPct(amount, config.FeePct); err != nil {
+			return nil, err
+		}
+		logger.Info("💸 Using fees from the fee percentage", "fees", config.Fees, "pct", config.FeePct, "amount", amount)
+	}
+
+	// Only pay the fees in a single denom, for fee checkers rejecting multi-denom fees
+	if config.FeeDenom != "" {
+		fees, err := sdk.ParseCoinsNormalized(config.Fees)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse fees: %w", err)
+		}
+
+		filtered, err := filterFeeByDenom(fees, config.FeeDenom)
+		if err != nil {
+			return nil, err
+		}
+		config.Fees = filtered.String()
+		logger.Info("💸 Using fees in a single denom", "fees", config.Fees)
+	}
+
+	keyringBackend, err := resolveKeyringBackend(config.KeyringBackend)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get keyring home directory
+	keyringDir, err := getKeyringHome()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get keyring home: %w", err)
+	}
+
+	// Initialize cosmos client with configuration
+	options := []cosmosclient.Option{
+		cosmosclient.WithNodeAddress(rpcEndpoint),
+		cosmosclient.WithFees(config.Fees),
+		cosmosclient.WithBech32Prefix(bech32Prefix),
+		cosmosclient.WithKeyringDir(keyringDir),
+		cosmosclient.WithKeyringBackend(clientKeyringBackend(keyringBackend)),
+		cosmosclient.WithKeyringServiceName(DefaultKeyringServiceName),
+	}
+
+	// Have the fees paid by a fee granter through x/feegrant
+	if config.FeeGranter != "" {
+		granter, err := parseFeeGranter(config.FeeGranter, bech32Prefix)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, cosmosclient.WithSigner(feeGranterSigner{granter: granter}))
+		logger.Info("🎁 Fees paid by fee granter", "granter", config.FeeGranter)
+	}
+
+	// Execute the messages on behalf of an authz granter
+	if config.AuthzGranter != "" {
+		if _, err := parseAuthzGranter(config.AuthzGranter, bech32Prefix); err != nil {
+			return nil, err
+		}
+		logger.Info("🔐 Executing messages on behalf of authz granter", "granter", config.AuthzGranter)
+	}
+
+	// With a gRPC endpoint, queries and broadcasts bypass the CometBFT RPC
+	var rpc rpcclient.Client
+	if config.GRPC != "" && !config.MockMode {
+		grpcClient, err := newGRPCRPC(config.GRPC)
+		if err != nil {
+			return nil, err
+		}
+		session.cleanups = append(session.cleanups, func() {
+			_ = grpcClient.Close()
+		})
+		rpc = grpcClient
+	}
+
+	// Skip the TLS certificate verification of the RPC endpoint
+	if config.InsecureSkipTLS && rpc == nil && !config.MockMode {
+		warnInsecureSkipTLS()
+
+		if rpc, err = newInsecureRPC(rpcEndpoint); err != nil {
+			return nil, err
+		}
+	}
+
+	// In mock mode, the client talks to an in-process fake node.
+	// In dry-run mode, transactions are signed but never reach the node.
+	if config.MockMode {
+		options = append(options,
+			cosmosclient.WithRPCClient(newMockRPC()),
+			cosmosclient.WithAccountRetriever(mockAccountRetriever{}),
+		)
+	} else if config.DryRun {
+		logger.Info("🧪 Dry-run mode: transactions will be built and signed but not broadcasted")
+
+		if rpc == nil {
+			if rpc, err = rpchttp.New(rpcEndpoint, "/websocket"); err != nil {
+				return nil, fmt.Errorf("failed to create RPC client: %w", err)
+			}
+		}
+
+		options = append(options,
+			cosmosclient.WithRPCClient(dryRunRPC{Client: rpc}),
+			cosmosclient.WithAccountRetriever(dryRunAccountRetriever{AccountRetriever: authtypes.AccountRetriever{}}),
+		)
+	} else if rpc != nil {
+		options = append(options, cosmosclient.WithRPCClient(rpc))
+	}
+
+	client, err := newSpamClient(ctx, config, options...)
+	if err != nil {
+		return nil, err
+	}
+	if config.ChainID != "" {
+		logger.Info("⛓️ Using chain-id", "chain_id", config.ChainID)
+	}
+
+	// Wait for a catching up node, whose account state would be stale
+	if config.WaitSync {
+		if err := waitForSync(ctx, client, syncPollInterval, config.WaitSyncTimeout); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.MinNodeVersion != "" {
+		if err := checkNodeVersion(ctx, client, config.MinNodeVersion, config.NodeVersionStrict); err != nil {
+			return nil, err
+		}
+	}
+
+	// Spread the transactions across the RPC pool, with one client per endpoint
+	nextClient := func() cosmosclient.Client {
+		return client
+	}
+	if len(rpcEndpoints) > 1 {
+		clients := map[string]cosmosclient.Client{rpcEndpoint: client}
+		for _, endpoint := range rpcEndpoints[1:] {
+			endpointOptions := append(slices.Clone(options), cosmosclient.WithNodeAddress(endpoint))
+			if config.InsecureSkipTLS {
+				rpc, err := newInsecureRPC(endpoint)
+				if err != nil {
+					return nil, err
+				}
+				endpointOptions = append(endpointOptions, cosmosclient.WithRPCClient(rpc))
+			}
+
+			if clients[endpoint], err = newSpamClient(ctx, config, endpointOptions...); err != nil {
+				return nil, fmt.Errorf("failed to connect to RPC endpoint %s: %w", endpoint, err)
+			}
+		}
+
+		rpcPool := NewRPCPool(rpcEndpoints, checkRPCHealth(newHealthClient(config.InsecureSkipTLS)))
+		poolCtx, cancelPool := context.WithCancel(context.Background())
+		session.cleanups = append(session.cleanups, cancelPool)
+		go rpcPool.Run(poolCtx, rpcPoolCheckInterval)
+
+		nextClient = func() cosmosclient.Client {
+			endpoint := rpcPool.Next()
+			logger.Debug("🔀 Selected RPC endpoint", "endpoint", endpoint)
+			return clients[endpoint]
+		}
+	}
+
+	// Get account from cosmos client's keyring
+	account, err := client.Account(config.Account)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account '%s' from cosmos client keyring: %w", config.Account, err)
+	}
+
+	// Get account address for verification
+	accountAddr, err := account.Address(bech32Prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account '%s' from keyring: %w", config.Account, err)
+	}
+
+	// Check if account exists on the blockchain (not needed in dry-run or mock mode)
+	if !config.DryRun && !config.MockMode {
+		if err := verifyAccountExists(ctx, client, accountAddr); err != nil {
+			return nil, fmt.Errorf("account verification failed: %w", err)
+		}
+	}
+
+	// Refuse to start with a balance below the minimum balance
+	if config.MinBalance != "" {
+		minBalance, err := parseAmount(config.MinBalance)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse minimum balance: %w", err)
+		}
+		if err := checkBalance(ctx, client, accountAddr, minBalance); err != nil {
+			return nil, err
+		}
+	}
+
+	// Resume from the sequence persisted by a recent run, which may be ahead of a lagging node
+	var persistedSeq uint64
+	var persisted bool
+	if config.NonceFile != "" && !config.SequenceOverride {
+		if persistedSeq, persisted, err = NewNonceStore(config.NonceFile).Load(config.NonceMaxAge); err != nil {
+			return nil, err
+		}
+	}
+
+	// Fetch and display current account sequence (always 0 in mock mode), unless set explicitly
+	var sequence uint64
+	if config.SequenceOverride {
+		sequence = config.StartSequence
+	} else if persisted {
+		sequence = persistedSeq + 1
+		logger.Info("💾 Resuming from the persisted sequence", "sequence", sequence, "file", config.NonceFile)
+	} else if !config.MockMode {
+		sequence, err = fetchAccountSequence(ctx, client, accountAddr)
+		if err != nil {
+			if !config.DryRun {
+				return nil, fmt.Errorf("failed to fetch account sequence: %w", err)
+			}
+			logger.Warn("⚠️ Account not found on chain, using sequence 0 for dry-run", "account", accountAddr)
+		}
+	}
+	logger.Debug("📊 Current account sequence", "sequence", sequence)
+
+	// Check that the block gas limit can absorb the configured rate
+	if config.ConsensusCheck {
+		if err := checkConsensusParams(ctx, client, config); err != nil {
+			return nil, fmt.Errorf("consensus params check failed: %w", err)
+		}
+	}
+
+	// Show the theoretical throughput allowed by the block gas limit
+	logBlockGasTarget(ctx, client, config)
+
+	amount, err := transferAmount(config)
+	if err != nil {
+		return nil, err
+	}
+
+	var memoTmpl *template.Template
+	if config.MemoTemplate != "" {
+		if memoTmpl, err = parseMemoTemplate(config.MemoTemplate); err != nil {
+			return nil, err
+		}
+	}
+
+	var memos []string
+	if config.MemoFile != "" {
+		if memos, err = loadMemoFile(config.MemoFile); err != nil {
+			return nil, err
+		}
+		for i, memo := range memos {
+			if err := checkMemoLength(decorateMemo(config.TxNote, memo), config.MemoMaxLen); err != nil {
+				return nil, fmt.Errorf("line %d of memo file %s: %w", i+1, config.MemoFile, err)
+			}
+		}
+		logger.Info("📝 Cycling through memos", "memos", len(memos), "file", config.MemoFile)
+	}
+
+	var addressPool *AddressPool
+	if config.AddressPoolFile != "" {
+		if addressPool, err = loadAddressPool(config.AddressPoolFile, bech32Prefix); err != nil {
+			return nil, err
+		}
+		logger.Info("📬 Cycling through recipient addresses", "addresses", addressPool.Len(), "file", config.AddressPoolFile)
+	}
+
+	var nextRecipient func(n uint64) string
+	if config.RecipientGen {
+		nextRecipient = recipientGenerator(config.RecipientGenSeed, bech32Prefix)
+		logger.Info("📬 Generating recipient addresses", "seed", config.RecipientGenSeed)
+	}
+
+	// Drain the account, sending the balance left once the fees are paid
+	var sendAllFees sdk.Coins
+	if config.SendAll {
+		if sendAllFees, err = parseAmount(config.Fees); err != nil {
+			return nil, fmt.Errorf("failed to parse fees: %w", err)
+		}
+		logger.Info("🚰 Sending the whole balance minus the fees", "fees", sendAllFees)
+	}
+
+	// Register the messages of the modules spamtx sends transactions to
+	registerMsgInterfaces(client.Context().InterfaceRegistry)
+
+	// Resolve the group policy and proposal message once for group proposals
+	var groupPolicyAddress string
+	var groupProposalMsg sdk.Msg
+	if config.sendsTxType(txTypeGroupSubmitProposal) {
+		if groupProposalMsg, err = loadGroupProposalMessage(client.Context().Codec, config.GroupMessageJSON); err != nil {
+			return nil, err
+		}
+
+		if groupPolicyAddress, err = fetchGroupPolicyAddress(ctx, client, config.GroupID); err != nil {
+			return nil, err
+		}
+		logger.Info("👥 Submitting proposals to group", "group", config.GroupID, "policy", groupPolicyAddress)
+	}
+
+	// Resolve the withdraw addresses to cycle through for withdraw address updates
+	var withdrawAddresses []string
+	if config.sendsTxType(txTypeSetWithdrawAddress) {
+		if config.WithdrawAddress != "" {
+			if err := validateWithdrawAddress(config.WithdrawAddress, bech32Prefix); err != nil {
+				return nil, err
+			}
+			withdrawAddresses = []string{config.WithdrawAddress}
+		} else {
+			sdkAddr, err := account.Record.GetAddress()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get account address: %w", err)
+			}
+			if withdrawAddresses, err = deriveWithdrawAddresses(sdkAddr, bech32Prefix, withdrawAddressCount); err != nil {
+				return nil, err
+			}
+		}
+		logger.Info("🏦 Cycling through withdraw addresses", "addresses", len(withdrawAddresses))
+	}
+
+	// Resolve the vote option once for governance votes
+	var voteOption govv1.VoteOption
+	if config.sendsTxType(txTypeGovVote) {
+		if voteOption, err = parseVoteOption(config.VoteOption); err != nil {
+			return nil, err
+		}
+		logger.Info("🗳️ Voting on proposal", "option", config.VoteOption, "proposal", config.ProposalID)
+	}
+
+	// Resolve the amount to undelegate or redelegate once for staking transactions
+	var stakeAmount sdk.Coin
+	if config.sendsTxType(txTypeStakingUndelegate) || config.sendsTxType(txTypeStakingRedelegate) {
+		if stakeAmount, err = stakingAmount(config.Amount); err != nil {
+			return nil, err
+		}
+		for _, validator := range []string{config.Validator, config.ValidatorSrc, config.ValidatorDst} {
+			if validator == "" {
+				continue
+			}
+			if err := checkValidatorPrefix(validator, bech32Prefix); err != nil {
+				return nil, err
+			}
+		}
+
+		if config.sendsTxType(txTypeStakingUndelegate) {
+			logger.Info("🥩 Undelegating from validator", "validator", config.Validator, "amount", stakeAmount)
+		}
+		if config.sendsTxType(txTypeStakingRedelegate) {
+			logger.Info("🥩 Redelegating between validators", "validator_src", config.ValidatorSrc, "validator_dst", config.ValidatorDst, "amount", stakeAmount)
+		}
+	}
+
+	// Check the grantee once for authz grants
+	if config.sendsTxType(txTypeAuthzGrant) {
+		if err := checkGrantee(config.Grantee, msgSender(config, accountAddr), bech32Prefix); err != nil {
+			return nil, err
+		}
+		logger.Info("🔑 Granting authorizations", "grantee", config.Grantee, "msg_type", config.AuthzMsgType, "expiry", config.GrantExpiry)
+	}
+
+	// Decode the extra message appended to every transaction
+	if config.ExtraMsg != "" {
+		if config.extraMsg, err = parseExtraMsg(client.Context().Codec, config.ExtraMsg); err != nil {
+			return nil, err
+		}
+		logger.Info("📎 Attaching an extra message to every transaction", "type", sdk.MsgTypeURL(config.extraMsg))
+	}
+
+	// Simulate the gas of the first transaction, reused for the rest of the run
+	if config.GasLimitAuto {
+		config.gasEstimator = newGasEstimator(config.GasAdjustment, config.GasResimInterval)
+	}
+
+	// Track the transactions dropped before entering the mempool
+	if config.WatchMempool {
+		config.mempoolChecker = &mempoolChecker{}
+		session.mempoolChecker = config.mempoolChecker
+		logger.Info("🧺 Watching the mempool for the broadcasted transactions")
+	}
+
+	// Record the broadcast latencies for the percentile table
+	if config.Histogram {
+		config.latencyHistogram = newLatencyHistogram(latencyWindowSize)
+		session.latencyHistogram = config.latencyHistogram
+	}
+
+	// Pick the transaction type of each transaction from the weight map
+	var selector *WeightedSelector
+	if len(config.WeightMap) > 0 {
+		seed := config.RandomSeed
+		if seed == 0 {
+			seed = time.Now().UnixNano()
+		}
+		selector = NewWeightedSelector(config.WeightMap, seed)
+		logger.Info("🎲 Mixing transaction types", "weights", weightMapValue{weights: &config.WeightMap}.String(), "seed", seed)
+	}
+
+	send := func(ctx context.Context, txNum, sequence uint64) error {
+		client := nextClient()
+
+		// The transaction is sent with the configuration of the selected transaction type
+		config := config
+		if selector != nil {
+			config.TxType = selector.Select()
+		}
+
+		memo := config.Memo
+		if memos != nil {
+			memo = memos[txNum%uint64(len(memos))]
+		}
+		if memoTmpl != nil {
+			rendered, err := renderMemo(memoTmpl, MemoData{
+				TxNum:     txNum,
+				Timestamp: time.Now(),
+				Account:   accountAddr,
+			})
+			if err != nil {
+				return err
+			}
+			memo = rendered
+		} else if config.NoteCounter {
+			memo = appendMemoCounter(memo, txNum)
+		}
+		memo = decorateMemo(config.TxNote, memo)
+
+		// Rendered memos vary in length, reject them before the chain does
+		if err := checkMemoLength(memo, config.MemoMaxLen); err != nil {
+			return err
+		}
+
+		if config.TxType == txTypeGroupSubmitProposal {
+			return sendGroupSubmitProposalTransaction(
+				ctx,
+				client,
+				account,
+				config,
+				groupPolicyAddress,
+				groupProposalMsg,
+				txNum,
+				bech32Prefix,
+				memo,
+				sequence,
+			)
+		}
+
+		if config.TxType == txTypeSetWithdrawAddress {
+			return sendSetWithdrawAddressTransaction(
+				ctx,
+				client,
+				account,
+				config,
+				withdrawAddresses[txNum%uint64(len(withdrawAddresses))],
+				txNum,
+				bech32Prefix,
+				memo,
+				sequence,
+			)
+		}
+
+		if config.TxType == txTypeGovVote {
+			return sendGovVoteTransaction(
+				ctx,
+				client,
+				account,
+				config,
+				voteOption,
+				txNum,
+				bech32Prefix,
+				memo,
+				sequence,
+			)
+		}
+
+		if config.TxType == txTypeStakingUndelegate {
+			return sendStakingUndelegateTransaction(
+				ctx,
+				client,
+				account,
+				config,
+				stakeAmount,
+				txNum,
+				bech32Prefix,
+				memo,
+				sequence,
+			)
+		}
+
+		if config.TxType == txTypeStakingRedelegate {
+			return sendStakingRedelegateTransaction(
+				ctx,
+				client,
+				account,
+				config,
+				stakeAmount,
+				txNum,
+				bech32Prefix,
+				memo,
+				sequence,
+			)
+		}
+
+		if config.TxType == txTypeAuthzGrant {
+			return sendAuthzGrantTransaction(
+				ctx,
+				client,
+				account,
+				config,
+				txNum,
+				bech32Prefix,
+				memo,
+				sequence,
+			)
+		}
+
+		if config.Heavy {
+			return sendHeavyTransaction(
+				ctx,
+				client,
+				account,
+				config,
+				amount,
+				txNum,
+				bech32Prefix,
+				memo,
+				sequence,
+			)
+		}
+
+		// Send to self, to the next address of the pool or to a generated address
+		var recipient string
+		switch {
+		case addressPool != nil:
+			recipient = addressPool.Next()
+		case nextRecipient != nil:
+			recipient = nextRecipient(txNum)
+		}
+
+		amount := amount
+		if config.SendAll {
+			balance, err := client.BankBalances(ctx, msgSender(config, accountAddr), nil)
+			if err != nil {
+				return fmt.Errorf("failed to query account balance: %w", err)
+			}
+			if amount, err = computeSendAllAmount(balance, sendAllFees); err != nil {
+				logger.Warn("⚠️ Skipping transaction, nothing left to send", "tx", txNum, "error", err)
+				return errSkipTx
+			}
+		}
+
+		return sendTransaction(
+			ctx,
+			client,
+			account,
+			config,
+			amount,
+			recipient,
+			txNum,
+			bech32Prefix,
+			memo,
+			sequence,
+		)
+	}
+
+	// Pre-allocate sequences, resyncing with the chain unless no account is queried
+	var fetch sequenceFetcher
+	if !config.DryRun && !config.MockMode {
+		fetch = func(ctx context.Context) (uint64, error) {
+			return fetchAccountSequence(ctx, client, accountAddr)
+		}
+	}
+	pool := NewSequencePool(sequence, sequencePoolSize, sequencePoolThreshold, fetch)
+
+	// Wait for the start height, so that coordinated instances start at the same block
+	if config.StartAfter > 0 {
+		if err := waitForHeight(ctx, client, config.StartAfter, startAfterPollInterval); err != nil {
+			return nil, err
+		}
+	}
+
+	session.client = client
+	session.rpcEndpoint = rpcEndpoint
+	session.accountAddr = accountAddr
+	session.send = send
+	session.pool = pool
+
+	return session, nil
+}
+
+// resolveChainEndpoint returns the RPC endpoint (the gRPC one with --grpc-addr) and the bech32 prefix of the chain,
+// along with the endpoints of the RPC pool when set, the first of which is returned as the RPC endpoint
+func resolveChainEndpoint(config Config) (rpcEndpoint, bech32Prefix string, rpcEndpoints []string, err error) {
+	// Use stub chain info in mock mode, custom RPC if provided, otherwise get from chain registry
+	if config.MockMode {
+		rpcEndpoint, bech32Prefix = mockRPCEndpoint, mockBech32Prefix
+		logger.Info("🧸 Chain mock mode: no transaction or query will reach a node")
+	} else if config.GRPC != "" {
+		if config.RPC != "" {
+			logger.Warn(fmt.Sprintf("⚠️ Both --%s and --%s are set, ignoring the RPC endpoint", flagRPC, flagGRPCAddr), "rpc", config.RPC)
+		}
+		rpcEndpoint = config.GRPC
+		logger.Debug("🔗 Using custom gRPC endpoint", "endpoint", rpcEndpoint)
+
+		if bech32Prefix, err = chainBech32Prefix(config); err != nil {
+			return "", "", nil, err
+		}
+	} else if config.RPCPool != "" {
+		if rpcEndpoints, err = parseRPCPool(config.RPCPool); err != nil {
+			return "", "", nil, err
+		}
+		rpcEndpoint = rpcEndpoints[0]
+		logger.Debug("🔗 Using RPC pool", "endpoints", strings.Join(rpcEndpoints, ","))
+
+		if bech32Prefix, err = chainBech32Prefix(config); err != nil {
+			return "", "", nil, err
+		}
+	} else if config.RPC != "" {
+		rpcEndpoint = config.RPC
+		logger.Debug("🔗 Using custom RPC endpoint", "endpoint", rpcEndpoint)
+
+		if bech32Prefix, err = chainBech32Prefix(config); err != nil {
+			return "", "", nil, err
+		}
+	} else {
+		// Get chain information from registry
+		rpcEndpoint, bech32Prefix, err = getChainInfo(config.Chain)
+		if err != nil {
+			return "", "", nil, fmt.Errorf("failed to get chain info: %w", err)
+		}
+		logger.Debug("🔗 Using RPC endpoint from chain registry", "endpoint", rpcEndpoint)
+	}
+
+	return rpcEndpoint, bech32Prefix, rpcEndpoints, nil
+}
+
+// newSpamClient creates a cosmos client signing with the configured sign mode and chain-id, with the account key loaded from Vault when needed,
+// refusing to talk to a node of another chain or to a mainnet node whose certificate is not verified
+func newSpamClient(ctx context.Context, config Config, options ...cosmosclient.Option) (cosmosclient.Client, error) {
+	client, err := cosmosclient.New(ctx, options...)
+	if err != nil {
+		return cosmosclient.Client{}, fmt.Errorf("failed to create cosmos client: %w", err)
+	}
+
+	// Load the account key from Vault into the in-memory keyring of the client
+	if config.KeyringBackend == keyringBackendVault {
+		vault, err := NewVaultKeyringBackend(config.VaultPath)
+		if err != nil {
+			return cosmosclient.Client{}, err
+		}
+		if err := vault.Load(ctx, client.AccountRegistry, config.Account); err != nil {
+			return cosmosclient.Client{}, err
+		}
+	}
+
+	signMode, err := parseSignMode(config.SignMode)
+	if err != nil {
+		return cosmosclient.Client{}, err
+	}
+	client.TxFactory = client.TxFactory.WithSignMode(signMode)
+
+	// Refuse to talk to a mainnet node whose certificate is not verified
+	if config.InsecureSkipTLS && !config.MockMode {
+		if err := checkInsecureSkipTLS(client.Context().ChainID); err != nil {
+			return cosmosclient.Client{}, err
+		}
+	}
+
+	// Sign with the explicit chain-id, refusing to run against a node of another chain
+	if config.ChainID != "" {
+		if err := checkChainID(config.ChainID, client.Context().ChainID); err != nil {
+			return cosmosclient.Client{}, err
+		}
+		client.TxFactory = client.TxFactory.WithChainID(config.ChainID)
 	}
 
-	chain, exists := registry.Chains[chainName]
-	if !exists {
-		return "", "", fmt.Errorf("chain '%s' not found in registry", chainName)
+	return client, nil
+}
+
+// registerMsgInterfaces registers the messages of the modules spamtx sends transactions to,
+// on top of the auth, bank and staking ones the cosmos client registers
+func registerMsgInterfaces(registry codectypes.InterfaceRegistry) {
+	authztypes.RegisterInterfaces(registry)
+	distributiontypes.RegisterInterfaces(registry)
+	govv1.RegisterInterfaces(registry)
+	grouptypes.RegisterInterfaces(registry)
+	stakingtypes.RegisterInterfaces(registry)
+}
+
+// sendFunc sends transaction number txNum using the given account sequence
+type sendFunc func(ctx context.Context, txNum, sequence uint64) error
+
+// errSkipTx is returned by a sendFunc that skipped the transaction without broadcasting it.
+// The transaction is not counted as failed and its sequence is reused.
+var errSkipTx = errors.New("transaction skipped")
+
+// isTxFailure reports whether err is a failed transaction, and not a skipped one
+// or one cut short because the run was cancelled
+func isTxFailure(ctx context.Context, err error) bool {
+	return err != nil && !errors.Is(err, errSkipTx) && ctx.Err() == nil && !errors.Is(err, context.Canceled)
+}
+
+// concurrency returns the number of transactions sent per tick, at least 1
+func concurrency(config Config) uint64 {
+	return max(config.Concurrent, 1)
+}
+
+// txTimeout returns the maximum duration of a broadcast attempt, defaultTxTimeout when not set
+func txTimeout(config Config) time.Duration {
+	if config.TxTimeout == 0 {
+		return defaultTxTimeout
 	}
 
-	// Enrich the chain to get full details
-	if err := EnrichChain(&chain); err != nil {
-		return "", "", fmt.Errorf("failed to enrich chain '%s': %w", chainName, err)
+	return config.TxTimeout
+}
+
+// targetTPS returns the configured throughput, accounting for the transactions sent in parallel per tick
+func targetTPS(config Config) uint64 {
+	return config.TPS * concurrency(config)
+}
+
+// runSpamLoop calls send at the configured rate until the context is cancelled, and reports the number of transactions sent.
+// metrics may be nil.
+func runSpamLoop(ctx context.Context, config Config, pool *SequencePool, send sendFunc, metrics *spamMetrics) error {
+	txCount, err := spamLoop(ctx, config, pool, send, metrics)
+	if err != nil {
+		return err
 	}
 
-	// Get RPC endpoint
-	if len(chain.APIs.RPC) == 0 {
-		return "", "", fmt.Errorf("no RPC endpoints found for chain '%s'", chainName)
+	// Report the latency percentiles after the mempool hit rate
+	if histogram := config.latencyHistogram; histogram != nil {
+		defer histogram.Print()
 	}
-	rpcEndpoint := chain.APIs.RPC[0].Address
 
-	// Get bech32 prefix
-	bech32Prefix := chain.Bech32Prefix
-	if bech32Prefix == "" {
-		return "", "", fmt.Errorf("no bech32 prefix found for chain '%s'", chainName)
+	// Report the mempool hit rate after the transaction count
+	if checker := config.mempoolChecker; checker != nil {
+		defer func() {
+			fmt.Printf("🧺 Mempool hit rate: %.1f%% (%d seen, %d dropped, %d unknown)\n", checker.HitRate(), checker.seen.Load(), checker.dropped.Load(), checker.unknown.Load())
+		}()
+	}
+
+	if config.Count > 0 && txCount >= config.Count {
+		fmt.Printf("🏁 Sent %d transactions, done.\n", txCount)
+		return nil
 	}
 
-	return rpcEndpoint, bech32Prefix, nil
+	fmt.Printf("Sent %d transactions total.\n", txCount)
+	return nil
 }
 
-// spamTransactions starts the transaction spamming process
-func spamTransactions(ctx context.Context, config Config) error {
-	var rpcEndpoint, bech32Prefix string
-	var err error
+// countErrors wraps send to count the failed transactions, ignoring those cut short by the end of the run
+func countErrors(count *atomic.Uint64, send sendFunc) sendFunc {
+	return func(ctx context.Context, txNum, sequence uint64) error {
+		err := send(ctx, txNum, sequence)
+		if isTxFailure(ctx, err) {
+			count.Add(1)
+		}
 
-	// Use custom RPC if provided, otherwise get from chain registry
-	if config.RPC != "" {
-		rpcEndpoint = config.RPC
-		log.Printf("🔗 Using custom RPC endpoint: %s", rpcEndpoint)
+		return err
+	}
+}
 
-		// Still need bech32 prefix from chain registry
-		_, bech32Prefix, err = getChainInfo(config.Chain)
-		if err != nil {
-			return fmt.Errorf("failed to get chain info for bech32 prefix: %w", err)
-		}
+// spamLoop calls send at the configured rate until the context is cancelled, the count is reached
+// or too many consecutive errors occurred, and returns the number of transactions sent.
+// Each tick sends config.Concurrent transactions in parallel, each with its own sequence from the pool.
+// metrics may be nil.
+func spamLoop(ctx context.Context, config Config, pool *SequencePool, send sendFunc, metrics *spamMetrics) (uint64, error) {
+	poolCtx, cancelPool := context.WithCancel(ctx)
+	defer cancelPool()
+	go pool.Run(poolCtx)
+
+	// Create ticker for rate limiting, ramping up from 1 TPS when set
+	var (
+		ticks  <-chan time.Time
+		ticker *time.Ticker
+		period = time.Second / time.Duration(config.TPS)
+	)
+
+	// In block mode, tick at most once per block so that a tick never reuses the sequence of a pending transaction
+	if config.BroadcastMode == broadcastModeBlock && period < config.BlockTimeEstimate {
+		period = config.BlockTimeEstimate
+		logger.Info("🐢 Sending at most one tick per block in block broadcast mode", "block_time_estimate", config.BlockTimeEstimate)
+	}
+	if config.RampUp > 0 {
+		ticks = rampTicker(poolCtx, 1, int(config.TPS), config.RampUp)
+	} else if config.RateLimitStrategy == rateLimitTokenBucket {
+		ticks = tokenBucketTicker(poolCtx, config.TPS)
 	} else {
-		// Get chain information from registry
-		rpcEndpoint, bech32Prefix, err = getChainInfo(config.Chain)
-		if err != nil {
-			return fmt.Errorf("failed to get chain info: %w", err)
-		}
-		log.Printf("🔗 Using RPC endpoint from chain registry: %s", rpcEndpoint)
+		ticker = time.NewTicker(period)
+		defer ticker.Stop()
+		ticks = ticker.C
 	}
 
-	// Get keyring home directory
-	keyringDir, err := getKeyringHome()
-	if err != nil {
-		return fmt.Errorf("failed to get keyring home: %w", err)
+	// Halve the rate of the ticker while block production stalls
+	var blockEvents <-chan bool
+	tickPeriod := period
+	if config.blockMonitor != nil && ticker != nil {
+		blockEvents = config.blockMonitor.Events()
 	}
 
-	// Initialize cosmos client with configuration
-	client, err := cosmosclient.New(ctx,
-		cosmosclient.WithNodeAddress(rpcEndpoint),
-		cosmosclient.WithFees(config.Fees),
-		cosmosclient.WithBech32Prefix(bech32Prefix),
-		cosmosclient.WithKeyringDir(keyringDir),
-		cosmosclient.WithKeyringBackend(DefaultKeyringBackend),
-		cosmosclient.WithKeyringServiceName(DefaultKeyringServiceName),
+	// batchSent counts the transactions dispatched since the last cooldown
+	var batchSent uint64
+
+	// The watchdog fires when no transaction was sent for the stall timeout
+	var (
+		watchdog   *WatchdogTimer
+		stalls     <-chan time.Time
+		stallCount uint64
 	)
-	if err != nil {
-		return fmt.Errorf("failed to create cosmos client: %w", err)
+	if config.StallTimeout > 0 {
+		watchdog = NewWatchdogTimer(config.StallTimeout)
+		defer watchdog.Stop()
+		stalls = watchdog.C()
 	}
 
-	// Get account from cosmos client's keyring
-	account, err := client.Account(config.Account)
-	if err != nil {
-		return fmt.Errorf("failed to get account '%s' from cosmos client keyring: %w", config.Account, err)
-	}
+	tracker := newTPSTracker(tpsWindowSize)
 
-	// Get account address for verification
-	accountAddr, err := account.Address(bech32Prefix)
-	if err != nil {
-		return fmt.Errorf("failed to get account '%s' from keyring: %w", config.Account, err)
-	}
+	// sem caps the number of in-flight transactions
+	sem := make(chan struct{}, concurrency(config))
+	var wg sync.WaitGroup
 
-	// Check if account exists on the blockchain
-	if err := verifyAccountExists(ctx, client, accountAddr); err != nil {
-		return fmt.Errorf("account verification failed: %w", err)
-	}
+	// mu guards the run state shared with the sending goroutines
+	var (
+		mu                          sync.Mutex
+		txCount, errStreak, pending uint64
+		stopErr                     error
+		stopped                     bool
+	)
 
-	// Fetch and display current account sequence
-	sequence, err := fetchAccountSequence(ctx, client, accountAddr)
-	if err != nil {
-		return fmt.Errorf("failed to fetch account sequence: %w", err)
-	}
-	log.Printf("📊 Current account sequence: %d", sequence)
+	sendNext := func() {
+		defer wg.Done()
+		defer func() { <-sem }()
 
-	// Parse the fees to get the amount for self-transfers
-	amount, err := parseAmount(config.Fees)
-	if err != nil {
-		return fmt.Errorf("failed to parse fees as amount: %w", err)
+		seq := pool.Acquire()
+		err := send(ctx, seq-pool.start, seq)
+
+		mu.Lock()
+		defer mu.Unlock()
+		pending--
+
+		if errors.Is(err, errSkipTx) {
+			pool.Release(seq)
+			return
+		}
+
+		if err != nil {
+			logger.Error("❌ Failed to send transaction", "sequence", seq, "error", err)
+			metrics.recordFailed()
+
+			// Reuse the sequence of the failed transaction
+			pool.Release(seq)
+
+			errStreak++
+			if config.MaxErrors > 0 && errStreak >= config.MaxErrors && !stopped {
+				stopped = true
+				stopErr = fmt.Errorf("stopping after %d consecutive errors (%d transactions sent): %w", errStreak, txCount, err)
+			}
+			return
+		}
+
+		errStreak = 0
+		txCount++
+		if watchdog != nil {
+			watchdog.Kick()
+		}
+		tracker.Record(time.Now())
+		metrics.recordSent(tracker.TPS())
+		if config.LogInterval > 0 && txCount%config.LogInterval == 0 {
+			if config.mempoolChecker != nil {
+				fmt.Printf("✅ Sent %d transactions (Target: %d TPS, Actual: %.1f TPS, Mempool hit rate: %.1f%%)\n", txCount, targetTPS(config), tracker.TPS(), config.mempoolChecker.HitRate())
+			} else {
+				fmt.Printf("✅ Sent %d transactions (Target: %d TPS, Actual: %.1f TPS)\n", txCount, targetTPS(config), tracker.TPS())
+			}
+		}
+		if config.Count > 0 && txCount >= config.Count {
+			stopped = true
+		}
 	}
 
-	// Create ticker for rate limiting
-	interval := time.Second / time.Duration(config.TPS)
-	ticker := time.NewTicker(interval)
-	defer ticker.Stop()
+	// finish waits for the in-flight transactions before returning the outcome of the run
+	finish := func() (uint64, error) {
+		wg.Wait()
+
+		return txCount, stopErr
+	}
 
-	var txCount uint64 = 0
 	for {
 		select {
-		case <-ticker.C:
-			var err error
-			if config.Heavy {
-				err = sendHeavyTransaction(
-					ctx,
-					client,
-					account,
-					config,
-					amount,
-					txCount,
-					bech32Prefix,
-					config.Memo,
-					sequence+txCount,
-				)
-			} else {
-				err = sendTransaction(
-					ctx,
-					client,
-					account,
-					config,
-					amount,
-					txCount,
-					bech32Prefix,
-					config.Memo,
-					sequence+txCount,
-				)
+		case <-ticks:
+			for range concurrency(config) {
+				select {
+				case sem <- struct{}{}:
+				case <-ctx.Done():
+					return finish()
+				}
+
+				mu.Lock()
+				// Never send more transactions than needed to reach the count
+				done := stopped || (config.Count > 0 && txCount+pending >= config.Count)
+				if !done {
+					pending++
+				}
+				mu.Unlock()
+
+				if done {
+					<-sem
+					break
+				}
+
+				wg.Add(1)
+				go sendNext()
 			}
-			if err != nil {
-				log.Printf("❌ Failed to send transaction: %v", err)
-				continue
+
+			// Wait for the CheckTx result, or the block inclusion, of the transactions of the tick
+			if config.BroadcastMode == broadcastModeSync || config.BroadcastMode == broadcastModeBlock {
+				wg.Wait()
+			}
+
+			mu.Lock()
+			isStopped := stopped
+			mu.Unlock()
+			if isStopped {
+				return finish()
 			}
-			txCount++
-			if txCount%config.TPS == 0 {
-				fmt.Printf("✅ Sent %d transactions (Rate: %d TPS)\n", txCount, config.TPS)
+
+			// Pause after each batch, for a sawtooth traffic pattern
+			if config.Cooldown > 0 {
+				batchSent += concurrency(config)
+				if batchSent >= batchSize(config) {
+					batchSent = 0
+					if err := cooldown(ctx, config.Cooldown, ticks, ticker, tickPeriod); err != nil {
+						return finish()
+					}
+					if watchdog != nil {
+						watchdog.Kick()
+					}
+				}
+			}
+		case <-stalls:
+			stallCount++
+			logger.Warn("⚠️ No transaction sent, restarting the rate limiter", "for", config.StallTimeout, "stalls", stallCount)
+			if config.StallMaxCount > 0 && stallCount >= config.StallMaxCount {
+				mu.Lock()
+				stopErr = fmt.Errorf("stopping after %d stalls of %s without a transaction sent (%d transactions sent)", stallCount, config.StallTimeout, txCount)
+				mu.Unlock()
+				return finish()
+			}
+
+			if ticker != nil {
+				ticker.Reset(tickPeriod)
 			}
+			watchdog.Kick()
+		case stalled := <-blockEvents:
+			if stalled {
+				tickPeriod = blockStallFactor * period
+				logger.Warn("🐢 Block production stalled, halving the TPS", "block_time_estimate", config.BlockTimeEstimate, "target_tps", float64(targetTPS(config))/blockStallFactor)
+			} else {
+				tickPeriod = period
+				logger.Info("🐇 Block production resumed, back to the target TPS", "target_tps", targetTPS(config))
+			}
+			ticker.Reset(tickPeriod)
 		case <-ctx.Done():
-			fmt.Printf("Sent %d transactions total.\n", txCount)
-			return nil
+			return finish()
 		}
 	}
 }
 
-// sendTransaction sends a bank transfer transaction to self with a specified memo.
-func sendTransaction(ctx context.Context, client cosmosclient.Client, account cosmosaccount.Account, config Config, amount sdk.Coins, txNum uint64, addressPrefix, memo string, sequence uint64) error {
-	txCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
-	defer cancel()
+// batchSize returns the number of transactions sent between two cooldowns, one second of transactions when not set
+func batchSize(config Config) uint64 {
+	if config.BatchSize == 0 {
+		return targetTPS(config)
+	}
+	return config.BatchSize
+}
+
+// cooldown pauses the rate limiting for duration, or until the context is cancelled.
+// The ticker, when set, is stopped and restarted with period after the pause. The tick delivered
+// during the pause by the other rate limiters is dropped, so that the next batch does not start right away.
+func cooldown(ctx context.Context, duration time.Duration, ticks <-chan time.Time, ticker *time.Ticker, period time.Duration) error {
+	logger.Info("😴 Cooling down", "at", time.Now().Format(time.RFC3339Nano), "for", duration)
+
+	if ticker != nil {
+		ticker.Stop()
+	}
+
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	if ticker != nil {
+		ticker.Reset(period)
+	}
+	select {
+	case <-ticks:
+	default:
+	}
+
+	return nil
+}
 
+// sendTransaction sends a bank transfer transaction with a specified memo to the recipient, or to self when the recipient is empty.
+func sendTransaction(ctx context.Context, client cosmosclient.Client, account cosmosaccount.Account, config Config, amount sdk.Coins, recipient string, txNum uint64, addressPrefix, memo string, sequence uint64) error {
 	// Get account address for self-transfer using the chain's bech32 prefix
 	accountAddr, err := account.Address(addressPrefix)
 	if err != nil {
 		return fmt.Errorf("failed to get account address: %w", err)
 	}
 
-	// Create and broadcast bank send transaction to self
+	// Create and broadcast bank send transaction to self, or to the authz granter
+	sender := msgSender(config, accountAddr)
+	if recipient == "" {
+		recipient = sender
+	}
 	bankSendMsg := &banktypes.MsgSend{
-		FromAddress: accountAddr,
-		ToAddress:   accountAddr,
+		FromAddress: sender,
+		ToAddress:   recipient,
 		Amount:      amount,
 	}
 
+	msg, err := buildMsg(config, accountAddr, bankSendMsg)
+	if err != nil {
+		return err
+	}
+
+	msgs := make([]sdk.Msg, config.MsgsPerTx)
+	for i := range msgs {
+		msgs[i] = msg
+	}
+
+	response, err := broadcastTx(ctx, client, account, config, txNum, memo, sequence, msgs...)
+	if err != nil {
+		return err
+	}
+
+	logger.Debug("🔗 Transaction broadcasted", "tx", txNum, "sequence", sequence, "hash", response.TxHash, "recipient", recipient, "msgs", len(msgs), "memo", memo)
+
+	return nil
+}
+
+// broadcastTx creates a transaction with the given messages and broadcasts it using the given sequence
+func broadcastTx(ctx context.Context, client cosmosclient.Client, account cosmosaccount.Account, config Config, txNum uint64, memo string, sequence uint64, msgs ...sdk.Msg) (response cosmosclient.Response, err error) {
+	if config.extraMsg != nil {
+		msgs = append(msgs, config.extraMsg)
+	}
+
+	ctx, span := startTxSpan(ctx, config, txNum, sequence)
+	var latency time.Duration
+	defer func() {
+		endTxSpan(span, latency, response.Code, err)
+	}()
+
+	if config.SimulateOnly {
+		result, err := runSimulationBenchmark(ctx, client, account, config, memo, sequence, msgs, config.SimulateCount)
+		if err != nil {
+			return cosmosclient.Response{}, err
+		}
+		result.Print(config.TxType)
+		return cosmosclient.Response{}, nil
+	}
+
+	gasLimit := config.GasLimitForType(config.TxType, config.GasLimit)
+	if config.gasEstimator != nil {
+		if gasLimit, err = config.gasEstimator.GasLimit(ctx, client, account, config, memo, sequence, msgs...); err != nil {
+			return cosmosclient.Response{}, err
+		}
+		defer func() {
+			if isOutOfGas(err) {
+				config.gasEstimator.RecordOutOfGas()
+			}
+		}()
+	}
+
+	// Expire the transaction offset blocks after the latest block, instead of letting it sit in the mempool
+	if config.TimeoutHeightOffset > 0 {
+		timeoutHeight, err := txTimeoutHeight(ctx, client, config.TimeoutHeightOffset)
+		if err != nil {
+			return cosmosclient.Response{}, err
+		}
+		client.TxFactory = client.TxFactory.WithTimeoutHeight(timeoutHeight)
+	}
+
+	// The latency of the histogram includes building and signing the transaction
+	created := time.Now()
 	txService, err := client.CreateTxWithOptions(
 		ctx,
 		account,
 		cosmosclient.TxOptions{
 			Memo:     memo,
 			Fees:     config.Fees,
-			GasLimit: config.GasLimit,
+			GasLimit: gasLimit,
 		},
-		bankSendMsg,
+		msgs...,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to create bank send transaction: %w", err)
+		return cosmosclient.Response{}, fmt.Errorf("failed to create transaction: %w", err)
 	}
 
 	// Broadcast the transaction
-	response, err := txService.BroadcastAsync(txCtx, cosmosclient.WithSequence(sequence))
+	start := time.Now()
+	response, err = broadcastWithRetry(ctx, txService, sequence, config.Retry, config.RetryDelay, txTimeout(config))
+	latency = time.Since(start)
 	if err != nil {
-		return fmt.Errorf("failed to broadcast transaction: %w", err)
+		return response, fmt.Errorf("failed to broadcast transaction: %w", err)
+	}
+
+	if config.latencyHistogram != nil {
+		config.latencyHistogram.Record(time.Since(created))
+	}
+
+	if config.BroadcastMode == broadcastModeSync {
+		logger.Info("🩺 CheckTx result", "tx", txNum, "sequence", sequence, "hash", response.TxHash, "code", response.Code, "log", response.RawLog)
 	}
 
 	if response.Code != 0 {
-		return fmt.Errorf("transaction failed with code %d", response.Code)
+		return response, fmt.Errorf("transaction failed with code %d", response.Code)
+	}
+
+	if config.DryRun {
+		logger.Info("🧪 Dry-run: would broadcast transaction", "tx", txNum, "sequence", sequence, "hash", response.TxHash)
+	}
+
+	if config.mempoolChecker != nil {
+		config.mempoolChecker.Record(ctx, client, response.TxHash)
+	}
+
+	if config.WatchBlock || config.BroadcastMode == broadcastModeBlock {
+		height, err := waitForInclusion(ctx, client, response.TxHash, inclusionTimeout)
+		if err != nil {
+			return response, err
+		}
+
+		if config.BroadcastMode == broadcastModeBlock {
+			logger.Info("📦 Transaction committed", "tx", txNum, "sequence", sequence, "hash", response.TxHash, "height", height)
+		}
 	}
 
-	// Log transaction details periodically
-	if txNum%100 == 0 {
-		log.Printf("🔗 Transaction #%d broadcasted with hash: %s, memo: %s", txNum, response.TxHash, config.Memo)
+	return response, nil
+}
+
+// checkChainID verifies that the chain-id reported by the node matches the expected one
+func checkChainID(expected, actual string) error {
+	if expected != actual {
+		return fmt.Errorf("chain-id mismatch: expected %s but node reports %s", expected, actual)
 	}
 
 	return nil
@@ -238,6 +1504,55 @@ func parseAmount(amountStr string) (sdk.Coins, error) {
 	return coins, nil
 }
 
+// transferAmount returns the amount of the self-transfers, which defaults to the fees for backward compatibility
+func transferAmount(config Config) (sdk.Coins, error) {
+	amountStr := config.Amount
+	if amountStr == "" {
+		amountStr = config.Fees
+	}
+
+	amount, err := parseAmount(amountStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse amount: %w", err)
+	}
+
+	return amount, nil
+}
+
+// filterFeeByDenom keeps the fee coin of the given denom only
+func filterFeeByDenom(fees sdk.Coins, denom string) (sdk.Coins, error) {
+	found, coin := fees.Find(denom)
+	if !found {
+		return nil, fmt.Errorf("fee denom %s not found in fees %s", denom, fees)
+	}
+
+	return sdk.NewCoins(coin), nil
+}
+
+// computeFeesFromPct returns pct percent of each amount coin, rounded up to the nearest integer unit
+func computeFeesFromPct(amount sdk.Coins, pct float64) (string, error) {
+	if pct <= 0 {
+		return "", fmt.Errorf("fee percentage must be greater than 0")
+	}
+
+	pctDec, err := math.LegacyNewDecFromStr(strconv.FormatFloat(pct, 'f', -1, 64))
+	if err != nil {
+		return "", fmt.Errorf("invalid fee percentage %g: %w", pct, err)
+	}
+
+	fees := sdk.NewCoins()
+	for _, coin := range amount {
+		fee := math.LegacyNewDecFromInt(coin.Amount).Mul(pctDec).QuoInt64(100).Ceil().TruncateInt()
+		fees = fees.Add(sdk.NewCoin(coin.Denom, fee))
+	}
+
+	if fees.IsZero() {
+		return "", fmt.Errorf("fees computed from %s at %g%% are zero", amount, pct)
+	}
+
+	return fees.String(), nil
+}
+
 // verifyAccountExists checks if an account exists on the blockchain
 func verifyAccountExists(ctx context.Context, client cosmosclient.Client, address string) error {
 	queryCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
@@ -257,6 +1572,36 @@ func verifyAccountExists(ctx context.Context, client cosmosclient.Client, addres
 	return nil
 }
 
+// checkBalance checks that the account balance holds at least minBalance of each denom
+func checkBalance(ctx context.Context, client cosmosclient.Client, address string, minBalance sdk.Coins) error {
+	queryCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	balance, err := client.BankBalances(queryCtx, address, nil)
+	if err != nil {
+		return fmt.Errorf("failed to query balance of %s: %w", address, err)
+	}
+
+	if shortfall := balanceShortfall(balance, minBalance); !shortfall.IsZero() {
+		return fmt.Errorf("balance of %s is below the minimum balance of %s: has %s, missing %s", address, minBalance, formatBalance(balance), shortfall)
+	}
+
+	logger.Info("💰 Account balance", "balance", balance)
+	return nil
+}
+
+// balanceShortfall returns the amount of each denom missing from balance to reach minBalance
+func balanceShortfall(balance, minBalance sdk.Coins) sdk.Coins {
+	var shortfall sdk.Coins
+	for _, coin := range minBalance {
+		if have := balance.AmountOf(coin.Denom); have.LT(coin.Amount) {
+			shortfall = shortfall.Add(sdk.NewCoin(coin.Denom, coin.Amount.Sub(have)))
+		}
+	}
+
+	return shortfall
+}
+
 // fetchAccountSequence fetches the current sequence number for an account
 func fetchAccountSequence(ctx context.Context, client cosmosclient.Client, address string) (uint64, error) {
 	queryCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
@@ -280,6 +1625,114 @@ func fetchAccountSequence(ctx context.Context, client cosmosclient.Client, addre
 	return account.GetSequence(), nil
 }
 
+// checkConsensusParams verifies that the block gas limit is sufficient for the configured TPS
+func checkConsensusParams(ctx context.Context, client cosmosclient.Client, config Config) error {
+	queryCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	resp, err := client.RPC.ConsensusParams(queryCtx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to query consensus params: %w", err)
+	}
+
+	maxGas := resp.ConsensusParams.Block.MaxGas
+	if err := checkBlockGasLimit(maxGas, targetTPS(config), estimateGasPerTx(config)); err != nil {
+		return err
+	}
+
+	logger.Info("⛽ Block gas limit is sufficient", "max_gas", maxGas, "target_tps", targetTPS(config))
+	return nil
+}
+
+// logBlockGasTarget logs how many transactions per second the block gas limit supports.
+// Failures are logged but never fatal.
+func logBlockGasTarget(ctx context.Context, client cosmosclient.Client, config Config) {
+	queryCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	resp, err := client.RPC.ConsensusParams(queryCtx, nil)
+	if err != nil {
+		logger.Warn("⚠️ Failed to query consensus params for block gas target", "error", err)
+		return
+	}
+
+	maxGas := resp.ConsensusParams.Block.MaxGas
+	if maxGas < 0 {
+		logger.Info("⛽ Block gas limit: unlimited")
+		return
+	}
+
+	blockTime, err := estimateBlockTime(queryCtx, client)
+	if err != nil {
+		logger.Warn("⚠️ Failed to estimate block time for block gas target", "error", err)
+		return
+	}
+
+	logger.Info("⛽ " + formatBlockGasTarget(maxGas, estimateGasPerTx(config), blockTime))
+}
+
+// estimateBlockTime returns the average time between the latest blocks
+func estimateBlockTime(ctx context.Context, client cosmosclient.Client) (time.Duration, error) {
+	resp, err := client.RPC.BlockchainInfo(ctx, 0, 0)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query latest blocks: %w", err)
+	}
+
+	// Block metas are ordered from the latest to the oldest block
+	if len(resp.BlockMetas) < 2 {
+		return 0, fmt.Errorf("not enough blocks to estimate block time")
+	}
+	latest := resp.BlockMetas[0].Header.Time
+	oldest := resp.BlockMetas[len(resp.BlockMetas)-1].Header.Time
+
+	blockTime := latest.Sub(oldest) / time.Duration(len(resp.BlockMetas)-1)
+	if blockTime <= 0 {
+		return 0, fmt.Errorf("invalid block times between %s and %s", oldest, latest)
+	}
+
+	return blockTime, nil
+}
+
+// formatBlockGasTarget describes the theoretical throughput allowed by the block gas limit
+func formatBlockGasTarget(maxGas int64, gasPerTx uint64, blockTime time.Duration) string {
+	txPerBlock := uint64(maxGas) / gasPerTx
+	tps := float64(txPerBlock) / blockTime.Seconds()
+
+	return fmt.Sprintf("Block gas limit: %d gas / %d gas-per-tx = %d tx-per-block ≈ %.1f TPS at %.1f-second blocks",
+		maxGas, gasPerTx, txPerBlock, tps, blockTime.Seconds())
+}
+
+// checkBlockGasLimit returns an error when tps * gasPerTx exceeds the block max gas.
+// A max gas of -1 means the block gas is unlimited.
+func checkBlockGasLimit(maxGas int64, tps, gasPerTx uint64) error {
+	if maxGas < 0 {
+		return nil
+	}
+
+	requiredGas := tps * gasPerTx
+	if requiredGas > uint64(maxGas) {
+		return fmt.Errorf("required gas %d (%d TPS * %d gas per tx) exceeds block max gas %d, consider reducing --%s to %d",
+			requiredGas, tps, gasPerTx, maxGas, flagTPS, uint64(maxGas)/gasPerTx)
+	}
+
+	return nil
+}
+
+// estimateGasPerTx returns the gas limit used by each transaction, or a rough estimate when it is not set
+func estimateGasPerTx(config Config) uint64 {
+	if gasLimit := config.GasLimitForType(config.TxType, config.GasLimit); gasLimit > 0 {
+		return gasLimit
+	}
+
+	// Rough estimate using the same model as calculateAddressCount:
+	// a base transaction cost plus ~15k gas per output
+	if config.Heavy {
+		return 50000 + calculateAddressCount(config)*15000
+	}
+
+	return 100000
+}
+
 // calculateAddressCount determines how many addresses to send to in heavy mode
 func calculateAddressCount(config Config) uint64 {
 	if config.HeavyAddressCount > 0 {
@@ -287,10 +1740,10 @@ func calculateAddressCount(config Config) uint64 {
 	}
 
 	// Scale based on gas limit if provided
-	if config.GasLimit > 0 {
+	if gasLimit := config.GasLimitForType(txTypeBankSend, config.GasLimit); gasLimit > 0 {
 		// Rough estimate: each output in MsgMultiSend uses ~15k gas
 		// Leave some buffer for base transaction costs
-		estimatedCount := (config.GasLimit - 50000) / 15000
+		estimatedCount := (gasLimit - 50000) / 15000
 		if estimatedCount > 0 {
 			return estimatedCount
 		}
@@ -302,14 +1755,12 @@ func calculateAddressCount(config Config) uint64 {
 
 // sendHeavyTransaction sends a bank multi-send transaction to self multiple times
 func sendHeavyTransaction(ctx context.Context, client cosmosclient.Client, account cosmosaccount.Account, config Config, amount sdk.Coins, txNum uint64, addressPrefix, memo string, sequence uint64) error {
-	txCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
-	defer cancel()
-
 	accountAddr, err := account.Address(addressPrefix)
 	if err != nil {
 		return fmt.Errorf("failed to get account address: %w", err)
 	}
 
+	sender := msgSender(config, accountAddr)
 	outputCount := calculateAddressCount(config)
 
 	// Calculate amount per output (split the total amount)
@@ -326,7 +1777,7 @@ func sendHeavyTransaction(ctx context.Context, client cosmosclient.Client, accou
 	totalOutput := amountPerOutput.MulInt(math.NewIntFromUint64(outputCount))
 	inputs := []banktypes.Input{
 		{
-			Address: accountAddr,
+			Address: sender,
 			Coins:   totalOutput,
 		},
 	}
@@ -335,7 +1786,7 @@ func sendHeavyTransaction(ctx context.Context, client cosmosclient.Client, accou
 	outputs := make([]banktypes.Output, outputCount)
 	for i := uint64(0); i < outputCount; i++ {
 		outputs[i] = banktypes.Output{
-			Address: accountAddr,
+			Address: sender,
 			Coins:   amountPerOutput,
 		}
 	}
@@ -345,34 +1796,17 @@ func sendHeavyTransaction(ctx context.Context, client cosmosclient.Client, accou
 		Outputs: outputs,
 	}
 
-	txService, err := client.CreateTxWithOptions(
-		ctx,
-		account,
-		cosmosclient.TxOptions{
-			Memo:     memo,
-			Fees:     config.Fees,
-			GasLimit: config.GasLimit,
-		},
-		multiSendMsg,
-	)
+	msg, err := buildMsg(config, accountAddr, multiSendMsg)
 	if err != nil {
-		return fmt.Errorf("failed to create multi-send transaction: %w", err)
+		return err
 	}
 
-	// Broadcast the transaction
-	response, err := txService.BroadcastAsync(txCtx, cosmosclient.WithSequence(sequence))
+	response, err := broadcastTx(ctx, client, account, config, txNum, memo, sequence, msg)
 	if err != nil {
-		return fmt.Errorf("failed to broadcast transaction: %w", err)
+		return err
 	}
 
-	if response.Code != 0 {
-		return fmt.Errorf("transaction failed with code %d", response.Code)
-	}
-
-	// Log transaction details periodically
-	if txNum%100 == 0 {
-		log.Printf("🔗 Heavy transaction #%d broadcasted with hash: %s, outputs: %d, memo: %s", txNum, response.TxHash, outputCount, config.Memo)
-	}
+	logger.Debug("🔗 Heavy transaction broadcasted", "tx", txNum, "sequence", sequence, "hash", response.TxHash, "outputs", outputCount, "memo", memo)
 
 	return nil
 }
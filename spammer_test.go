@@ -1,10 +1,28 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/ignite/cli/v29/ignite/pkg/cosmosclient"
+	"github.com/julienrbrt/spamtx/chainregistry"
+	"google.golang.org/grpc"
 	"gotest.tools/v3/assert"
 )
 
@@ -92,11 +110,12 @@ func TestSpamTransactionsValidation(t *testing.T) {
 
 func TestValidConfigDoesNotError(t *testing.T) {
 	validConfig := Config{
-		Chain:   "cosmoshub",
-		Account: "cosmos1test123",
-		Fees:    "1000uatom",
-		Memo:    "test memo",
-		TPS:     5,
+		Chain:     "cosmoshub",
+		Account:   "cosmos1test123",
+		Fees:      "1000uatom",
+		Memo:      "test memo",
+		TPS:       5,
+		MsgsPerTx: 1,
 	}
 
 	err := validateConfig(validConfig)
@@ -120,6 +139,110 @@ func TestSendTransactionCreatesCorrectAmount(t *testing.T) {
 	assert.Equal(t, amount[0].Amount.String(), "1000")
 }
 
+func TestSendTransactionMsgsPerTx(t *testing.T) {
+	tests := []struct {
+		name      string
+		msgsPerTx int
+		wantMsgs  int
+	}{
+		{
+			name:      "single message",
+			msgsPerTx: 1,
+			wantMsgs:  1,
+		},
+		{
+			name:      "batched messages",
+			msgsPerTx: 5,
+			wantMsgs:  5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, txs := newStakingTestClient(t)
+
+			account, _, err := client.AccountRegistry.Create("alice")
+			assert.NilError(t, err)
+			accountAddr, err := account.Address(mockBech32Prefix)
+			assert.NilError(t, err)
+
+			config := Config{Fees: "1000uatom", GasLimit: 200000, MsgsPerTx: tt.msgsPerTx}
+			amount := sdk.NewCoins(sdk.NewInt64Coin("uatom", 1))
+			err = sendTransaction(context.Background(), client, account, config, amount, "", 0, mockBech32Prefix, "batch", 1)
+			assert.NilError(t, err)
+
+			assert.Equal(t, len(*txs), 1)
+			msgs := (*txs)[0].GetMsgs()
+			assert.Equal(t, len(msgs), tt.wantMsgs)
+			for _, msg := range msgs {
+				assert.DeepEqual(t, msg, sdk.Msg(&banktypes.MsgSend{
+					FromAddress: accountAddr,
+					ToAddress:   accountAddr,
+					Amount:      amount,
+				}))
+			}
+		})
+	}
+}
+
+func TestSendTransactionTimeoutHeight(t *testing.T) {
+	for _, offset := range []uint64{0, 20} {
+		t.Run(fmt.Sprintf("offset %d", offset), func(t *testing.T) {
+			client, txs := newStakingTestClient(t)
+
+			account, _, err := client.AccountRegistry.Create("alice")
+			assert.NilError(t, err)
+
+			// The mock node reports a latest height of 0
+			config := Config{Fees: "1000uatom", GasLimit: 200000, MsgsPerTx: 1, TimeoutHeightOffset: offset}
+			err = sendTransaction(context.Background(), client, account, config, sdk.NewCoins(sdk.NewInt64Coin("uatom", 1)), "", 0, mockBech32Prefix, "timeout", 1)
+			assert.NilError(t, err)
+
+			assert.Equal(t, len(*txs), 1)
+			tx, ok := (*txs)[0].(sdk.TxWithTimeoutHeight)
+			assert.Assert(t, ok)
+			assert.Equal(t, tx.GetTimeoutHeight(), offset)
+		})
+	}
+}
+
+func TestTransferAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   Config
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "explicit amount",
+			config:   Config{Fees: "1000uatom", Amount: "1uatom"},
+			expected: "1uatom",
+		},
+		{
+			name:     "defaults to the fees",
+			config:   Config{Fees: "1000uatom"},
+			expected: "1000uatom",
+		},
+		{
+			name:    "invalid amount",
+			config:  Config{Fees: "1000uatom", Amount: "0uatom"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amount, err := transferAmount(tt.config)
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "failed to parse amount")
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, amount.String(), tt.expected)
+		})
+	}
+}
+
 func TestParseAmountHandlesMultipleCoins(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -195,6 +318,73 @@ func TestGetChainInfoInvalidChain(t *testing.T) {
 	assert.Assert(t, bech32Prefix == "")
 }
 
+func TestChainBech32Prefix(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// Serve a registry without the private chain
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"chains":[]}`))
+	}))
+	defer server.Close()
+
+	previousURL, previousNoCache := registryURL, registryNoCache
+	registryURL, registryNoCache = server.URL, true
+	t.Cleanup(func() {
+		registryURL, registryNoCache = previousURL, previousNoCache
+	})
+
+	// The chain prefix skips the registry
+	prefix, err := chainBech32Prefix(Config{Chain: "private-testnet", RPC: "http://localhost:26657", Bech32Prefix: "private"})
+	assert.NilError(t, err)
+	assert.Equal(t, prefix, "private")
+
+	_, err = chainBech32Prefix(Config{Chain: "private-testnet", RPC: "http://localhost:26657"})
+	assert.ErrorIs(t, err, chainregistry.ErrChainNotFound)
+	assert.ErrorContains(t, err, "set --chain-prefix")
+}
+
+func TestPrintChainInfo(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/testchain":
+			_, _ = w.Write([]byte(`{"chain":{"chain_name":"testchain","chain_id":"testchain-1","bech32_prefix":"test","apis":{"rpc":[{"address":"https://rpc.testchain.io"}]}}}`))
+		default:
+			_, _ = w.Write([]byte(`{"chains":[{"chain_name":"testchain"}]}`))
+		}
+	}))
+	defer server.Close()
+
+	previousURL, previousNoCache := registryURL, registryNoCache
+	registryURL, registryNoCache = server.URL, true
+	t.Cleanup(func() {
+		registryURL, registryNoCache = previousURL, previousNoCache
+	})
+
+	var buf bytes.Buffer
+	assert.NilError(t, printChainInfo(&buf, "testchain"))
+
+	var info ChainInfo
+	assert.NilError(t, json.Unmarshal(buf.Bytes(), &info))
+	assert.DeepEqual(t, info, ChainInfo{
+		Chain:        "testchain",
+		ChainID:      "testchain-1",
+		RPC:          "https://rpc.testchain.io",
+		Bech32Prefix: "test",
+	})
+
+	// The chain info does not require the flags of a run
+	cmd := spamCmd()
+	buf.Reset()
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{"testchain", "--chain-info"})
+	assert.NilError(t, cmd.Execute())
+	assert.Assert(t, strings.Contains(buf.String(), `"chain_id": "testchain-1"`))
+
+	assert.ErrorIs(t, printChainInfo(&buf, "unknown"), chainregistry.ErrChainNotFound)
+}
+
 func TestSpamTransactionsRespectsContextCancellation(t *testing.T) {
 	config := Config{
 		Chain:   "cosmoshub",
@@ -329,6 +519,86 @@ func TestGetChainInfoMultipleChains(t *testing.T) {
 	}
 }
 
+// balanceQueryClient answers balance queries with a fixed balance
+type balanceQueryClient struct {
+	banktypes.QueryClient
+	balance sdk.Coins
+	err     error
+}
+
+func (c balanceQueryClient) AllBalances(context.Context, *banktypes.QueryAllBalancesRequest, ...grpc.CallOption) (*banktypes.QueryAllBalancesResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &banktypes.QueryAllBalancesResponse{Balances: c.balance}, nil
+}
+
+func TestCheckBalance(t *testing.T) {
+	tests := []struct {
+		name       string
+		balance    sdk.Coins
+		queryErr   error
+		minBalance string
+		wantErr    string
+	}{
+		{
+			name:       "above minimum",
+			balance:    sdk.NewCoins(sdk.NewInt64Coin("uatom", 2000)),
+			minBalance: "1000uatom",
+		},
+		{
+			name:       "equal to minimum",
+			balance:    sdk.NewCoins(sdk.NewInt64Coin("uatom", 1000)),
+			minBalance: "1000uatom",
+		},
+		{
+			name:       "below minimum",
+			balance:    sdk.NewCoins(sdk.NewInt64Coin("uatom", 400)),
+			minBalance: "1000uatom",
+			wantErr:    "has 400uatom, missing 600uatom",
+		},
+		{
+			name:       "empty balance",
+			minBalance: "1000uatom",
+			wantErr:    "has 0, missing 1000uatom",
+		},
+		{
+			name:       "missing denom",
+			balance:    sdk.NewCoins(sdk.NewInt64Coin("uatom", 2000)),
+			minBalance: "1000uatom,50stake",
+			wantErr:    "missing 50stake",
+		},
+		{
+			name:       "query error",
+			queryErr:   errors.New("connection refused"),
+			minBalance: "1000uatom",
+			wantErr:    "failed to query balance",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := cosmosclient.New(context.Background(),
+				cosmosclient.WithRPCClient(newMockRPC()),
+				cosmosclient.WithBankQueryClient(balanceQueryClient{balance: tt.balance, err: tt.queryErr}),
+				cosmosclient.WithKeyringDir(t.TempDir()),
+				cosmosclient.WithKeyringBackend(DefaultKeyringBackend),
+			)
+			assert.NilError(t, err)
+
+			minBalance, err := parseAmount(tt.minBalance)
+			assert.NilError(t, err)
+
+			err = checkBalance(context.Background(), client, "cosmos1abc123", minBalance)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+		})
+	}
+}
+
 func TestFetchAccountSequence(t *testing.T) {
 	// Test that fetchAccountSequence handles invalid inputs gracefully
 	tests := []struct {
@@ -417,3 +687,669 @@ func TestCalculateAddressCount(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckBlockGasLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		maxGas   int64
+		tps      uint64
+		gasPerTx uint64
+		wantErr  bool
+	}{
+		{
+			name:     "unlimited block gas",
+			maxGas:   -1,
+			tps:      1000,
+			gasPerTx: 200000,
+			wantErr:  false,
+		},
+		{
+			name:     "enough block gas",
+			maxGas:   10000000,
+			tps:      50,
+			gasPerTx: 200000,
+			wantErr:  false,
+		},
+		{
+			name:     "exactly enough block gas",
+			maxGas:   1000000,
+			tps:      10,
+			gasPerTx: 100000,
+			wantErr:  false,
+		},
+		{
+			name:     "insufficient block gas",
+			maxGas:   1000000,
+			tps:      11,
+			gasPerTx: 100000,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkBlockGasLimit(tt.maxGas, tt.tps, tt.gasPerTx)
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "consider reducing --tps to 10")
+			} else {
+				assert.NilError(t, err)
+			}
+		})
+	}
+}
+
+func TestCheckChainID(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		actual   string
+		wantErr  bool
+	}{
+		{
+			name:     "matching chain-id",
+			expected: "cosmoshub-4",
+			actual:   "cosmoshub-4",
+			wantErr:  false,
+		},
+		{
+			name:     "mismatching chain-id",
+			expected: "cosmoshub-4",
+			actual:   "theta-testnet-001",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkChainID(tt.expected, tt.actual)
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "chain-id mismatch")
+			} else {
+				assert.NilError(t, err)
+			}
+		})
+	}
+}
+
+// blockTimesRPC returns block metas with the given times, from the latest to the oldest
+type blockTimesRPC struct {
+	rpcclient.Client
+	times []time.Time
+}
+
+func (r blockTimesRPC) BlockchainInfo(context.Context, int64, int64) (*ctypes.ResultBlockchainInfo, error) {
+	metas := make([]*cmttypes.BlockMeta, len(r.times))
+	for i, ts := range r.times {
+		metas[i] = &cmttypes.BlockMeta{Header: cmttypes.Header{Time: ts}}
+	}
+	return &ctypes.ResultBlockchainInfo{BlockMetas: metas}, nil
+}
+
+func TestEstimateBlockTime(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		times    []time.Time
+		expected time.Duration
+		wantErr  bool
+	}{
+		{
+			name:     "regular blocks",
+			times:    []time.Time{now, now.Add(-6 * time.Second), now.Add(-12 * time.Second)},
+			expected: 6 * time.Second,
+		},
+		{
+			name:     "irregular blocks",
+			times:    []time.Time{now, now.Add(-1 * time.Second), now.Add(-4 * time.Second)},
+			expected: 2 * time.Second,
+		},
+		{
+			name:    "single block",
+			times:   []time.Time{now},
+			wantErr: true,
+		},
+		{
+			name:    "identical block times",
+			times:   []time.Time{now, now},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := cosmosclient.Client{RPC: blockTimesRPC{times: tt.times}}
+
+			blockTime, err := estimateBlockTime(context.Background(), client)
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, blockTime, tt.expected)
+		})
+	}
+}
+
+func TestFormatBlockGasTarget(t *testing.T) {
+	tests := []struct {
+		name      string
+		maxGas    int64
+		gasPerTx  uint64
+		blockTime time.Duration
+		expected  string
+	}{
+		{
+			name:      "one second blocks",
+			maxGas:    10000000,
+			gasPerTx:  100000,
+			blockTime: time.Second,
+			expected:  "Block gas limit: 10000000 gas / 100000 gas-per-tx = 100 tx-per-block ≈ 100.0 TPS at 1.0-second blocks",
+		},
+		{
+			name:      "slow blocks",
+			maxGas:    75000000,
+			gasPerTx:  200000,
+			blockTime: 6 * time.Second,
+			expected:  "Block gas limit: 75000000 gas / 200000 gas-per-tx = 375 tx-per-block ≈ 62.5 TPS at 6.0-second blocks",
+		},
+		{
+			name:      "block gas below tx gas",
+			maxGas:    50000,
+			gasPerTx:  100000,
+			blockTime: 5 * time.Second,
+			expected:  "Block gas limit: 50000 gas / 100000 gas-per-tx = 0 tx-per-block ≈ 0.0 TPS at 5.0-second blocks",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, formatBlockGasTarget(tt.maxGas, tt.gasPerTx, tt.blockTime), tt.expected)
+		})
+	}
+}
+
+func TestEstimateGasPerTx(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   Config
+		expected uint64
+	}{
+		{
+			name:     "explicit gas limit",
+			config:   Config{GasLimit: 250000},
+			expected: 250000,
+		},
+		{
+			name:     "heavy mode with default address count",
+			config:   Config{Heavy: true},
+			expected: 200000, // 50000 + 10*15000
+		},
+		{
+			name:     "default estimate",
+			config:   Config{},
+			expected: 100000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, estimateGasPerTx(tt.config), tt.expected)
+		})
+	}
+}
+
+func TestRunSpamLoopStopsAfterMaxErrors(t *testing.T) {
+	config := Config{
+		TPS:       1000,
+		MaxErrors: 3,
+	}
+
+	var calls uint64
+	send := func(ctx context.Context, txNum, sequence uint64) error {
+		calls++
+		return errors.New("broadcast failed")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := runSpamLoop(ctx, config, NewSequencePool(0, sequencePoolSize, sequencePoolThreshold, nil), send, nil)
+	assert.ErrorContains(t, err, "stopping after 3 consecutive errors")
+	assert.ErrorContains(t, err, "broadcast failed")
+	assert.Equal(t, calls, uint64(3))
+}
+
+func TestRunSpamLoopResetsErrorStreakOnSuccess(t *testing.T) {
+	config := Config{
+		TPS:       1000,
+		MaxErrors: 2,
+	}
+
+	// Alternate failures and successes so the streak never reaches the threshold,
+	// then fail twice in a row.
+	var calls uint64
+	send := func(ctx context.Context, txNum, sequence uint64) error {
+		calls++
+		if calls <= 6 && calls%2 == 0 {
+			return nil
+		}
+		return errors.New("broadcast failed")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := runSpamLoop(ctx, config, NewSequencePool(0, sequencePoolSize, sequencePoolThreshold, nil), send, nil)
+	assert.ErrorContains(t, err, "(3 transactions sent)")
+	assert.Equal(t, calls, uint64(8))
+}
+
+func TestRunSpamLoopStopsAfterCount(t *testing.T) {
+	config := Config{
+		TPS:   1000,
+		Count: 5,
+	}
+
+	// Failed transactions do not count towards the total
+	var calls, sequences []uint64
+	send := func(ctx context.Context, txNum, sequence uint64) error {
+		calls = append(calls, txNum)
+		if len(calls) == 2 {
+			return errors.New("broadcast failed")
+		}
+		sequences = append(sequences, sequence)
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := runSpamLoop(ctx, config, NewSequencePool(10, sequencePoolSize, sequencePoolThreshold, nil), send, nil)
+	assert.NilError(t, err)
+	assert.Assert(t, ctx.Err() == nil, "loop should stop before the context is cancelled")
+	assert.Equal(t, len(calls), 6)
+	assert.DeepEqual(t, sequences, []uint64{10, 11, 12, 13, 14})
+}
+
+func TestRunSpamLoopSkippedTransactions(t *testing.T) {
+	config := Config{
+		TPS:       1000,
+		Count:     3,
+		MaxErrors: 1,
+	}
+
+	// Skipped transactions are neither failures nor sent, and their sequence is reused
+	var calls int
+	var sequences []uint64
+	send := func(ctx context.Context, txNum, sequence uint64) error {
+		calls++
+		if calls <= 2 {
+			return errSkipTx
+		}
+		sequences = append(sequences, sequence)
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := runSpamLoop(ctx, config, NewSequencePool(10, sequencePoolSize, sequencePoolThreshold, nil), send, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, calls, 5)
+	assert.DeepEqual(t, sequences, []uint64{10, 11, 12})
+}
+
+func TestRunSpamLoopConcurrentDistinctSequences(t *testing.T) {
+	config := Config{
+		TPS:        100,
+		Concurrent: 4,
+		Count:      20,
+	}
+
+	var mu sync.Mutex
+	var inFlight, maxInFlight int
+	seen := make(map[uint64]bool)
+	send := func(ctx context.Context, txNum, sequence uint64) error {
+		mu.Lock()
+		assert.Check(t, !seen[sequence], "sequence %d handed out twice", sequence)
+		seen[sequence] = true
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		mu.Unlock()
+
+		time.Sleep(time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := runSpamLoop(ctx, config, NewSequencePool(100, sequencePoolSize, sequencePoolThreshold, nil), send, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, len(seen), 20)
+	for sequence := uint64(100); sequence < 120; sequence++ {
+		assert.Check(t, seen[sequence], "sequence %d was not sent", sequence)
+	}
+	assert.Assert(t, maxInFlight <= 4, "at most 4 transactions should be in flight, got %d", maxInFlight)
+}
+
+func TestRunSpamLoopSyncBroadcastMode(t *testing.T) {
+	config := Config{
+		TPS:           1000,
+		Concurrent:    2,
+		Count:         10,
+		BroadcastMode: broadcastModeSync,
+	}
+
+	// Every transaction of a tick completes before the transactions of the next tick start
+	var mu sync.Mutex
+	var completed int
+	send := func(ctx context.Context, txNum, sequence uint64) error {
+		mu.Lock()
+		assert.Check(t, completed%2 == 0, "transaction %d started with %d transactions completed", txNum, completed)
+		mu.Unlock()
+
+		// The transactions of a tick complete at different times
+		time.Sleep(time.Duration(1+sequence%2*10) * time.Millisecond)
+
+		mu.Lock()
+		completed++
+		mu.Unlock()
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := runSpamLoop(ctx, config, NewSequencePool(0, sequencePoolSize, sequencePoolThreshold, nil), send, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, completed, 10)
+}
+
+func TestRunSpamLoopBlockBroadcastMode(t *testing.T) {
+	config := Config{
+		TPS:               1000,
+		Count:             3,
+		BroadcastMode:     broadcastModeBlock,
+		BlockTimeEstimate: 50 * time.Millisecond,
+	}
+
+	var calls atomic.Uint64
+	send := func(ctx context.Context, txNum, sequence uint64) error {
+		calls.Add(1)
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// The ticks are at least a block time apart, despite the TPS
+	start := time.Now()
+	err := runSpamLoop(ctx, config, NewSequencePool(0, sequencePoolSize, sequencePoolThreshold, nil), send, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, calls.Load(), uint64(3))
+	assert.Assert(t, time.Since(start) >= 150*time.Millisecond, "3 ticks took %s", time.Since(start))
+}
+
+func TestRunSpamLoopCooldown(t *testing.T) {
+	config := Config{
+		TPS:       1000,
+		Count:     9,
+		BatchSize: 3,
+		Cooldown:  50 * time.Millisecond,
+	}
+
+	var mu sync.Mutex
+	var sentAt []time.Time
+	send := func(ctx context.Context, txNum, sequence uint64) error {
+		mu.Lock()
+		sentAt = append(sentAt, time.Now())
+		mu.Unlock()
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := runSpamLoop(ctx, config, NewSequencePool(0, sequencePoolSize, sequencePoolThreshold, nil), send, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, len(sentAt), 9)
+
+	// The batches of 3 transactions are separated by a cooldown
+	slices.SortFunc(sentAt, time.Time.Compare)
+	for _, i := range []int{3, 6} {
+		gap := sentAt[i].Sub(sentAt[i-1])
+		assert.Assert(t, gap >= config.Cooldown, "gap before transaction %d: %s", i, gap)
+	}
+}
+
+func TestRunSpamLoopStopsAfterStalls(t *testing.T) {
+	config := Config{
+		TPS:           1000,
+		StallTimeout:  20 * time.Millisecond,
+		StallMaxCount: 2,
+	}
+
+	// The first transactions succeed, then the node goes offline
+	var calls atomic.Uint64
+	send := func(ctx context.Context, txNum, sequence uint64) error {
+		if calls.Add(1) <= 5 {
+			return nil
+		}
+		return errors.New("connection refused")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	err := runSpamLoop(ctx, config, NewSequencePool(0, sequencePoolSize, sequencePoolThreshold, nil), send, nil)
+	assert.ErrorContains(t, err, "stopping after 2 stalls")
+	assert.ErrorContains(t, err, "(5 transactions sent)")
+	assert.Assert(t, time.Since(start) >= 2*config.StallTimeout)
+}
+
+func TestRunSpamLoopBlockThrottle(t *testing.T) {
+	config := Config{
+		TPS:          50,
+		Count:        6,
+		blockMonitor: &blockTimeMonitor{events: make(chan bool)},
+	}
+
+	var mu sync.Mutex
+	var sentAt []time.Time
+	send := func(ctx context.Context, txNum, sequence uint64) error {
+		mu.Lock()
+		sentAt = append(sentAt, time.Now())
+		mu.Unlock()
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Block production stalls before the first transaction, halving the rate of the whole run
+	go func() {
+		config.blockMonitor.events <- true
+	}()
+
+	err := runSpamLoop(ctx, config, NewSequencePool(0, sequencePoolSize, sequencePoolThreshold, nil), send, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, len(sentAt), 6)
+
+	slices.SortFunc(sentAt, time.Time.Compare)
+	period := time.Second / time.Duration(config.TPS)
+	elapsed := sentAt[5].Sub(sentAt[0])
+	assert.Assert(t, elapsed >= 5*blockStallFactor*period*9/10, "6 transactions sent in %s", elapsed)
+}
+
+func TestBatchSize(t *testing.T) {
+	assert.Equal(t, batchSize(Config{TPS: 10}), uint64(10))
+	assert.Equal(t, batchSize(Config{TPS: 10, Concurrent: 4}), uint64(40))
+	assert.Equal(t, batchSize(Config{TPS: 10, BatchSize: 25}), uint64(25))
+}
+
+func TestTargetTPS(t *testing.T) {
+	assert.Equal(t, targetTPS(Config{TPS: 10}), uint64(10))
+	assert.Equal(t, targetTPS(Config{TPS: 10, Concurrent: 1}), uint64(10))
+	assert.Equal(t, targetTPS(Config{TPS: 10, Concurrent: 5}), uint64(50))
+}
+
+func TestTxTimeout(t *testing.T) {
+	assert.Equal(t, txTimeout(Config{}), defaultTxTimeout)
+	assert.Equal(t, txTimeout(Config{TxTimeout: 5 * time.Second}), 5*time.Second)
+}
+
+func TestFilterFeeByDenom(t *testing.T) {
+	tests := []struct {
+		name     string
+		fees     string
+		denom    string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "mixed fees",
+			fees:     "1000uatom,500stake",
+			denom:    "uatom",
+			expected: "1000uatom",
+		},
+		{
+			name:     "single denom",
+			fees:     "1000uatom",
+			denom:    "uatom",
+			expected: "1000uatom",
+		},
+		{
+			name:    "denom absent",
+			fees:    "1000uatom,500stake",
+			denom:   "uosmo",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fees, err := sdk.ParseCoinsNormalized(tt.fees)
+			assert.NilError(t, err)
+
+			filtered, err := filterFeeByDenom(fees, tt.denom)
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "not found in fees")
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, filtered.String(), tt.expected)
+		})
+	}
+}
+
+func TestComputeFeesFromPct(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   string
+		pct      float64
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "whole percentage",
+			amount:   "100000uatom",
+			pct:      1,
+			expected: "1000uatom",
+		},
+		{
+			name:     "fractional percentage",
+			amount:   "100000uatom",
+			pct:      0.5,
+			expected: "500uatom",
+		},
+		{
+			name:     "rounds up",
+			amount:   "1001uatom",
+			pct:      10,
+			expected: "101uatom",
+		},
+		{
+			name:     "tiny fee rounds up to one unit",
+			amount:   "10uatom",
+			pct:      0.01,
+			expected: "1uatom",
+		},
+		{
+			name:     "multiple coins",
+			amount:   "1000stake,2000uatom",
+			pct:      5,
+			expected: "50stake,100uatom",
+		},
+		{
+			name:    "zero percentage",
+			amount:  "1000uatom",
+			pct:     0,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amount, err := parseAmount(tt.amount)
+			assert.NilError(t, err)
+
+			fees, err := computeFeesFromPct(amount, tt.pct)
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, fees, tt.expected)
+		})
+	}
+}
+
+func TestCountErrors(t *testing.T) {
+	var failed atomic.Uint64
+	send := countErrors(&failed, func(ctx context.Context, txNum, sequence uint64) error {
+		if txNum%3 == 0 {
+			return errors.New("broadcast failed")
+		}
+		return nil
+	})
+
+	for txNum := range uint64(7) {
+		_ = send(context.Background(), txNum, txNum)
+	}
+	assert.Equal(t, failed.Load(), uint64(3))
+}
+
+func TestCountErrorsIgnoresCancelledSends(t *testing.T) {
+	config := Config{
+		TPS:        1000,
+		Concurrent: 4,
+	}
+
+	// Every send is still in flight when the run ends
+	var failed atomic.Uint64
+	inFlight := make(chan struct{}, 4)
+	send := countErrors(&failed, func(ctx context.Context, txNum, sequence uint64) error {
+		inFlight <- struct{}{}
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		for range 4 {
+			<-inFlight
+		}
+		cancel()
+	}()
+
+	_, err := spamLoop(ctx, config, NewSequencePool(0, sequencePoolSize, sequencePoolThreshold, nil), send, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, failed.Load(), uint64(0))
+}
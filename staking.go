@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/ignite/cli/v29/ignite/pkg/cosmosaccount"
+	"github.com/ignite/cli/v29/ignite/pkg/cosmosclient"
+)
+
+// validatorPrefixSuffix is appended to the chain bech32 prefix to form the validator operator address prefix
+const validatorPrefixSuffix = "valoper"
+
+// validateValidatorAddress checks that the validator address is a valid bech32 address
+func validateValidatorAddress(flag, address string) error {
+	if _, _, err := bech32.DecodeAndConvert(address); err != nil {
+		return fmt.Errorf("invalid --%s validator address %s: %w", flag, address, err)
+	}
+
+	return nil
+}
+
+// checkValidatorPrefix checks that the validator address is an operator address of the chain
+func checkValidatorPrefix(address, bech32Prefix string) error {
+	if _, err := sdk.GetFromBech32(address, bech32Prefix+validatorPrefixSuffix); err != nil {
+		return fmt.Errorf("invalid validator address %s: %w", address, err)
+	}
+
+	return nil
+}
+
+// stakingAmount parses the amount to undelegate or redelegate, which must be a single coin
+func stakingAmount(amount string) (sdk.Coin, error) {
+	coins, err := parseAmount(amount)
+	if err != nil {
+		return sdk.Coin{}, fmt.Errorf("invalid staking amount: %w", err)
+	}
+
+	if len(coins) != 1 {
+		return sdk.Coin{}, fmt.Errorf("staking amount must be a single coin, got %s", coins)
+	}
+
+	return coins[0], nil
+}
+
+// newUndelegateMsg creates the message undelegating amount from the validator
+func newUndelegateMsg(delegator, validator string, amount sdk.Coin) *stakingtypes.MsgUndelegate {
+	return &stakingtypes.MsgUndelegate{
+		DelegatorAddress: delegator,
+		ValidatorAddress: validator,
+		Amount:           amount,
+	}
+}
+
+// newRedelegateMsg creates the message redelegating amount from the source to the destination validator
+func newRedelegateMsg(delegator, srcValidator, dstValidator string, amount sdk.Coin) *stakingtypes.MsgBeginRedelegate {
+	return &stakingtypes.MsgBeginRedelegate{
+		DelegatorAddress:    delegator,
+		ValidatorSrcAddress: srcValidator,
+		ValidatorDstAddress: dstValidator,
+		Amount:              amount,
+	}
+}
+
+// sendStakingUndelegateTransaction undelegates the staking amount from the validator
+func sendStakingUndelegateTransaction(ctx context.Context, client cosmosclient.Client, account cosmosaccount.Account, config Config, amount sdk.Coin, txNum uint64, addressPrefix, memo string, sequence uint64) error {
+	accountAddr, err := account.Address(addressPrefix)
+	if err != nil {
+		return fmt.Errorf("failed to get account address: %w", err)
+	}
+
+	msg, err := buildMsg(config, accountAddr, newUndelegateMsg(msgSender(config, accountAddr), config.Validator, amount))
+	if err != nil {
+		return err
+	}
+
+	response, err := broadcastTx(ctx, client, account, config, txNum, memo, sequence, msg)
+	if err != nil {
+		return err
+	}
+
+	logger.Debug("🔗 Undelegation broadcasted", "tx", txNum, "sequence", sequence, "hash", response.TxHash, "validator", config.Validator, "amount", amount, "memo", memo)
+
+	return nil
+}
+
+// sendStakingRedelegateTransaction redelegates the staking amount from the source to the destination validator
+func sendStakingRedelegateTransaction(ctx context.Context, client cosmosclient.Client, account cosmosaccount.Account, config Config, amount sdk.Coin, txNum uint64, addressPrefix, memo string, sequence uint64) error {
+	accountAddr, err := account.Address(addressPrefix)
+	if err != nil {
+		return fmt.Errorf("failed to get account address: %w", err)
+	}
+
+	msg, err := buildMsg(config, accountAddr, newRedelegateMsg(msgSender(config, accountAddr), config.ValidatorSrc, config.ValidatorDst, amount))
+	if err != nil {
+		return err
+	}
+
+	response, err := broadcastTx(ctx, client, account, config, txNum, memo, sequence, msg)
+	if err != nil {
+		return err
+	}
+
+	logger.Debug("🔗 Redelegation broadcasted", "tx", txNum, "sequence", sequence, "hash", response.TxHash, "validator_src", config.ValidatorSrc, "validator_dst", config.ValidatorDst, "amount", amount, "memo", memo)
+
+	return nil
+}
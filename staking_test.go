@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/ignite/cli/v29/ignite/pkg/cosmosclient"
+	"gotest.tools/v3/assert"
+)
+
+func TestStakingAmount(t *testing.T) {
+	tests := []struct {
+		amount  string
+		want    sdk.Coin
+		wantErr string
+	}{
+		{amount: "1000uatom", want: sdk.NewCoin("uatom", math.NewInt(1000))},
+		{amount: "1000uatom,5stake", wantErr: "single coin"},
+		{amount: "0uatom", wantErr: "greater than zero"},
+		{amount: "atom", wantErr: "invalid staking amount"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.amount, func(t *testing.T) {
+			got, err := stakingAmount(tt.amount)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, got, tt.want)
+		})
+	}
+}
+
+func TestCheckValidatorPrefix(t *testing.T) {
+	validator := sdk.MustBech32ifyAddressBytes("cosmosvaloper", make([]byte, 20))
+
+	assert.NilError(t, checkValidatorPrefix(validator, "cosmos"))
+	assert.ErrorContains(t, checkValidatorPrefix(validator, "osmo"), "invalid validator address")
+	assert.ErrorContains(t, checkValidatorPrefix(sdk.MustBech32ifyAddressBytes("cosmos", make([]byte, 20)), "cosmos"), "invalid validator address")
+}
+
+// newStakingTestClient creates a mock mode client recording the signed transactions
+func newStakingTestClient(t *testing.T) (cosmosclient.Client, *[]sdk.Tx) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+
+	var modes []signing.SignMode
+	var txs []sdk.Tx
+
+	client, err := cosmosclient.New(context.Background(),
+		cosmosclient.WithRPCClient(newMockRPC()),
+		cosmosclient.WithAccountRetriever(mockAccountRetriever{}),
+		cosmosclient.WithSigner(recordingSigner{modes: &modes, txs: &txs}),
+		cosmosclient.WithBech32Prefix(mockBech32Prefix),
+		cosmosclient.WithKeyringDir(t.TempDir()),
+		cosmosclient.WithKeyringBackend(DefaultKeyringBackend),
+	)
+	assert.NilError(t, err)
+	stakingtypes.RegisterInterfaces(client.Context().InterfaceRegistry)
+
+	return client, &txs
+}
+
+func TestSendStakingUndelegateTransaction(t *testing.T) {
+	client, txs := newStakingTestClient(t)
+
+	account, _, err := client.AccountRegistry.Create("alice")
+	assert.NilError(t, err)
+	accountAddr, err := account.Address(mockBech32Prefix)
+	assert.NilError(t, err)
+
+	validator := sdk.MustBech32ifyAddressBytes(mockBech32Prefix+validatorPrefixSuffix, make([]byte, 20))
+	config := Config{Fees: "1000uatom", GasLimit: 200000, TxType: txTypeStakingUndelegate, Validator: validator}
+	amount := sdk.NewCoin("uatom", math.NewInt(5))
+
+	err = sendStakingUndelegateTransaction(context.Background(), client, account, config, amount, 0, mockBech32Prefix, "undelegate", 1)
+	assert.NilError(t, err)
+
+	assert.Equal(t, len(*txs), 1)
+	msgs := (*txs)[0].GetMsgs()
+	assert.Equal(t, len(msgs), 1)
+	assert.DeepEqual(t, msgs[0], newUndelegateMsg(accountAddr, validator, amount))
+}
+
+func TestSendStakingRedelegateTransaction(t *testing.T) {
+	client, txs := newStakingTestClient(t)
+
+	account, _, err := client.AccountRegistry.Create("alice")
+	assert.NilError(t, err)
+	accountAddr, err := account.Address(mockBech32Prefix)
+	assert.NilError(t, err)
+
+	srcValidator := sdk.MustBech32ifyAddressBytes(mockBech32Prefix+validatorPrefixSuffix, make([]byte, 20))
+	dstValidator := sdk.MustBech32ifyAddressBytes(mockBech32Prefix+validatorPrefixSuffix, append(make([]byte, 19), 1))
+	config := Config{Fees: "1000uatom", GasLimit: 200000, TxType: txTypeStakingRedelegate, ValidatorSrc: srcValidator, ValidatorDst: dstValidator}
+	amount := sdk.NewCoin("uatom", math.NewInt(5))
+
+	err = sendStakingRedelegateTransaction(context.Background(), client, account, config, amount, 0, mockBech32Prefix, "redelegate", 1)
+	assert.NilError(t, err)
+
+	assert.Equal(t, len(*txs), 1)
+	msgs := (*txs)[0].GetMsgs()
+	assert.Equal(t, len(msgs), 1)
+	assert.DeepEqual(t, msgs[0], newRedelegateMsg(accountAddr, srcValidator, dstValidator, amount))
+}
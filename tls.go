@@ -0,0 +1,77 @@
+package main
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"os"
+
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	jsonrpcclient "github.com/cometbft/cometbft/rpc/jsonrpc/client"
+)
+
+// mainnetChainIDs are the chain IDs of well-known mainnets, on which TLS verification cannot be skipped
+var mainnetChainIDs = map[string]bool{
+	"agoric-3":         true,
+	"akashnet-2":       true,
+	"archway-1":        true,
+	"axelar-dojo-1":    true,
+	"celestia":         true,
+	"cosmoshub-4":      true,
+	"dydx-mainnet-1":   true,
+	"dymension_1100-1": true,
+	"evmos_9001-2":     true,
+	"injective-1":      true,
+	"juno-1":           true,
+	"kaiyo-1":          true,
+	"kava_2222-10":     true,
+	"neutron-1":        true,
+	"noble-1":          true,
+	"osmosis-1":        true,
+	"pacific-1":        true,
+	"phoenix-1":        true,
+	"secret-4":         true,
+	"sommelier-3":      true,
+	"stargaze-1":       true,
+	"stride-1":         true,
+	"umee-1":           true,
+	"xion-mainnet-1":   true,
+}
+
+// checkInsecureSkipTLS refuses to skip TLS verification on a mainnet
+func checkInsecureSkipTLS(chainID string) error {
+	if mainnetChainIDs[chainID] {
+		return fmt.Errorf("skipping TLS verification is not allowed on mainnet chain %s", chainID)
+	}
+
+	return nil
+}
+
+// warnInsecureSkipTLS prints a warning that TLS certificates are not verified
+func warnInsecureSkipTLS() {
+	fmt.Fprintln(os.Stderr, "⚠️⚠️⚠️ WARNING: TLS CERTIFICATE VERIFICATION IS DISABLED (--insecure-skip-tls) ⚠️⚠️⚠️")
+	fmt.Fprintln(os.Stderr, "⚠️ The RPC endpoint identity is not verified, only use this with private testnets")
+}
+
+// newInsecureRPC creates an RPC client that does not verify the TLS certificate of the endpoint
+func newInsecureRPC(endpoint string) (*rpchttp.HTTP, error) {
+	httpClient, err := jsonrpcclient.DefaultHTTPClient(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+
+	transport, ok := httpClient.Transport.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("unexpected HTTP transport %T", httpClient.Transport)
+	}
+	transport.TLSClientConfig = &tls.Config{
+		InsecureSkipVerify: true,
+	}
+
+	rpc, err := rpchttp.NewWithClient(endpoint, "/websocket", httpClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create RPC client: %w", err)
+	}
+
+	return rpc, nil
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	"gotest.tools/v3/assert"
+)
+
+func TestCheckInsecureSkipTLS(t *testing.T) {
+	tests := []struct {
+		name    string
+		chainID string
+		wantErr bool
+	}{
+		{
+			name:    "mainnet",
+			chainID: "osmosis-1",
+			wantErr: true,
+		},
+		{
+			name:    "testnet",
+			chainID: "osmo-test-5",
+			wantErr: false,
+		},
+		{
+			name:    "unknown chain id",
+			chainID: "",
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkInsecureSkipTLS(tt.chainID)
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "not allowed on mainnet")
+			} else {
+				assert.NilError(t, err)
+			}
+		})
+	}
+}
+
+func TestNewInsecureRPC(t *testing.T) {
+	// Self-signed TLS server answering every JSON-RPC call with an empty result
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID json.RawMessage `json:"id"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result":  map[string]any{},
+		})
+	}))
+	defer server.Close()
+
+	// The certificate is rejected by default
+	rpc, err := rpchttp.New(server.URL, "/websocket")
+	assert.NilError(t, err)
+	_, err = rpc.Health(context.Background())
+	assert.ErrorContains(t, err, "certificate")
+
+	insecureRPC, err := newInsecureRPC(server.URL)
+	assert.NilError(t, err)
+	_, err = insecureRPC.Health(context.Background())
+	assert.NilError(t, err)
+}
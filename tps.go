@@ -0,0 +1,59 @@
+package main
+
+import "time"
+
+// tpsWindowSize is the number of successful broadcasts used to measure the actual TPS
+const tpsWindowSize = 100
+
+// tpsTracker measures the actual TPS over a sliding window of successful broadcasts
+type tpsTracker struct {
+	timestamps []time.Time
+	next       int
+	full       bool
+}
+
+// newTPSTracker creates a tracker keeping the timestamps of the last size broadcasts
+func newTPSTracker(size int) *tpsTracker {
+	return &tpsTracker{
+		timestamps: make([]time.Time, size),
+	}
+}
+
+// Record adds the timestamp of a successful broadcast to the window
+func (t *tpsTracker) Record(ts time.Time) {
+	t.timestamps[t.next] = ts
+	t.next = (t.next + 1) % len(t.timestamps)
+	if t.next == 0 {
+		t.full = true
+	}
+}
+
+// Len returns the number of timestamps currently in the window
+func (t *tpsTracker) Len() int {
+	if t.full {
+		return len(t.timestamps)
+	}
+	return t.next
+}
+
+// TPS returns the measured rate over the window.
+// N timestamps span N-1 intervals, so at least two broadcasts are needed.
+func (t *tpsTracker) TPS() float64 {
+	n := t.Len()
+	if n < 2 {
+		return 0
+	}
+
+	oldest := t.timestamps[0]
+	if t.full {
+		oldest = t.timestamps[t.next]
+	}
+	newest := t.timestamps[(t.next-1+len(t.timestamps))%len(t.timestamps)]
+
+	elapsed := newest.Sub(oldest).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+
+	return float64(n-1) / elapsed
+}
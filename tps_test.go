@@ -0,0 +1,67 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestTPSTracker(t *testing.T) {
+	start := time.Now()
+
+	tests := []struct {
+		name     string
+		size     int
+		records  int
+		interval time.Duration
+		wantLen  int
+		wantTPS  float64
+	}{
+		{
+			name:     "empty window",
+			size:     10,
+			records:  0,
+			interval: 100 * time.Millisecond,
+			wantLen:  0,
+			wantTPS:  0,
+		},
+		{
+			name:     "single broadcast",
+			size:     10,
+			records:  1,
+			interval: 100 * time.Millisecond,
+			wantLen:  1,
+			wantTPS:  0,
+		},
+		{
+			name:     "partially filled window",
+			size:     10,
+			records:  5,
+			interval: 100 * time.Millisecond,
+			wantLen:  5,
+			wantTPS:  10,
+		},
+		{
+			name:     "wrapped window",
+			size:     10,
+			records:  25,
+			interval: 50 * time.Millisecond,
+			wantLen:  10,
+			wantTPS:  20,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := newTPSTracker(tt.size)
+			for i := 0; i < tt.records; i++ {
+				tracker.Record(start.Add(time.Duration(i) * tt.interval))
+			}
+
+			assert.Equal(t, tracker.Len(), tt.wantLen)
+			assert.Assert(t, tracker.TPS() > tt.wantTPS-0.01 && tracker.TPS() < tt.wantTPS+0.01,
+				"expected %.2f TPS, got %.2f", tt.wantTPS, tracker.TPS())
+		})
+	}
+}
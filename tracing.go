@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation name of the spamtx tracer
+const tracerName = "github.com/julienrbrt/spamtx"
+
+// initTracer registers a global trace provider exporting spans to an OpenTelemetry collector.
+// The endpoint is either host:port (insecure) or a http(s):// URL.
+func initTracer(ctx context.Context, endpoint string) (*sdktrace.TracerProvider, error) {
+	var opts []otlptracegrpc.Option
+	if strings.Contains(endpoint, "://") {
+		opts = append(opts, otlptracegrpc.WithEndpointURL(endpoint))
+	} else {
+		opts = append(opts, otlptracegrpc.WithEndpoint(endpoint), otlptracegrpc.WithInsecure())
+	}
+
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OpenTelemetry exporter: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceName("spamtx"),
+		)),
+	)
+	otel.SetTracerProvider(tp)
+
+	return tp, nil
+}
+
+// startTxSpan starts a span for a single transaction.
+// Without a configured trace provider, the span is a no-op.
+func startTxSpan(ctx context.Context, config Config, txNum, sequence uint64) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, "broadcast_tx", trace.WithAttributes(
+		attribute.String("chain", config.Chain),
+		attribute.Int64("tx_num", int64(txNum)),
+		attribute.Int64("sequence", int64(sequence)),
+	))
+}
+
+// endTxSpan records the broadcast outcome on the span and ends it
+func endTxSpan(span trace.Span, latency time.Duration, code uint32, err error) {
+	span.SetAttributes(
+		attribute.Int64("broadcast_latency_ms", latency.Milliseconds()),
+		attribute.Int64("error_code", int64(code)),
+	)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
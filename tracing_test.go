@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gotest.tools/v3/assert"
+)
+
+func TestTxSpanAttributes(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+	})
+
+	config := Config{Chain: "cosmoshub"}
+
+	_, span := startTxSpan(context.Background(), config, 3, 42)
+	endTxSpan(span, 150*time.Millisecond, 0, nil)
+
+	_, span = startTxSpan(context.Background(), config, 4, 43)
+	endTxSpan(span, 20*time.Millisecond, 32, errors.New("account sequence mismatch"))
+
+	spans := recorder.Ended()
+	assert.Equal(t, len(spans), 2)
+
+	attrs := func(s sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+		m := make(map[attribute.Key]attribute.Value)
+		for _, kv := range s.Attributes() {
+			m[kv.Key] = kv.Value
+		}
+		return m
+	}
+
+	ok := attrs(spans[0])
+	assert.Equal(t, ok["chain"].AsString(), "cosmoshub")
+	assert.Equal(t, ok["tx_num"].AsInt64(), int64(3))
+	assert.Equal(t, ok["sequence"].AsInt64(), int64(42))
+	assert.Equal(t, ok["broadcast_latency_ms"].AsInt64(), int64(150))
+	assert.Equal(t, ok["error_code"].AsInt64(), int64(0))
+	assert.Equal(t, spans[0].Status().Code, codes.Unset)
+
+	failed := attrs(spans[1])
+	assert.Equal(t, failed["error_code"].AsInt64(), int64(32))
+	assert.Equal(t, spans[1].Status().Code, codes.Error)
+}
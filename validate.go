@@ -0,0 +1,307 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/ignite/cli/v29/ignite/pkg/chainregistry"
+	"github.com/ignite/cli/v29/ignite/pkg/cosmosclient"
+)
+
+// validationCheck is the outcome of a single chain registry consistency check
+type validationCheck struct {
+	Name   string
+	Passed bool
+	Detail string
+	Fix    string
+}
+
+// validateChainRegistry fetches a chain from the registry and checks its data consistency
+func validateChainRegistry(ctx context.Context, chainName string) ([]validationCheck, error) {
+	registry, err := loadChainRegistry()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch chains: %w", err)
+	}
+
+	chain, err := registry.Get(chainName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := registry.Enrich(&chain); err != nil {
+		return nil, fmt.Errorf("failed to enrich chain '%s': %w", chainName, err)
+	}
+
+	var checks []validationCheck
+	var reachableRPC string
+
+	if len(chain.APIs.RPC) == 0 {
+		checks = append(checks, validationCheck{
+			Name: "RPC endpoints",
+			Fix:  "add at least one RPC endpoint to the chain registry entry",
+		})
+	}
+	for _, api := range chain.APIs.RPC {
+		check := checkRPCEndpoint(ctx, api.Address)
+		if check.Passed && reachableRPC == "" {
+			reachableRPC = api.Address
+		}
+		checks = append(checks, check)
+	}
+
+	if len(chain.APIs.Grpc) == 0 {
+		checks = append(checks, validationCheck{
+			Name: "gRPC endpoints",
+			Fix:  "add at least one gRPC endpoint (host:port) to the chain registry entry",
+		})
+	}
+	for _, api := range chain.APIs.Grpc {
+		checks = append(checks, checkGRPCEndpoint(ctx, api.Address))
+	}
+
+	checks = append(checks, checkBech32Prefix(ctx, reachableRPC, chain.Bech32Prefix))
+	checks = append(checks, checkMinGasPrices(chain.Fees.FeeTokens))
+	checks = append(checks, checkFeeDenomSupply(ctx, reachableRPC, chain))
+
+	return checks, nil
+}
+
+// checkRPCEndpoint verifies that the RPC endpoint answers its /status route
+func checkRPCEndpoint(ctx context.Context, address string) validationCheck {
+	check := validationCheck{
+		Name: fmt.Sprintf("RPC %s", address),
+		Fix:  "remove or update the unreachable RPC endpoint in the chain registry",
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, strings.TrimSuffix(address, "/")+"/status", nil)
+	if err != nil {
+		check.Detail = fmt.Sprintf("invalid address: %v", err)
+		return check
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		check.Detail = fmt.Sprintf("request failed: %v", err)
+		return check
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		check.Detail = fmt.Sprintf("unexpected status code %d", resp.StatusCode)
+		return check
+	}
+
+	check.Passed = true
+	return check
+}
+
+// checkGRPCEndpoint verifies that a TCP connection can be opened to the gRPC endpoint
+func checkGRPCEndpoint(ctx context.Context, address string) validationCheck {
+	check := validationCheck{
+		Name: fmt.Sprintf("gRPC %s", address),
+		Fix:  "remove or update the unreachable gRPC endpoint in the chain registry",
+	}
+
+	dialer := net.Dialer{Timeout: 5 * time.Second}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		check.Detail = fmt.Sprintf("connection failed: %v", err)
+		return check
+	}
+	_ = conn.Close()
+
+	check.Passed = true
+	return check
+}
+
+// checkBech32Prefix verifies that the registry prefix is the bech32 prefix of the chain, queried over the reachable RPC endpoint
+func checkBech32Prefix(ctx context.Context, rpcEndpoint, prefix string) validationCheck {
+	check := validationCheck{
+		Name: "bech32 prefix",
+		Fix:  "set bech32_prefix in the chain registry entry to the bech32 prefix of the chain",
+	}
+
+	if prefix == "" {
+		check.Detail = "no bech32 prefix set"
+		return check
+	}
+
+	if rpcEndpoint == "" {
+		check.Detail = "no reachable RPC endpoint to query"
+		check.Fix = "fix the RPC endpoints first"
+		return check
+	}
+
+	client, err := newValidationClient(ctx, rpcEndpoint, prefix)
+	if err != nil {
+		check.Detail = err.Error()
+		return check
+	}
+
+	chainPrefix, err := queryBech32Prefix(ctx, authtypes.NewQueryClient(client.Context()))
+	if err != nil {
+		check.Detail = err.Error()
+		return check
+	}
+
+	if chainPrefix != prefix {
+		check.Detail = fmt.Sprintf("chain prefix is %s, registry prefix is %s", chainPrefix, prefix)
+		return check
+	}
+
+	check.Passed = true
+	check.Detail = prefix
+	return check
+}
+
+// queryBech32Prefix returns the bech32 account prefix of the chain x/auth module
+func queryBech32Prefix(ctx context.Context, queryClient authtypes.QueryClient) (string, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	resp, err := queryClient.Bech32Prefix(queryCtx, &authtypes.Bech32PrefixRequest{})
+	if err != nil {
+		return "", fmt.Errorf("failed to query bech32 prefix: %w", err)
+	}
+
+	return resp.Bech32Prefix, nil
+}
+
+// checkMinGasPrices verifies that every fee token declares a minimum gas price
+func checkMinGasPrices(feeTokens []chainregistry.FeeToken) validationCheck {
+	check := validationCheck{
+		Name: "minimum gas prices",
+		Fix:  "set fixed_min_gas_price or low_gas_price for each fee token in the chain registry entry",
+	}
+
+	if len(feeTokens) == 0 {
+		check.Detail = "no fee tokens set"
+		return check
+	}
+
+	var missing []string
+	for _, token := range feeTokens {
+		if token.FixedMinGasPrice <= 0 && token.LowGasPrice <= 0 {
+			missing = append(missing, token.Denom)
+		}
+	}
+
+	if len(missing) > 0 {
+		check.Detail = fmt.Sprintf("no minimum gas price for %s", strings.Join(missing, ", "))
+		return check
+	}
+
+	check.Passed = true
+	return check
+}
+
+// checkFeeDenomSupply verifies that the first fee denom has a non-zero supply on chain
+func checkFeeDenomSupply(ctx context.Context, rpcEndpoint string, chain chainregistry.Chain) validationCheck {
+	check := validationCheck{
+		Name: "fee denom supply",
+		Fix:  "make sure the fee token denom in the chain registry matches an on-chain denom",
+	}
+
+	if len(chain.Fees.FeeTokens) == 0 {
+		check.Detail = "no fee tokens set"
+		return check
+	}
+	denom := chain.Fees.FeeTokens[0].Denom
+	check.Name = fmt.Sprintf("fee denom %s supply", denom)
+
+	if rpcEndpoint == "" {
+		check.Detail = "no reachable RPC endpoint to query"
+		check.Fix = "fix the RPC endpoints first"
+		return check
+	}
+
+	client, err := newValidationClient(ctx, rpcEndpoint, chain.Bech32Prefix)
+	if err != nil {
+		check.Detail = err.Error()
+		return check
+	}
+
+	queryCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	resp, err := banktypes.NewQueryClient(client.Context()).SupplyOf(queryCtx, &banktypes.QuerySupplyOfRequest{
+		Denom: denom,
+	})
+	if err != nil {
+		check.Detail = fmt.Sprintf("failed to query supply: %v", err)
+		return check
+	}
+
+	if resp.Amount.IsZero() {
+		check.Detail = "denom has no supply"
+		return check
+	}
+
+	check.Passed = true
+	check.Detail = resp.Amount.String()
+	return check
+}
+
+// newValidationClient creates a cosmos client querying the chain over the RPC endpoint
+func newValidationClient(ctx context.Context, rpcEndpoint, bech32Prefix string) (cosmosclient.Client, error) {
+	keyringDir, err := getKeyringHome()
+	if err != nil {
+		return cosmosclient.Client{}, fmt.Errorf("failed to get keyring home: %w", err)
+	}
+
+	client, err := cosmosclient.New(ctx,
+		cosmosclient.WithNodeAddress(rpcEndpoint),
+		cosmosclient.WithBech32Prefix(bech32Prefix),
+		cosmosclient.WithKeyringDir(keyringDir),
+		cosmosclient.WithKeyringBackend(DefaultKeyringBackend),
+		cosmosclient.WithKeyringServiceName(DefaultKeyringServiceName),
+	)
+	if err != nil {
+		return cosmosclient.Client{}, fmt.Errorf("failed to create cosmos client: %w", err)
+	}
+
+	return client, nil
+}
+
+// printValidationReport prints the checks under title and returns an error if any of them failed
+func printValidationReport(title string, checks []validationCheck) error {
+	fmt.Printf("%s:\n", title)
+
+	var failed int
+	for _, check := range checks {
+		if check.Passed {
+			if check.Detail != "" {
+				fmt.Printf("✅ %s (%s)\n", check.Name, check.Detail)
+			} else {
+				fmt.Printf("✅ %s\n", check.Name)
+			}
+			continue
+		}
+
+		failed++
+		if check.Detail != "" {
+			fmt.Printf("❌ %s: %s\n", check.Name, check.Detail)
+		} else {
+			fmt.Printf("❌ %s\n", check.Name)
+		}
+		fmt.Printf("   💡 %s\n", check.Fix)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d checks failed", failed, len(checks))
+	}
+
+	fmt.Printf("All %d checks passed.\n", len(checks))
+	return nil
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/ignite/cli/v29/ignite/pkg/chainregistry"
+	"google.golang.org/grpc"
+	"gotest.tools/v3/assert"
+)
+
+func TestCheckRPCEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/status" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	check := checkRPCEndpoint(context.Background(), server.URL)
+	assert.Assert(t, check.Passed, check.Detail)
+
+	check = checkRPCEndpoint(context.Background(), server.URL+"/unknown")
+	assert.Assert(t, !check.Passed)
+	assert.Assert(t, check.Fix != "")
+}
+
+func TestCheckBech32Prefix(t *testing.T) {
+	tests := []struct {
+		name        string
+		rpcEndpoint string
+		prefix      string
+		wantDetail  string
+	}{
+		{
+			name:        "empty prefix",
+			rpcEndpoint: "http://localhost:26657",
+			wantDetail:  "no bech32 prefix set",
+		},
+		{
+			name:       "no reachable RPC endpoint",
+			prefix:     "cosmos",
+			wantDetail: "no reachable RPC endpoint to query",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := checkBech32Prefix(context.Background(), tt.rpcEndpoint, tt.prefix)
+			assert.Assert(t, !check.Passed)
+			assert.Equal(t, check.Detail, tt.wantDetail)
+		})
+	}
+}
+
+// bech32PrefixQueryClient answers the x/auth Bech32Prefix query
+type bech32PrefixQueryClient struct {
+	authtypes.QueryClient
+	prefix string
+	err    error
+}
+
+func (c bech32PrefixQueryClient) Bech32Prefix(context.Context, *authtypes.Bech32PrefixRequest, ...grpc.CallOption) (*authtypes.Bech32PrefixResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &authtypes.Bech32PrefixResponse{Bech32Prefix: c.prefix}, nil
+}
+
+func TestQueryBech32Prefix(t *testing.T) {
+	prefix, err := queryBech32Prefix(context.Background(), bech32PrefixQueryClient{prefix: "osmo"})
+	assert.NilError(t, err)
+	assert.Equal(t, prefix, "osmo")
+
+	_, err = queryBech32Prefix(context.Background(), bech32PrefixQueryClient{err: errors.New("unknown query path")})
+	assert.ErrorContains(t, err, "failed to query bech32 prefix: unknown query path")
+}
+
+func TestCheckMinGasPrices(t *testing.T) {
+	tests := []struct {
+		name      string
+		feeTokens []chainregistry.FeeToken
+		passed    bool
+	}{
+		{
+			name: "fixed min gas price set",
+			feeTokens: []chainregistry.FeeToken{
+				{Denom: "uatom", FixedMinGasPrice: 0.005},
+			},
+			passed: true,
+		},
+		{
+			name: "low gas price set",
+			feeTokens: []chainregistry.FeeToken{
+				{Denom: "uosmo", LowGasPrice: 0.0025},
+			},
+			passed: true,
+		},
+		{
+			name: "one token missing gas prices",
+			feeTokens: []chainregistry.FeeToken{
+				{Denom: "uatom", FixedMinGasPrice: 0.005},
+				{Denom: "stake"},
+			},
+			passed: false,
+		},
+		{
+			name:      "no fee tokens",
+			feeTokens: nil,
+			passed:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := checkMinGasPrices(tt.feeTokens)
+			assert.Equal(t, check.Passed, tt.passed, check.Detail)
+		})
+	}
+}
+
+func TestPrintValidationReport(t *testing.T) {
+	err := printValidationReport("Chain registry validation for 'cosmoshub'", []validationCheck{
+		{Name: "bech32 prefix", Passed: true},
+	})
+	assert.NilError(t, err)
+
+	err = printValidationReport("Chain registry validation for 'cosmoshub'", []validationCheck{
+		{Name: "bech32 prefix", Passed: true},
+		{Name: "minimum gas prices", Detail: "no fee tokens set", Fix: "set gas prices"},
+	})
+	assert.ErrorContains(t, err, "1 of 2 checks failed")
+}
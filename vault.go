@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/ignite/cli/v29/ignite/pkg/cosmosaccount"
+)
+
+const (
+	// keyringBackendVault reads the account key from HashiCorp Vault into an in-memory keyring
+	keyringBackendVault cosmosaccount.KeyringBackend = "vault"
+
+	// vaultAddrEnv and vaultTokenEnv are the environment variables of the Vault server address and token, as used by the Vault CLI
+	vaultAddrEnv  = "VAULT_ADDR"
+	vaultTokenEnv = "VAULT_TOKEN"
+)
+
+// errVaultKeyringReadOnly is returned by the keyring subcommands, which cannot manage the keys stored in Vault
+var errVaultKeyringReadOnly = errors.New("the vault keyring backend is read-only, manage the keys in vault directly")
+
+// vaultSecret is the account key stored in Vault, either a mnemonic or a hex encoded secp256k1 private key
+type vaultSecret struct {
+	Mnemonic   string `json:"mnemonic"`
+	PrivateKey string `json:"private_key"`
+}
+
+// VaultKeyringBackend reads the key of the account from a Vault KV secret (v1 or v2 engine)
+// and imports it into the in-memory keyring of a cosmos client, so that the key never touches the disk.
+type VaultKeyringBackend struct {
+	// Address is the base URL of the Vault server
+	Address string
+	// Token authenticates the requests to Vault
+	Token string
+	// Path is the path of the secret, including the mount, e.g. secret/data/spamtx/alice for a KV v2 engine
+	Path string
+
+	httpClient *http.Client
+}
+
+// NewVaultKeyringBackend creates a Vault keyring backend reading the secret at path,
+// with the server address and token read from VAULT_ADDR and VAULT_TOKEN
+func NewVaultKeyringBackend(path string) (*VaultKeyringBackend, error) {
+	address := os.Getenv(vaultAddrEnv)
+	if address == "" {
+		return nil, fmt.Errorf("%s must be set to use the vault keyring backend", vaultAddrEnv)
+	}
+
+	token := os.Getenv(vaultTokenEnv)
+	if token == "" {
+		return nil, fmt.Errorf("%s must be set to use the vault keyring backend", vaultTokenEnv)
+	}
+
+	return &VaultKeyringBackend{
+		Address: address,
+		Token:   token,
+		Path:    path,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}, nil
+}
+
+// ReadSecret reads the account key from Vault
+func (v *VaultKeyringBackend) ReadSecret(ctx context.Context) (vaultSecret, error) {
+	url := fmt.Sprintf("%s/v1/%s", strings.TrimSuffix(v.Address, "/"), strings.TrimPrefix(v.Path, "/"))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return vaultSecret{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", v.Token)
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return vaultSecret{}, fmt.Errorf("failed to read vault secret %s: %w", v.Path, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return vaultSecret{}, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return vaultSecret{}, fmt.Errorf("failed to read vault secret %s: vault returned status %d", v.Path, resp.StatusCode)
+	}
+
+	// A KV v1 engine returns the secret in data, a KV v2 engine in data.data
+	var secretResp struct {
+		Data struct {
+			vaultSecret
+			Data *vaultSecret `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &secretResp); err != nil {
+		return vaultSecret{}, fmt.Errorf("failed to unmarshal vault response: %w", err)
+	}
+
+	secret := secretResp.Data.vaultSecret
+	if secretResp.Data.Data != nil {
+		secret = *secretResp.Data.Data
+	}
+
+	if secret.Mnemonic == "" && secret.PrivateKey == "" {
+		return vaultSecret{}, fmt.Errorf("vault secret %s has neither a mnemonic nor a private_key field", v.Path)
+	}
+
+	return secret, nil
+}
+
+// Load reads the account key from Vault and imports it in the registry under name
+func (v *VaultKeyringBackend) Load(ctx context.Context, registry cosmosaccount.Registry, name string) error {
+	secret, err := v.ReadSecret(ctx)
+	if err != nil {
+		return err
+	}
+
+	if secret.Mnemonic != "" {
+		if _, err := registry.Import(name, secret.Mnemonic, ""); err != nil {
+			return fmt.Errorf("failed to import account '%s' from vault: %w", name, err)
+		}
+		return nil
+	}
+
+	if err := registry.Keyring.ImportPrivKeyHex(name, secret.PrivateKey, string(hd.Secp256k1Type)); err != nil {
+		return fmt.Errorf("failed to import account '%s' from vault: %w", name, err)
+	}
+
+	return nil
+}
+
+// clientKeyringBackend returns the keyring backend of the cosmos client, in memory for the vault backend
+func clientKeyringBackend(backend cosmosaccount.KeyringBackend) cosmosaccount.KeyringBackend {
+	if backend == keyringBackendVault {
+		return cosmosaccount.KeyringMemory
+	}
+
+	return backend
+}
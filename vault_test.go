@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ignite/cli/v29/ignite/pkg/cosmosaccount"
+	"gotest.tools/v3/assert"
+)
+
+const (
+	testVaultToken = "s.test-token"
+	// testMnemonic is a well-known test mnemonic, never holding any funds
+	testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+)
+
+// testPrivateKey returns the hex encoded private key derived from testMnemonic
+func testPrivateKey(t *testing.T) string {
+	t.Helper()
+
+	privateKey, err := hd.Secp256k1.Derive()(testMnemonic, "", hd.CreateHDPath(sdk.CoinType, 0, 0).String())
+	assert.NilError(t, err)
+
+	return hex.EncodeToString(privateKey)
+}
+
+// newVaultServer starts a mock Vault HTTP API serving a KV v1 and a KV v2 secret
+func newVaultServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	privateKey := testPrivateKey(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != testVaultToken {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors": ["permission denied"]}`))
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1/kv/spamtx/alice":
+			_, _ = w.Write([]byte(`{"data": {"mnemonic": "` + testMnemonic + `"}}`))
+		case "/v1/secret/data/spamtx/alice":
+			_, _ = w.Write([]byte(`{"data": {"data": {"private_key": "` + privateKey + `"}, "metadata": {"version": 1}}}`))
+		case "/v1/secret/data/spamtx/empty":
+			_, _ = w.Write([]byte(`{"data": {"data": {"password": "hunter2"}, "metadata": {"version": 1}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors": []}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestVaultKeyringBackendReadSecret(t *testing.T) {
+	server := newVaultServer(t)
+
+	tests := []struct {
+		name    string
+		path    string
+		token   string
+		expect  vaultSecret
+		wantErr string
+	}{
+		{
+			name:   "kv v1 secret",
+			path:   "kv/spamtx/alice",
+			token:  testVaultToken,
+			expect: vaultSecret{Mnemonic: testMnemonic},
+		},
+		{
+			name:   "kv v2 secret",
+			path:   "/secret/data/spamtx/alice",
+			token:  testVaultToken,
+			expect: vaultSecret{PrivateKey: testPrivateKey(t)},
+		},
+		{
+			name:    "secret without key",
+			path:    "secret/data/spamtx/empty",
+			token:   testVaultToken,
+			wantErr: "neither a mnemonic nor a private_key",
+		},
+		{
+			name:    "missing secret",
+			path:    "secret/data/spamtx/bob",
+			token:   testVaultToken,
+			wantErr: "status 404",
+		},
+		{
+			name:    "invalid token",
+			path:    "kv/spamtx/alice",
+			token:   "s.invalid",
+			wantErr: "status 403",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(vaultAddrEnv, server.URL+"/")
+			t.Setenv(vaultTokenEnv, tt.token)
+
+			vault, err := NewVaultKeyringBackend(tt.path)
+			assert.NilError(t, err)
+
+			secret, err := vault.ReadSecret(context.Background())
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, secret, tt.expect)
+		})
+	}
+}
+
+func TestNewVaultKeyringBackendRequiresEnv(t *testing.T) {
+	t.Setenv(vaultAddrEnv, "")
+	t.Setenv(vaultTokenEnv, testVaultToken)
+	_, err := NewVaultKeyringBackend("kv/spamtx/alice")
+	assert.ErrorContains(t, err, vaultAddrEnv)
+
+	t.Setenv(vaultAddrEnv, "http://127.0.0.1:8200")
+	t.Setenv(vaultTokenEnv, "")
+	_, err = NewVaultKeyringBackend("kv/spamtx/alice")
+	assert.ErrorContains(t, err, vaultTokenEnv)
+}
+
+func TestVaultKeyringBackendLoad(t *testing.T) {
+	server := newVaultServer(t)
+	t.Setenv(vaultAddrEnv, server.URL)
+	t.Setenv(vaultTokenEnv, testVaultToken)
+
+	// The mnemonic and the private key of the same account are loaded to the same address
+	var addresses []string
+	for _, path := range []string{"kv/spamtx/alice", "secret/data/spamtx/alice"} {
+		registry, err := cosmosaccount.NewInMemory(cosmosaccount.WithBech32Prefix("cosmos"))
+		assert.NilError(t, err)
+
+		vault, err := NewVaultKeyringBackend(path)
+		assert.NilError(t, err)
+		assert.NilError(t, vault.Load(context.Background(), registry, "alice"))
+
+		account, err := registry.GetByName("alice")
+		assert.NilError(t, err)
+		address, err := account.Address("cosmos")
+		assert.NilError(t, err)
+		addresses = append(addresses, address)
+	}
+	assert.Equal(t, addresses[0], addresses[1])
+}
+
+func TestInitializeKeyringRejectsVault(t *testing.T) {
+	_, _, err := initializeKeyring("cosmoshub", KeyringOptions{
+		RPC:          "http://localhost:26657",
+		Bech32Prefix: "cosmos",
+		Backend:      keyringBackendVault,
+	})
+	assert.ErrorIs(t, err, errVaultKeyringReadOnly)
+}
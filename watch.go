@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/ignite/cli/v29/ignite/pkg/cosmosclient"
+)
+
+const (
+	// inclusionPollInterval is the delay between two transaction lookups
+	inclusionPollInterval = 500 * time.Millisecond
+	// inclusionTimeout is the maximum time to wait for a transaction to be included in a block
+	inclusionTimeout = 30 * time.Second
+	// startAfterPollInterval is the delay between two block height lookups while waiting to start
+	startAfterPollInterval = 2 * time.Second
+	// syncPollInterval is the delay between two node status lookups while waiting for the node to sync
+	syncPollInterval = 5 * time.Second
+)
+
+// waitForInclusion polls the node until the transaction is included in a block or the timeout expires.
+// It returns the height of the block including the transaction.
+func waitForInclusion(ctx context.Context, client cosmosclient.Client, hash string, timeout time.Duration) (int64, error) {
+	bz, err := hex.DecodeString(hash)
+	if err != nil {
+		return 0, fmt.Errorf("invalid transaction hash %s: %w", hash, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(inclusionPollInterval)
+	defer ticker.Stop()
+
+	start := time.Now()
+	for {
+		resp, err := client.RPC.Tx(ctx, bz, false)
+		if err == nil {
+			if resp.TxResult.Code != 0 {
+				return resp.Height, fmt.Errorf("transaction %s included at height %d but failed with code %d: %s", hash, resp.Height, resp.TxResult.Code, resp.TxResult.Log)
+			}
+
+			logger.Debug("📦 Transaction included", "hash", hash, "height", resp.Height, "latency", time.Since(start).Round(time.Millisecond))
+			return resp.Height, nil
+		}
+		if !strings.Contains(err.Error(), "not found") {
+			return 0, fmt.Errorf("failed to fetch transaction %s: %w", hash, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return 0, fmt.Errorf("transaction %s not included after %s: %w", hash, timeout, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// waitForHeight polls the node status until the latest block height reaches height.
+// Status errors are logged and polling continues, until the context is cancelled.
+func waitForHeight(ctx context.Context, client cosmosclient.Client, height uint64, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		reached, latest, err := heightReached(ctx, client, height)
+		if err != nil {
+			logger.Warn("⚠️ Failed to fetch node status", "error", err)
+		} else if reached {
+			logger.Info("🏁 Reached block height, starting", "height", latest)
+			return nil
+		}
+
+		logger.Info("⏳ Waiting for block height", "height", height)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("block height %d not reached: %w", height, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// waitForSync polls the node status until the node is no longer catching up, failing after timeout.
+// Status errors are logged and polling continues, as freshly started nodes may not answer yet.
+func waitForSync(ctx context.Context, client cosmosclient.Client, interval, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		status, err := client.RPC.Status(ctx)
+		if err != nil {
+			logger.Warn("⚠️ Failed to fetch node status", "error", err)
+		} else if !status.SyncInfo.CatchingUp {
+			logger.Info("🔄 Node is synced", "height", status.SyncInfo.LatestBlockHeight)
+			return nil
+		} else {
+			logger.Info("⏳ Waiting for the node to sync", "height", status.SyncInfo.LatestBlockHeight)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("node not synced after %s: %w", timeout, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// stopAtHeight wraps send to query the block height every interval successful transactions,
+// calling stop once the chain reaches height
+func stopAtHeight(client cosmosclient.Client, height, interval uint64, send sendFunc, stop context.CancelFunc) sendFunc {
+	var sent atomic.Uint64
+
+	return func(ctx context.Context, txNum, sequence uint64) error {
+		if err := send(ctx, txNum, sequence); err != nil {
+			return err
+		}
+
+		if sent.Add(1)%interval != 0 {
+			return nil
+		}
+
+		reached, latest, err := heightReached(ctx, client, height)
+		if err != nil {
+			logger.Warn("⚠️ Failed to fetch node status", "error", err)
+			return nil
+		}
+		if reached {
+			logger.Info("🏁 Reached block height, stopping", "height", latest)
+			stop()
+		}
+
+		return nil
+	}
+}
+
+// txTimeoutHeight returns the height after which a transaction expires, offset blocks after the latest block of the node
+func txTimeoutHeight(ctx context.Context, client cosmosclient.Client, offset uint64) (uint64, error) {
+	status, err := client.RPC.Status(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch node status: %w", err)
+	}
+
+	return uint64(max(status.SyncInfo.LatestBlockHeight, 0)) + offset, nil
+}
+
+// heightReached reports whether the latest block height of the node reached height, along with the latest height
+func heightReached(ctx context.Context, client cosmosclient.Client, height uint64) (bool, int64, error) {
+	status, err := client.RPC.Status(ctx)
+	if err != nil {
+		return false, 0, err
+	}
+
+	latest := status.SyncInfo.LatestBlockHeight
+	return latest >= 0 && uint64(latest) >= height, latest, nil
+}
@@ -0,0 +1,310 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/ignite/cli/v29/ignite/pkg/cosmosclient"
+	"gotest.tools/v3/assert"
+)
+
+// pendingTxRPC reports a transaction as not found for a number of lookups before returning it
+type pendingTxRPC struct {
+	rpcclient.Client
+	pending int
+	result  *ctypes.ResultTx
+	err     error
+	calls   *int
+}
+
+func (r pendingTxRPC) Tx(context.Context, []byte, bool) (*ctypes.ResultTx, error) {
+	*r.calls++
+	if *r.calls <= r.pending {
+		return nil, errors.New("tx (ABCD) not found")
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	return r.result, nil
+}
+
+func TestWaitForInclusion(t *testing.T) {
+	const hash = "ABCD"
+
+	tests := []struct {
+		name       string
+		hash       string
+		rpc        pendingTxRPC
+		timeout    time.Duration
+		wantHeight int64
+		wantErr    string
+		calls      int
+	}{
+		{
+			name:       "included immediately",
+			hash:       hash,
+			rpc:        pendingTxRPC{result: &ctypes.ResultTx{Height: 10}},
+			wantHeight: 10,
+			calls:      1,
+		},
+		{
+			name:       "included after polling",
+			hash:       hash,
+			rpc:        pendingTxRPC{pending: 2, result: &ctypes.ResultTx{Height: 12}},
+			wantHeight: 12,
+			calls:      3,
+		},
+		{
+			name:    "included but failed",
+			hash:    hash,
+			rpc:     pendingTxRPC{result: &ctypes.ResultTx{Height: 10, TxResult: abci.ExecTxResult{Code: 5, Log: "insufficient funds"}}},
+			wantErr: "failed with code 5",
+			calls:   1,
+		},
+		{
+			name:    "rpc error",
+			hash:    hash,
+			rpc:     pendingTxRPC{err: errors.New("connection refused")},
+			wantErr: "connection refused",
+			calls:   1,
+		},
+		{
+			name:    "timeout",
+			hash:    hash,
+			rpc:     pendingTxRPC{pending: 100},
+			timeout: 100 * time.Millisecond,
+			wantErr: "not included after",
+			calls:   1,
+		},
+		{
+			name:    "invalid hash",
+			hash:    "not-hex",
+			wantErr: "invalid transaction hash",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+			tt.rpc.calls = &calls
+
+			timeout := tt.timeout
+			if timeout == 0 {
+				timeout = 5 * time.Second
+			}
+
+			height, err := waitForInclusion(context.Background(), cosmosclient.Client{RPC: tt.rpc}, tt.hash, timeout)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NilError(t, err)
+				assert.Equal(t, height, tt.wantHeight)
+			}
+			assert.Equal(t, calls, tt.calls)
+		})
+	}
+}
+
+// growingChainRPC reports a block height increasing by one on each status lookup
+type growingChainRPC struct {
+	rpcclient.Client
+	height *int64
+	err    error
+}
+
+func (r growingChainRPC) Status(context.Context) (*ctypes.ResultStatus, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	*r.height++
+	return &ctypes.ResultStatus{SyncInfo: ctypes.SyncInfo{LatestBlockHeight: *r.height}}, nil
+}
+
+func TestWaitForHeight(t *testing.T) {
+	tests := []struct {
+		name         string
+		startHeight  int64
+		target       uint64
+		expectHeight int64
+	}{
+		{
+			name:         "already reached",
+			startHeight:  20,
+			target:       10,
+			expectHeight: 21,
+		},
+		{
+			name:         "reached after polling",
+			startHeight:  5,
+			target:       8,
+			expectHeight: 8,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			height := tt.startHeight
+			rpc := growingChainRPC{height: &height}
+
+			err := waitForHeight(context.Background(), cosmosclient.Client{RPC: rpc}, tt.target, time.Millisecond)
+			assert.NilError(t, err)
+			assert.Equal(t, height, tt.expectHeight)
+		})
+	}
+}
+
+func TestWaitForHeightCancelled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	var height int64
+	rpc := growingChainRPC{height: &height, err: errors.New("connection refused")}
+
+	err := waitForHeight(ctx, cosmosclient.Client{RPC: rpc}, 10, time.Millisecond)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestStopAtHeight(t *testing.T) {
+	tests := []struct {
+		name         string
+		sends        int
+		interval     uint64
+		expectLookup int64
+		expectStop   bool
+	}{
+		{
+			name:         "below poll interval",
+			sends:        2,
+			interval:     3,
+			expectLookup: 0,
+			expectStop:   false,
+		},
+		{
+			name:         "height not reached",
+			sends:        3,
+			interval:     3,
+			expectLookup: 1,
+			expectStop:   false,
+		},
+		{
+			name:         "height reached",
+			sends:        6,
+			interval:     3,
+			expectLookup: 2,
+			expectStop:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var height int64
+			rpc := growingChainRPC{height: &height}
+
+			ctx, stop := context.WithCancel(context.Background())
+			defer stop()
+
+			send := stopAtHeight(cosmosclient.Client{RPC: rpc}, 2, tt.interval, func(context.Context, uint64, uint64) error {
+				return nil
+			}, stop)
+			for i := range tt.sends {
+				assert.NilError(t, send(ctx, uint64(i), uint64(i)))
+			}
+
+			assert.Equal(t, height, tt.expectLookup)
+			assert.Equal(t, ctx.Err() != nil, tt.expectStop)
+		})
+	}
+}
+
+func TestStopAtHeightSkipsFailures(t *testing.T) {
+	var height int64
+	rpc := growingChainRPC{height: &height}
+
+	ctx, stop := context.WithCancel(context.Background())
+	defer stop()
+
+	// Failed transactions do not count towards the poll interval
+	send := stopAtHeight(cosmosclient.Client{RPC: rpc}, 1, 1, func(context.Context, uint64, uint64) error {
+		return errors.New("insufficient fees")
+	}, stop)
+	assert.ErrorContains(t, send(ctx, 0, 0), "insufficient fees")
+	assert.Equal(t, height, int64(0))
+	assert.NilError(t, ctx.Err())
+}
+
+// catchingUpRPC reports the node as catching up for a number of status lookups
+type catchingUpRPC struct {
+	rpcclient.Client
+	catchingUp int
+	calls      *int
+}
+
+func (r catchingUpRPC) Status(context.Context) (*ctypes.ResultStatus, error) {
+	*r.calls++
+	if *r.calls == 1 {
+		return nil, errors.New("connection refused")
+	}
+	return &ctypes.ResultStatus{SyncInfo: ctypes.SyncInfo{CatchingUp: *r.calls <= r.catchingUp}}, nil
+}
+
+func TestTxTimeoutHeight(t *testing.T) {
+	height := int64(99)
+	client := cosmosclient.Client{RPC: growingChainRPC{height: &height}}
+
+	timeoutHeight, err := txTimeoutHeight(context.Background(), client, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, timeoutHeight, uint64(110))
+
+	client = cosmosclient.Client{RPC: growingChainRPC{height: &height, err: errors.New("connection refused")}}
+	_, err = txTimeoutHeight(context.Background(), client, 10)
+	assert.ErrorContains(t, err, "connection refused")
+}
+
+func TestWaitForSync(t *testing.T) {
+	tests := []struct {
+		name       string
+		catchingUp int
+		timeout    time.Duration
+		wantErr    string
+		calls      int
+	}{
+		{
+			name:    "synced after a status error",
+			timeout: time.Second,
+			calls:   2,
+		},
+		{
+			name:       "synced after catching up",
+			catchingUp: 4,
+			timeout:    time.Second,
+			calls:      5,
+		},
+		{
+			name:       "timeout",
+			catchingUp: 1000,
+			timeout:    50 * time.Millisecond,
+			wantErr:    "node not synced after 50ms",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+			rpc := catchingUpRPC{catchingUp: tt.catchingUp, calls: &calls}
+
+			err := waitForSync(context.Background(), cosmosclient.Client{RPC: rpc}, time.Millisecond, tt.timeout)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				assert.ErrorIs(t, err, context.DeadlineExceeded)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, calls, tt.calls)
+		})
+	}
+}
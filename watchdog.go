@@ -0,0 +1,38 @@
+package main
+
+import (
+	"time"
+)
+
+// DefaultStallMaxCount is the default number of stalls after which the run is stopped
+const DefaultStallMaxCount = 3
+
+// WatchdogTimer fires when it is not kicked for its timeout.
+// It is kicked every time a transaction is sent, so that it only fires when the run stalls.
+type WatchdogTimer struct {
+	timeout time.Duration
+	timer   *time.Timer
+}
+
+// NewWatchdogTimer creates a watchdog timer firing after timeout unless kicked
+func NewWatchdogTimer(timeout time.Duration) *WatchdogTimer {
+	return &WatchdogTimer{
+		timeout: timeout,
+		timer:   time.NewTimer(timeout),
+	}
+}
+
+// Kick restarts the timeout. A fire not yet received from C is discarded.
+func (w *WatchdogTimer) Kick() {
+	w.timer.Reset(w.timeout)
+}
+
+// C returns the channel receiving the time the watchdog fired
+func (w *WatchdogTimer) C() <-chan time.Time {
+	return w.timer.C
+}
+
+// Stop stops the watchdog timer
+func (w *WatchdogTimer) Stop() {
+	w.timer.Stop()
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestWatchdogTimer(t *testing.T) {
+	const timeout = 50 * time.Millisecond
+
+	watchdog := NewWatchdogTimer(timeout)
+	defer watchdog.Stop()
+
+	// Kicking the watchdog before its timeout delays the fire
+	start := time.Now()
+	for range 4 {
+		time.Sleep(timeout / 2)
+		watchdog.Kick()
+	}
+
+	fired := <-watchdog.C()
+	assert.Assert(t, fired.Sub(start) >= 2*timeout+timeout, "fired after %s", fired.Sub(start))
+
+	// A fire not received before the kick is discarded
+	time.Sleep(2 * timeout)
+	watchdog.Kick()
+	select {
+	case <-watchdog.C():
+		t.Fatal("watchdog fired right after being kicked")
+	case <-time.After(timeout / 2):
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// parseWeightMap parses a comma-separated list of tx-type=weight pairs, e.g. bank-send=70,gov-vote=30
+func parseWeightMap(value string) (map[string]uint64, error) {
+	weights := make(map[string]uint64)
+	if value == "" {
+		return weights, nil
+	}
+
+	for pair := range strings.SplitSeq(value, ",") {
+		txType, weight, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid weight map entry %q, must be tx-type=weight", pair)
+		}
+
+		w, err := strconv.ParseUint(weight, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid weight %q for %s: %w", weight, txType, err)
+		}
+		weights[txType] = w
+	}
+
+	return weights, nil
+}
+
+// weightMapValue is the flag value of --weight-map, parsed into a map of weights per transaction type
+type weightMapValue struct {
+	weights *map[string]uint64
+}
+
+// String returns the weights as sorted tx-type=weight pairs
+func (v weightMapValue) String() string {
+	if v.weights == nil {
+		return ""
+	}
+
+	pairs := make([]string, 0, len(*v.weights))
+	for txType, weight := range *v.weights {
+		pairs = append(pairs, fmt.Sprintf("%s=%d", txType, weight))
+	}
+	slices.Sort(pairs)
+
+	return strings.Join(pairs, ",")
+}
+
+// Set parses the weights
+func (v weightMapValue) Set(value string) error {
+	weights, err := parseWeightMap(value)
+	if err != nil {
+		return err
+	}
+	*v.weights = weights
+
+	return nil
+}
+
+// Type returns the type shown in the flag usage
+func (v weightMapValue) Type() string {
+	return "string"
+}
+
+// WeightedSelector picks transaction types at random, in proportion to their weight.
+// The types are walked in sorted order, so that a seed always produces the same sequence.
+type WeightedSelector struct {
+	txTypes    []string
+	cumulative []uint64
+	total      uint64
+
+	// mu guards rng, which is not safe for concurrent use
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// NewWeightedSelector creates a selector of the transaction types of weights, seeded with seed.
+// Types with a weight of 0 are never selected.
+func NewWeightedSelector(weights map[string]uint64, seed int64) *WeightedSelector {
+	s := &WeightedSelector{
+		rng: rand.New(rand.NewSource(seed)),
+	}
+	for _, txType := range slices.Sorted(maps.Keys(weights)) {
+		if weights[txType] == 0 {
+			continue
+		}
+		s.total += weights[txType]
+		s.txTypes = append(s.txTypes, txType)
+		s.cumulative = append(s.cumulative, s.total)
+	}
+
+	return s
+}
+
+// Select returns the next transaction type
+func (s *WeightedSelector) Select() string {
+	s.mu.Lock()
+	n := uint64(s.rng.Int63n(int64(s.total)))
+	s.mu.Unlock()
+
+	i, _ := slices.BinarySearchFunc(s.cumulative, n, func(c, n uint64) int {
+		if c <= n {
+			return -1
+		}
+		return 1
+	})
+
+	return s.txTypes[i]
+}
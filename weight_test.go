@@ -0,0 +1,78 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestParseWeightMap(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    map[string]uint64
+		wantErr string
+	}{
+		{
+			name:  "empty",
+			value: "",
+			want:  map[string]uint64{},
+		},
+		{
+			name:  "multiple entries with spaces",
+			value: "bank-send=70, gov-vote=30",
+			want:  map[string]uint64{txTypeBankSend: 70, txTypeGovVote: 30},
+		},
+		{
+			name:    "missing weight",
+			value:   "bank-send",
+			wantErr: "must be tx-type=weight",
+		},
+		{
+			name:    "invalid weight",
+			value:   "gov-vote=-1",
+			wantErr: "invalid weight",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseWeightMap(tt.value)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, got, tt.want)
+		})
+	}
+}
+
+func TestWeightedSelectorDistribution(t *testing.T) {
+	weights := map[string]uint64{txTypeBankSend: 70, txTypeGovVote: 20, txTypeStakingUndelegate: 10, txTypeAuthzGrant: 0}
+	const selections = 10000
+
+	selector := NewWeightedSelector(weights, 42)
+	counts := make(map[string]int)
+	for range selections {
+		counts[selector.Select()]++
+	}
+
+	// Types with a weight of 0 are never selected
+	assert.Equal(t, counts[txTypeAuthzGrant], 0)
+
+	for txType, weight := range weights {
+		got := float64(counts[txType]) / selections * 100
+		assert.Assert(t, math.Abs(got-float64(weight)) <= 5, "%s selected %.1f%% of the time, expected %d%%", txType, got, weight)
+	}
+}
+
+func TestWeightedSelectorSeed(t *testing.T) {
+	weights := map[string]uint64{txTypeBankSend: 50, txTypeGovVote: 50}
+
+	first, second := NewWeightedSelector(weights, 7), NewWeightedSelector(weights, 7)
+	for range 100 {
+		assert.Equal(t, first.Select(), second.Select())
+	}
+}